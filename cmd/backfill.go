@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/dusanbre/otg-sports-api/internal/goalserve"
+	"github.com/dusanbre/otg-sports-api/internal/services"
+	"github.com/joho/godotenv"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backfillSport string
+	backfillFrom  string
+	backfillTo    string
+	backfillForce bool
+)
+
+var backfillCmd = &cobra.Command{
+	Use:   "backfill",
+	Short: "Re-fetch past days from GoalServe",
+	Long: `Fetch historical GoalServe feeds day by day and upsert the matches,
+picking up late score corrections after a match has left the today feed.
+
+Days within the last week use the d-N feeds; older days use the
+?date=dd.MM.yyyy historical feed. Completed days are recorded, so an
+interrupted run resumes where it stopped; use --force to fetch them again.
+
+Examples:
+  otg-sport-api backfill --sport soccer --from 2026-09-01 --to 2026-09-30
+  otg-sport-api backfill --sport basketball --from 2026-10-12 --to 2026-10-14 --force`,
+	Run: runBackfill,
+}
+
+func init() {
+	rootCmd.AddCommand(backfillCmd)
+	backfillCmd.Flags().StringVarP(&backfillSport, "sport", "s", "", "Sport to backfill: soccer or basketball (required)")
+	backfillCmd.Flags().StringVar(&backfillFrom, "from", "", "First day to fetch, YYYY-MM-DD in GOALSERVE_TIMEZONE (required)")
+	backfillCmd.Flags().StringVar(&backfillTo, "to", "", "Last day to fetch, YYYY-MM-DD in GOALSERVE_TIMEZONE (default: yesterday)")
+	backfillCmd.Flags().BoolVar(&backfillForce, "force", false, "Fetch days already completed by an earlier run")
+	backfillCmd.MarkFlagRequired("sport")
+	backfillCmd.MarkFlagRequired("from")
+}
+
+func runBackfill(cmd *cobra.Command, args []string) {
+	// Load environment variables
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: .env file not found, using environment variables")
+	}
+
+	sport := strings.ToLower(strings.TrimSpace(backfillSport))
+	if sport != "soccer" && sport != "basketball" {
+		log.Fatalf("Invalid sport: %s. Valid options: soccer, basketball", backfillSport)
+	}
+
+	from, err := time.Parse("2006-01-02", backfillFrom)
+	if err != nil {
+		log.Fatalf("Invalid --from date %q, expected YYYY-MM-DD", backfillFrom)
+	}
+
+	to := goalserve.FeedDay(time.Now()).AddDate(0, 0, -1)
+	if backfillTo != "" {
+		if to, err = time.Parse("2006-01-02", backfillTo); err != nil {
+			log.Fatalf("Invalid --to date %q, expected YYYY-MM-DD", backfillTo)
+		}
+	}
+	if to.Before(from) {
+		log.Fatalf("--to (%s) is before --from (%s)", to.Format("2006-01-02"), from.Format("2006-01-02"))
+	}
+	if _, last := goalserve.DayFeedWindow(time.Now()); to.After(last) {
+		log.Fatalf("Goalserve day feeds end at %s; --to %s is out of range", last.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+
+	// Match seasons are named from the season_start of the standings rules
+	if err := services.LoadSeasonRules(); err != nil {
+		log.Fatalf("Failed to load standings rules: %v", err)
+	}
+
+	// Get database instance
+	db, err := database.GetInstance()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	// Cancelled on SIGINT/SIGTERM; completed days are kept for the next run
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	backfill := services.NewBackfillService(db)
+
+	fmt.Println()
+	fmt.Printf("%-12s %-10s %-10s %-10s %-10s %s\n", "DAY", "INSERTED", "UPDATED", "UNCHANGED", "FAILED", "NOTE")
+	fmt.Println(strings.Repeat("-", 70))
+
+	total, err := backfill.Run(ctx, services.BackfillOptions{
+		Sport: sport,
+		From:  from,
+		To:    to,
+		Force: backfillForce,
+	}, func(day services.BackfillDay) {
+		date := day.Day.Format("2006-01-02")
+		switch {
+		case day.Skipped:
+			fmt.Printf("%-12s %-10s %-10s %-10s %-10s %s\n", date, "-", "-", "-", "-", "already completed")
+		case day.Err != nil:
+			fmt.Printf("%-12s %-10s %-10s %-10s %-10s %s\n", date, "-", "-", "-", "-", day.Err)
+		default:
+			r := day.Result
+			fmt.Printf("%-12s %-10d %-10d %-10d %-10d\n", date, r.Inserted, r.Updated, r.Unchanged, r.Failed)
+		}
+	})
+
+	fmt.Println(strings.Repeat("-", 70))
+	fmt.Printf("%-12s %-10d %-10d %-10d %-10d\n", "TOTAL", total.Inserted, total.Updated, total.Unchanged, total.Failed)
+	fmt.Println()
+
+	if errors.Is(err, context.Canceled) {
+		log.Println("Backfill interrupted; run the same command again to resume")
+		return
+	}
+	if err != nil {
+		logSyncError(sport, err)
+		log.Fatal("Backfill stopped")
+	}
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/dusanbre/otg-sports-api/internal/predictions"
+	"github.com/joho/godotenv"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backtestSport string
+	backtestFrom  string
+	backtestTo    string
+)
+
+var backtestCmd = &cobra.Command{
+	Use:   "backtest",
+	Short: "Score match predictions against stored results",
+	Long: `Predict every finished match in a date range as it would have been
+predicted on its day, from the results stored before that day, and report
+how well the predictions were calibrated: Brier score, log loss, mean
+absolute error of the expected score, and predicted against observed
+outcome rates by probability bucket.
+
+Soccer uses the Poisson model fit per league, basketball the Elo rating
+difference. PREDICTION_LOOKBACK and PREDICTION_MIN_MATCHES apply as in the
+API.
+
+--from and --to take a date (YYYY-MM-DD, UTC) or an RFC 3339 time.
+
+Examples:
+  otg-sport-api backtest --sport soccer --from 2026-01-01
+  otg-sport-api backtest --sport basketball --from 2025-10-01 --to 2026-06-30`,
+	Args: cobra.NoArgs,
+	Run:  runBacktest,
+}
+
+func init() {
+	rootCmd.AddCommand(backtestCmd)
+	backtestCmd.Flags().StringVarP(&backtestSport, "sport", "s", "", "Sport to backtest: soccer or basketball (required)")
+	backtestCmd.Flags().StringVar(&backtestFrom, "from", "", "Score matches kicking off at or after this time (required)")
+	backtestCmd.Flags().StringVar(&backtestTo, "to", "", "Score matches kicking off before this time (default: now)")
+	backtestCmd.MarkFlagRequired("sport")
+	backtestCmd.MarkFlagRequired("from")
+}
+
+func runBacktest(cmd *cobra.Command, args []string) {
+	// Load environment variables
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: .env file not found, using environment variables")
+	}
+
+	sport := strings.ToLower(strings.TrimSpace(backtestSport))
+	if sport != "soccer" && sport != "basketball" {
+		log.Fatalf("Invalid sport: %s. Valid options: soccer, basketball", backtestSport)
+	}
+
+	from, err := parseReprocessTime(backtestFrom)
+	if err != nil {
+		log.Fatalf("Invalid --from %q: %v", backtestFrom, err)
+	}
+	to := time.Now()
+	if backtestTo != "" {
+		if to, err = parseReprocessTime(backtestTo); err != nil {
+			log.Fatalf("Invalid --to %q: %v", backtestTo, err)
+		}
+	}
+	if !to.After(from) {
+		log.Fatalf("--to (%s) is not after --from (%s)", to.Format(time.RFC3339), from.Format(time.RFC3339))
+	}
+
+	opts, err := predictions.OptionsFromEnv()
+	if err != nil {
+		log.Fatalf("Invalid prediction options: %v", err)
+	}
+
+	// Get database instance
+	db, err := database.GetInstance()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	report, err := predictions.NewService(db, opts).Backtest(ctx, sport, from, to)
+	if errors.Is(err, context.Canceled) {
+		log.Println("Backtest interrupted")
+		return
+	}
+	if err != nil {
+		log.Fatalf("Backtest failed: %v", err)
+	}
+
+	fmt.Println()
+	fmt.Printf("Sport:      %s\n", report.Sport)
+	fmt.Printf("Range:      %s to %s\n", report.From.UTC().Format(time.RFC3339), report.To.UTC().Format(time.RFC3339))
+	fmt.Printf("Predicted:  %d matches (%d skipped without enough history)\n", report.Matches, report.Skipped)
+	if report.Matches == 0 {
+		fmt.Println()
+		return
+	}
+	fmt.Printf("Brier:      %.4f\n", report.Brier)
+	fmt.Printf("Log loss:   %.4f\n", report.LogLoss)
+	fmt.Printf("Score MAE:  %.2f\n", report.ScoreMAE)
+	fmt.Println()
+
+	fmt.Printf("%-12s %-12s %-12s %s\n", "PREDICTED", "OUTCOMES", "MEAN", "OBSERVED")
+	fmt.Println(strings.Repeat("-", 50))
+	for _, b := range report.Buckets {
+		bucket := fmt.Sprintf("%.0f-%.0f%%", b.From*100, b.To*100)
+		if b.Predictions == 0 {
+			fmt.Printf("%-12s %-12d %-12s %s\n", bucket, 0, "-", "-")
+			continue
+		}
+		fmt.Printf("%-12s %-12d %-12.3f %.3f\n", bucket, b.Predictions, b.Predicted, b.Observed)
+	}
+	fmt.Println()
+}
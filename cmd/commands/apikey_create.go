@@ -15,6 +15,7 @@ var (
 	keyName   string
 	keySports string
 	rateLimit int
+	keyAdmin  bool
 )
 
 var ApiKeyCreateCmd = &cobra.Command{
@@ -24,7 +25,8 @@ var ApiKeyCreateCmd = &cobra.Command{
 Examples:
   otg-sport-api apikey create --name "Mobile App" --sports soccer,basketball
   otg-sport-api apikey create --name "Admin Key" --sports "*"
-  otg-sport-api apikey create --name "Soccer Only" --sports soccer --rate-limit 200`,
+  otg-sport-api apikey create --name "Soccer Only" --sports soccer --rate-limit 200
+  otg-sport-api apikey create --name "On-call" --sports "*" --admin`,
 	Run: runApiKeyCreate,
 }
 
@@ -32,6 +34,7 @@ func init() {
 	ApiKeyCreateCmd.Flags().StringVarP(&keyName, "name", "n", "", "Name/description for the API key (required)")
 	ApiKeyCreateCmd.Flags().StringVarP(&keySports, "sports", "s", "", "Comma-separated sports (soccer,basketball) or * for all (required)")
 	ApiKeyCreateCmd.Flags().IntVarP(&rateLimit, "rate-limit", "r", 100, "Rate limit (requests per minute)")
+	ApiKeyCreateCmd.Flags().BoolVar(&keyAdmin, "admin", false, "Allow access to the /api/v1/admin endpoints")
 	ApiKeyCreateCmd.MarkFlagRequired("name")
 	ApiKeyCreateCmd.MarkFlagRequired("sports")
 }
@@ -75,7 +78,7 @@ func runApiKeyCreate(cmd *cobra.Command, args []string) {
 	}
 
 	// Insert into database
-	_, err = db.CreateApiKey(keyHash, keyPrefix, keyName, sports, rateLimit)
+	_, err = db.CreateApiKey(keyHash, keyPrefix, keyName, sports, rateLimit, keyAdmin)
 	if err != nil {
 		log.Fatalf("Failed to create API key: %v", err)
 	}
@@ -87,6 +90,7 @@ func runApiKeyCreate(cmd *cobra.Command, args []string) {
 	fmt.Printf("  Name:       %s\n", keyName)
 	fmt.Printf("  Sports:     %s\n", strings.Join(sports, ", "))
 	fmt.Printf("  Rate Limit: %d req/min\n", rateLimit)
+	fmt.Printf("  Admin:      %t\n", keyAdmin)
 	fmt.Printf("  Key:        %s\n", plainKey)
 	fmt.Println()
 	fmt.Println("  ⚠️  Save this key now - it won't be shown again!")
@@ -43,8 +43,8 @@ func runApiKeyList(cmd *cobra.Command, args []string) {
 
 	// Print header
 	fmt.Println()
-	fmt.Printf("%-6s %-15s %-25s %-25s %-10s %-10s\n", "ID", "PREFIX", "NAME", "SPORTS", "RATE", "STATUS")
-	fmt.Println(strings.Repeat("-", 95))
+	fmt.Printf("%-6s %-15s %-25s %-25s %-10s %-10s %-6s\n", "ID", "PREFIX", "NAME", "SPORTS", "RATE", "STATUS", "ADMIN")
+	fmt.Println(strings.Repeat("-", 102))
 
 	// Print each key
 	for _, k := range keys {
@@ -63,8 +63,13 @@ func runApiKeyList(cmd *cobra.Command, args []string) {
 			name = name[:20] + "..."
 		}
 
-		fmt.Printf("%-6d %-15s %-25s %-25s %-10d %-10s\n",
-			k.ID, k.KeyPrefix, name, sports, k.RateLimit, status)
+		admin := "no"
+		if k.IsAdmin {
+			admin = "yes"
+		}
+
+		fmt.Printf("%-6d %-15s %-25s %-25s %-10d %-10s %-6s\n",
+			k.ID, k.KeyPrefix, name, sports, k.RateLimit, status, admin)
 	}
 	fmt.Println()
 }
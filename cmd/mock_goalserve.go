@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/dusanbre/otg-sports-api/internal/goalserve/mock"
+	"github.com/spf13/cobra"
+)
+
+var (
+	mockPort   string
+	mockConfig mock.Config
+)
+
+var mockGoalserveCmd = &cobra.Command{
+	Use:   "mock-goalserve",
+	Short: "Run a local mock of the GoalServe feed API",
+	Long: `Run a local HTTP server that imitates the GoalServe feed API with
+generated leagues, teams and matches.
+
+Served feeds:
+  - /getfeed/{key}/soccernew/{home|d-N|dN}
+  - /getfeed/{key}/bsktbl/{home|d-N|dN}
+  - ?date=dd.MM.yyyy selects a specific day
+  - /getfeed/{key}/h2h/{team1}/{team2}
+  - /getfeed/{key}/bsktbl/h2h_{team1}-{team2}
+
+Matches move through their statuses in real time, so a running sync
+sees kickoffs, score changes and final results. Point GOALSERVE_URL at
+http://localhost:<port> to run sync and serve end to end.
+
+Fault injection:
+  --latency with --slow-rate  delay a share of responses
+  --error-rate                answer a share of requests with 500
+  --truncate-rate             cut a share of responses off mid-JSON`,
+	Run: runMockGoalserve,
+}
+
+func init() {
+	rootCmd.AddCommand(mockGoalserveCmd)
+	mockGoalserveCmd.Flags().StringVarP(&mockPort, "port", "p", "9090", "Port to run the mock server on")
+	mockGoalserveCmd.Flags().StringVar(&mockConfig.APIKey, "key", "", "API key to require in the feed path (default: accept any)")
+	mockGoalserveCmd.Flags().Uint64Var(&mockConfig.Seed, "seed", 1, "Seed for the generated leagues, teams and fixtures")
+	mockGoalserveCmd.Flags().IntVar(&mockConfig.Leagues, "leagues", 4, "Number of leagues per sport")
+	mockGoalserveCmd.Flags().IntVar(&mockConfig.MatchesPerLeague, "matches", 6, "Number of matches per league per day (max 10)")
+	mockGoalserveCmd.Flags().DurationVar(&mockConfig.Latency, "latency", 5*time.Second, "Delay added to slow responses")
+	mockGoalserveCmd.Flags().Float64Var(&mockConfig.SlowRate, "slow-rate", 0, "Fraction of responses delayed by --latency (0-1)")
+	mockGoalserveCmd.Flags().Float64Var(&mockConfig.ErrorRate, "error-rate", 0, "Fraction of requests answered with 500 (0-1)")
+	mockGoalserveCmd.Flags().Float64Var(&mockConfig.TruncateRate, "truncate-rate", 0, "Fraction of responses truncated mid-JSON (0-1)")
+}
+
+func runMockGoalserve(cmd *cobra.Command, args []string) {
+	if mockConfig.MatchesPerLeague > 10 {
+		mockConfig.MatchesPerLeague = 10
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%s", mockPort),
+		Handler: mock.NewServer(mockConfig).Handler(),
+	}
+
+	go func() {
+		fmt.Printf("Starting mock GoalServe on port %s (GOALSERVE_URL=http://localhost:%s)...\n", mockPort, mockPort)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Failed to start mock server: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+
+	log.Println("Shutting down mock server...")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Error shutting down mock server: %v", err)
+	}
+
+	log.Println("Mock server stopped")
+}
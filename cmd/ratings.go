@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/dusanbre/otg-sports-api/internal/ratings"
+	"github.com/joho/godotenv"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ratingsSport   string
+	ratingsRebuild bool
+)
+
+var ratingsCmd = &cobra.Command{
+	Use:   "ratings",
+	Short: "Update the Elo team ratings",
+	Long: `Rate the finished soccer and basketball matches that have no rating yet.
+The sync does this after every today sync; run it by hand after a backfill.
+
+Ratings are replayed from the earliest match that is unrated or whose
+score changed after it was rated. --rebuild replays every match instead,
+for instance after the rating model changed.
+
+Examples:
+  otg-sport-api ratings
+  otg-sport-api ratings --sport basketball --rebuild`,
+	Args: cobra.NoArgs,
+	Run:  runRatings,
+}
+
+func init() {
+	rootCmd.AddCommand(ratingsCmd)
+	ratingsCmd.Flags().StringVarP(&ratingsSport, "sport", "s", "", "Sport to rate: soccer or basketball (default: both)")
+	ratingsCmd.Flags().BoolVar(&ratingsRebuild, "rebuild", false, "Recompute every rating from the first match")
+}
+
+func runRatings(cmd *cobra.Command, args []string) {
+	// Load environment variables
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: .env file not found, using environment variables")
+	}
+
+	sports := []string{"soccer", "basketball"}
+	if ratingsSport != "" {
+		sport := strings.ToLower(strings.TrimSpace(ratingsSport))
+		if sport != "soccer" && sport != "basketball" {
+			log.Fatalf("Invalid sport: %s. Valid options: soccer, basketball", ratingsSport)
+		}
+		sports = []string{sport}
+	}
+
+	// Get database instance
+	db, err := database.GetInstance()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	rater := ratings.NewService(db)
+	for _, sport := range sports {
+		var rated int
+		if ratingsRebuild {
+			rated, err = rater.Rebuild(ctx, sport)
+		} else {
+			rated, err = rater.Update(ctx, sport)
+		}
+		if errors.Is(err, context.Canceled) {
+			log.Println("Rating interrupted")
+			return
+		}
+		if err != nil {
+			log.Fatalf("Failed to rate %s matches: %v", sport, err)
+		}
+		log.Printf("%s: %d matches rated", sport, rated)
+	}
+}
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/dusanbre/otg-sports-api/internal/goalserve"
+	"github.com/dusanbre/otg-sports-api/internal/services"
+	"github.com/joho/godotenv"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reprocessSport string
+	reprocessFeed  string
+	reprocessSince string
+	reprocessUntil string
+)
+
+var reprocessCmd = &cobra.Command{
+	Use:   "reprocess",
+	Short: "Re-run the sync over archived GoalServe feeds",
+	Long: `Run archived GoalServe payloads from raw_feeds through the current soccer
+and basketball sync, without fetching anything. Use it after a parser fix
+or a new column to rebuild the matches the archive still covers.
+
+Each match is rebuilt once, from the newest archived payload that listed
+it, so it ends at its latest archived state. The sync archives every
+payload it fetches for RAW_FEED_RETENTION (default 168h; 0 disables the
+archive).
+
+--feed and --until only choose which matches are rebuilt: those listed by
+that feed, or fetched before that time. Their state still comes from the
+newest payload of any feed, so a subset never rolls a match back.
+
+The run takes the sync leadership and refuses to start while a sync worker
+holds it.
+
+--since and --until take a date (YYYY-MM-DD, UTC) or an RFC 3339 time.
+
+Examples:
+  otg-sport-api reprocess --sport soccer --since 2026-10-01
+  otg-sport-api reprocess --feed soccernew/home --since 2026-10-01
+  otg-sport-api reprocess --sport basketball --since 2026-10-01 --until 2026-10-08`,
+	Args: cobra.NoArgs,
+	Run:  runReprocess,
+}
+
+func init() {
+	rootCmd.AddCommand(reprocessCmd)
+	reprocessCmd.Flags().StringVarP(&reprocessSport, "sport", "s", "", "Sport to reprocess: soccer or basketball (default: both, or the sport of --feed)")
+	reprocessCmd.Flags().StringVar(&reprocessFeed, "feed", "", "Rebuild only the matches listed by this feed path, e.g. soccernew/home (default: every feed)")
+	reprocessCmd.Flags().StringVar(&reprocessSince, "since", "", "Read payloads fetched at or after this time (required)")
+	reprocessCmd.Flags().StringVar(&reprocessUntil, "until", "", "Rebuild only the matches listed before this time (default: now)")
+	reprocessCmd.MarkFlagRequired("since")
+}
+
+func runReprocess(cmd *cobra.Command, args []string) {
+	// Load environment variables
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: .env file not found, using environment variables")
+	}
+
+	feed := strings.Trim(strings.TrimSpace(reprocessFeed), "/")
+	sport := strings.ToLower(strings.TrimSpace(reprocessSport))
+	if sport != "" && sport != "soccer" && sport != "basketball" {
+		log.Fatalf("Invalid sport: %s. Valid options: soccer, basketball", reprocessSport)
+	}
+	if feed != "" {
+		feedSport := goalserve.FeedSport(feed)
+		if feedSport == "" {
+			log.Fatalf("Unknown feed %q, expected a soccernew/... or bsktbl/... path", reprocessFeed)
+		}
+		if sport != "" && sport != feedSport {
+			log.Fatalf("Feed %s is a %s feed, not %s", feed, feedSport, sport)
+		}
+		sport = feedSport
+	}
+
+	since, err := parseReprocessTime(reprocessSince)
+	if err != nil {
+		log.Fatalf("Invalid --since %q: %v", reprocessSince, err)
+	}
+	var until time.Time
+	if reprocessUntil != "" {
+		if until, err = parseReprocessTime(reprocessUntil); err != nil {
+			log.Fatalf("Invalid --until %q: %v", reprocessUntil, err)
+		}
+		if !until.After(since) {
+			log.Fatalf("--until (%s) is not after --since (%s)", reprocessUntil, reprocessSince)
+		}
+	}
+
+	// Match seasons are named from the season_start of the standings rules
+	if err := services.LoadSeasonRules(); err != nil {
+		log.Fatalf("Failed to load standings rules: %v", err)
+	}
+
+	// Get database instance
+	db, err := database.GetInstance()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Println()
+	fmt.Printf("%-20s %-40s %-10s %s\n", "FETCHED (UTC)", "FEED", "MATCHES", "NOTE")
+	fmt.Println(strings.Repeat("-", 120))
+
+	total, err := services.NewReprocessService(db).Run(ctx, services.ReprocessOptions{
+		Sport: sport,
+		Feed:  feed,
+		Since: since,
+		Until: until,
+	}, func(f services.ReprocessedFeed) {
+		fetched := f.Feed.FetchedAt.UTC().Format("2006-01-02 15:04:05")
+		switch {
+		case f.Err != nil:
+			fmt.Printf("%-20s %-40s %-10s %s\n", fetched, f.Feed.Feed, "-", f.Err)
+		case !f.Selected:
+			fmt.Printf("%-20s %-40s %-10d %s\n", fetched, f.Feed.Feed, f.Matches, "not selected, newer states only")
+		default:
+			fmt.Printf("%-20s %-40s %-10d\n", fetched, f.Feed.Feed, f.Matches)
+		}
+	})
+
+	fmt.Println(strings.Repeat("-", 120))
+	fmt.Printf("%-10s %-10s %-10s %s\n", "INSERTED", "UPDATED", "UNCHANGED", "FAILED")
+	fmt.Printf("%-10d %-10d %-10d %d\n", total.Inserted, total.Updated, total.Unchanged, total.Failed)
+	fmt.Println()
+
+	if errors.Is(err, context.Canceled) {
+		log.Println("Reprocess interrupted")
+		return
+	}
+	if err != nil {
+		log.Fatalf("Reprocess stopped: %v", err)
+	}
+}
+
+// parseReprocessTime accepts a UTC date (YYYY-MM-DD) or an RFC 3339 time
+func parseReprocessTime(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.New("expected YYYY-MM-DD or an RFC 3339 time")
+	}
+	return t, nil
+}
@@ -13,9 +13,11 @@ var rootCmd = &cobra.Command{
 and exposes it through a REST API.
 
 Available commands:
-  serve  - Start the REST API server
-  sync   - Run the data sync scheduler
-  apikey - Manage API keys`,
+  serve          - Start the REST API server
+  sync           - Run the data sync scheduler
+  apikey         - Manage API keys
+  backfill       - Re-fetch past days from GoalServe
+  mock-goalserve - Run a local mock of the GoalServe feed API`,
 }
 
 // Execute runs the root command
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/dusanbre/otg-sports-api/internal/services"
+	"github.com/dusanbre/otg-sports-api/internal/standings"
+	"github.com/joho/godotenv"
+	"github.com/spf13/cobra"
+)
+
+var seasonsSport string
+
+var seasonsCmd = &cobra.Command{
+	Use:   "seasons",
+	Short: "Regroup stored matches into seasons from the configured season start",
+	Long: `Regroup the stored soccer and basketball matches of every league into the
+seasons named from its season_start in the standings config (STANDINGS_CONFIG,
+default etc/standings.json). Leagues without one start in July.
+
+The sync names the season of each match it writes; run this after changing
+a season_start so matches synced earlier follow it. Seasons left without
+matches are deleted.
+
+Examples:
+  otg-sport-api seasons
+  otg-sport-api seasons --sport soccer`,
+	Args: cobra.NoArgs,
+	Run:  runSeasons,
+}
+
+func init() {
+	rootCmd.AddCommand(seasonsCmd)
+	seasonsCmd.Flags().StringVarP(&seasonsSport, "sport", "s", "", "Sport to regroup: soccer or basketball (default: both)")
+}
+
+func runSeasons(cmd *cobra.Command, args []string) {
+	// Load environment variables
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: .env file not found, using environment variables")
+	}
+
+	sports := []string{"soccer", "basketball"}
+	if seasonsSport != "" {
+		sport := strings.ToLower(strings.TrimSpace(seasonsSport))
+		if sport != "soccer" && sport != "basketball" {
+			log.Fatalf("Invalid sport: %s. Valid options: soccer, basketball", seasonsSport)
+		}
+		sports = []string{sport}
+	}
+
+	standingsConfig, err := standings.ConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load standings rules: %v", err)
+	}
+
+	// Get database instance
+	db, err := database.GetInstance()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	for _, sport := range sports {
+		moved, err := services.RegroupSeasons(ctx, db, standingsConfig, sport)
+		if errors.Is(err, context.Canceled) {
+			log.Println("Season regrouping interrupted")
+			return
+		}
+		if err != nil {
+			log.Fatalf("Failed to regroup %s seasons: %v", sport, err)
+		}
+		log.Printf("%s: %d matches moved season", sport, moved)
+	}
+}
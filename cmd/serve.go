@@ -12,6 +12,9 @@ import (
 
 	"github.com/dusanbre/otg-sports-api/internal/api"
 	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/dusanbre/otg-sports-api/internal/h2h"
+	"github.com/dusanbre/otg-sports-api/internal/predictions"
+	"github.com/dusanbre/otg-sports-api/internal/standings"
 	"github.com/joho/godotenv"
 	"github.com/spf13/cobra"
 )
@@ -29,7 +32,9 @@ The server provides endpoints for:
   - Soccer matches (GET /api/v1/soccer/matches)
   - Basketball matches (GET /api/v1/basketball/matches)
   - Live matches for each sport
-  - League listings
+  - League listings and standings
+  - Head-to-head records, topped up from Goalserve when few are stored
+  - Pre-match outcome probabilities (include=prediction)
 
 Authentication is required via API key:
   - Header: Authorization: Bearer <api_key>
@@ -62,8 +67,25 @@ func runServe(cmd *cobra.Command, args []string) {
 
 	fmt.Println("Successfully connected to database!")
 
-	// Create and start server
-	server := api.NewServer(db, apiPort)
+	standingsConfig, err := standings.ConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load standings rules: %v", err)
+	}
+
+	h2hOptions, err := h2h.OptionsFromEnv()
+	if err != nil {
+		log.Fatalf("Invalid head-to-head options: %v", err)
+	}
+
+	predictionOptions, err := predictions.OptionsFromEnv()
+	if err != nil {
+		log.Fatalf("Invalid prediction options: %v", err)
+	}
+
+	// Create and start server. It never calls Goalserve: head-to-head reads
+	// the h2h feeds the sync worker caches.
+	server := api.NewServer(db, apiPort, standingsConfig,
+		h2h.NewService(db, h2hOptions), predictions.NewService(db, predictionOptions))
 
 	// Start server in goroutine
 	go func() {
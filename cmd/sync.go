@@ -1,34 +1,77 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
-	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
 	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/dusanbre/otg-sports-api/internal/goalserve"
+	"github.com/dusanbre/otg-sports-api/internal/h2h"
+	"github.com/dusanbre/otg-sports-api/internal/ratings"
 	"github.com/dusanbre/otg-sports-api/internal/services"
 	"github.com/go-co-op/gocron/v2"
 	"github.com/joho/godotenv"
 	"github.com/spf13/cobra"
 )
 
+var syncCadence services.SyncCadence
+
+// rawFeedPruneInterval is how often archived payloads past their retention are deleted
+const rawFeedPruneInterval = time.Hour
+
+// Head-to-head pairs queued by the API are fetched h2hFillBatch at a time every h2hFillInterval
+const (
+	h2hFillInterval = 30 * time.Second
+	h2hFillBatch    = 10
+)
+
 var syncCmd = &cobra.Command{
 	Use:   "sync",
 	Short: "Run the data sync scheduler",
-	Long: `Start the data sync scheduler that periodically fetches sports data
-from GoalServe and stores it in the database.
+	Long: `Start the data sync scheduler that fetches sports data from GoalServe
+and stores it in the database, pacing each feed by what it carries:
+
+  - Today's feed every --live-interval while matches are in play or
+    within --kickoff-window of kickoff, otherwise every --today-interval
+  - The next 7 days every --future-interval
+
+Soccer and basketball are paced independently. Intervals can also be
+set with SYNC_LIVE_INTERVAL, SYNC_TODAY_INTERVAL, SYNC_FUTURE_INTERVAL
+and SYNC_KICKOFF_WINDOW; flags take precedence.
+
+Several sync workers can run against the same database. They elect a
+leader through a Postgres advisory lock and only the leader syncs; the
+others stand by and take over within seconds if it stops. The current
+leader is shown by GET /health.
 
-The scheduler runs every minute and syncs:
-  - Soccer matches (today and next 7 days)
-  - Basketball matches (today and next 7 days)`,
+After each today sync the finished matches not rated yet update the Elo
+ratings (see the ratings command).
+
+Every decoded payload is archived gzipped in raw_feeds for
+RAW_FEED_RETENTION (default 168h, 0 disables it) so the reprocess
+command can re-run the sync over it.
+
+The API never calls GoalServe. Head-to-head requests it could not answer
+from h2h_cache are queued, and the leader fetches them every 30s at low
+priority, within the same GOALSERVE_RATE_LIMIT budget as the feeds.
+
+Set GOALSERVE_RECORD_DIR to save every raw feed response, or
+GOALSERVE_REPLAY_DIR to serve feeds from those recordings offline.`,
 	Run: runSync,
 }
 
 func init() {
 	rootCmd.AddCommand(syncCmd)
+	defaults := services.DefaultSyncCadence()
+	syncCmd.Flags().DurationVar(&syncCadence.Live, "live-interval", defaults.Live, "Today feed interval while matches are live or near kickoff")
+	syncCmd.Flags().DurationVar(&syncCadence.Today, "today-interval", defaults.Today, "Today feed interval when nothing is live")
+	syncCmd.Flags().DurationVar(&syncCadence.Future, "future-interval", defaults.Future, "Next 7 days feed interval")
+	syncCmd.Flags().DurationVar(&syncCadence.KickoffWindow, "kickoff-window", defaults.KickoffWindow, "How close to kickoff a match speeds up syncing")
 }
 
 func runSync(cmd *cobra.Command, args []string) {
@@ -37,6 +80,26 @@ func runSync(cmd *cobra.Command, args []string) {
 		log.Println("Warning: .env file not found, using environment variables")
 	}
 
+	cadence, err := resolveSyncCadence(cmd)
+	if err != nil {
+		log.Fatalf("Invalid sync cadence: %v", err)
+	}
+
+	retention, err := services.RawFeedRetentionFromEnv()
+	if err != nil {
+		log.Fatalf("Invalid raw feed retention: %v", err)
+	}
+
+	// Match seasons are named from the season_start of the standings rules
+	if err := services.LoadSeasonRules(); err != nil {
+		log.Fatalf("Failed to load standings rules: %v", err)
+	}
+
+	h2hOptions, err := h2h.OptionsFromEnv()
+	if err != nil {
+		log.Fatalf("Invalid head-to-head options: %v", err)
+	}
+
 	// Get database instance
 	db, err := database.GetInstance()
 	if err != nil {
@@ -46,64 +109,137 @@ func runSync(cmd *cobra.Command, args []string) {
 
 	fmt.Println("Successfully connected to database!")
 
-	// Create sync services
-	soccerSyncService := services.NewSoccerSyncService(db)
-	basketballSyncService := services.NewBasketballSyncService(db)
+	// Cancelled on SIGINT/SIGTERM so in-flight Goalserve requests stop promptly
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Only the elected leader runs the scheduler; standbys wait in Run
+	elector := services.NewLeaderElector(db, database.SyncLeadership)
+	elector.Run(ctx, func(leaderCtx context.Context) {
+		runScheduler(leaderCtx, db, cadence, retention, h2h.NewService(db, h2hOptions))
+	})
+
+	log.Println("Sync stopped")
+}
+
+// runScheduler runs the sync jobs until ctx is done, which happens on
+// shutdown or when this instance loses leadership
+func runScheduler(ctx context.Context, db *database.DB, cadence services.SyncCadence, retention time.Duration, h2hService *h2h.Service) {
+	pacers := []*services.SyncPacer{
+		services.NewSoccerSyncPacer(db, cadence),
+		services.NewBasketballSyncPacer(db, cadence),
+	}
+	rater := ratings.NewService(db)
+
+	// Shares the process-wide rate budget with the sync services' clients
+	h2hClient := goalserve.NewClient()
+	defer h2hClient.Close()
 
 	// Create scheduler
 	scheduler, err := gocron.NewScheduler()
 	if err != nil {
-		log.Fatalf("Failed to create scheduler: %v", err)
+		log.Printf("Failed to create scheduler: %v", err)
+		return
 	}
 
-	// Schedule soccer sync job
-	soccerJob, err := scheduler.NewJob(
-		gocron.DurationJob(1*time.Minute),
-		gocron.NewTask(func() {
-			log.Println("Running scheduled soccer match sync...")
-			if err := soccerSyncService.SyncMatches(); err != nil {
-				log.Printf("Error syncing soccer matches: %v", err)
-			}
-		}),
-	)
-	if err != nil {
-		log.Fatalf("Failed to create soccer job: %v", err)
+	for _, pacer := range pacers {
+		sport := pacer.Sport()
+
+		// Ticks every live interval; the pacer skips ticks while nothing is live
+		todayJob, err := scheduler.NewJob(
+			gocron.DurationJob(cadence.Live),
+			gocron.NewTask(func() {
+				synced, err := pacer.Tick(ctx)
+				if err != nil {
+					logSyncError(sport, err)
+					return
+				}
+				// Matches finish in the today feed, so rate them right after it
+				if synced {
+					if _, err := rater.Update(ctx, sport); err != nil {
+						log.Printf("Error updating %s ratings: %v", sport, err)
+					}
+				}
+			}),
+			gocron.WithName(sport+"-today"),
+			gocron.WithSingletonMode(gocron.LimitModeReschedule),
+			gocron.WithStartAt(gocron.WithStartImmediately()),
+		)
+		if err != nil {
+			log.Printf("Failed to create %s today job: %v", sport, err)
+			scheduler.Shutdown()
+			return
+		}
+		fmt.Printf("Scheduled %s today job with ID: %s - every %s while live, every %s otherwise\n",
+			sport, todayJob.ID(), cadence.Live, cadence.Today)
+
+		futureJob, err := scheduler.NewJob(
+			gocron.DurationJob(cadence.Future),
+			gocron.NewTask(func() {
+				if err := pacer.SyncFuture(ctx); err != nil {
+					logSyncError(sport, err)
+				}
+			}),
+			gocron.WithName(sport+"-future"),
+			gocron.WithSingletonMode(gocron.LimitModeReschedule),
+			gocron.WithStartAt(gocron.WithStartImmediately()),
+		)
+		if err != nil {
+			log.Printf("Failed to create %s future job: %v", sport, err)
+			scheduler.Shutdown()
+			return
+		}
+		fmt.Printf("Scheduled %s future job with ID: %s - runs every %s\n", sport, futureJob.ID(), cadence.Future)
 	}
-	fmt.Printf("Scheduled soccer job with ID: %s - runs every 1 minute\n", soccerJob.ID())
 
-	// Schedule basketball sync job
-	basketballJob, err := scheduler.NewJob(
-		gocron.DurationJob(1*time.Minute),
+	if retention > 0 {
+		pruneJob, err := scheduler.NewJob(
+			gocron.DurationJob(rawFeedPruneInterval),
+			gocron.NewTask(func() {
+				deleted, err := services.PruneRawFeeds(ctx, db, retention)
+				if err != nil {
+					log.Printf("Error pruning raw feeds: %v", err)
+				} else if deleted > 0 {
+					log.Printf("Pruned %d raw feeds older than %s", deleted, retention)
+				}
+			}),
+			gocron.WithName("raw-feed-prune"),
+			gocron.WithSingletonMode(gocron.LimitModeReschedule),
+			gocron.WithStartAt(gocron.WithStartImmediately()),
+		)
+		if err != nil {
+			log.Printf("Failed to create raw feed prune job: %v", err)
+			scheduler.Shutdown()
+			return
+		}
+		fmt.Printf("Scheduled raw feed prune job with ID: %s - keeps %s of payloads\n", pruneJob.ID(), retention)
+	}
+
+	h2hJob, err := scheduler.NewJob(
+		gocron.DurationJob(h2hFillInterval),
 		gocron.NewTask(func() {
-			log.Println("Running scheduled basketball match sync...")
-			if err := basketballSyncService.SyncMatches(); err != nil {
-				log.Printf("Error syncing basketball matches: %v", err)
+			filled, err := h2hService.FillRequests(ctx, h2hClient, h2hFillBatch)
+			if err != nil {
+				log.Printf("Error fetching queued head-to-head feeds: %v", err)
+			} else if filled > 0 {
+				log.Printf("Cached %d head-to-head feeds", filled)
 			}
 		}),
+		gocron.WithName("h2h-fill"),
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
 	)
 	if err != nil {
-		log.Fatalf("Failed to create basketball job: %v", err)
-	}
-	fmt.Printf("Scheduled basketball job with ID: %s - runs every 1 minute\n", basketballJob.ID())
-
-	// Run initial sync
-	log.Println("Running initial soccer match sync...")
-	if err := soccerSyncService.SyncMatches(); err != nil {
-		log.Printf("Error in initial soccer sync: %v", err)
-	}
-
-	log.Println("Running initial basketball match sync...")
-	if err := basketballSyncService.SyncMatches(); err != nil {
-		log.Printf("Error in initial basketball sync: %v", err)
+		log.Printf("Failed to create h2h fill job: %v", err)
+		scheduler.Shutdown()
+		return
 	}
+	fmt.Printf("Scheduled h2h fill job with ID: %s - up to %d pairs every %s\n", h2hJob.ID(), h2hFillBatch, h2hFillInterval)
 
 	// Start scheduler
 	scheduler.Start()
 
-	// Wait for interrupt signal
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	// Wait for shutdown or lost leadership
+	<-ctx.Done()
 
 	log.Println("Shutting down scheduler...")
 	if err := scheduler.Shutdown(); err != nil {
@@ -112,3 +248,42 @@ func runSync(cmd *cobra.Command, args []string) {
 
 	log.Println("Scheduler stopped")
 }
+
+// resolveSyncCadence starts from the SYNC_* environment and applies the flags
+// that were set explicitly
+func resolveSyncCadence(cmd *cobra.Command) (services.SyncCadence, error) {
+	cadence, err := services.SyncCadenceFromEnv()
+	if err != nil {
+		return cadence, err
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("live-interval") {
+		cadence.Live = syncCadence.Live
+	}
+	if flags.Changed("today-interval") {
+		cadence.Today = syncCadence.Today
+	}
+	if flags.Changed("future-interval") {
+		cadence.Future = syncCadence.Future
+	}
+	if flags.Changed("kickoff-window") {
+		cadence.KickoffWindow = syncCadence.KickoffWindow
+	}
+
+	return cadence, cadence.Validate()
+}
+
+// logSyncError logs a failed sync run, calling out failures that need operator action
+func logSyncError(sport string, err error) {
+	switch {
+	case errors.Is(err, context.Canceled):
+		log.Printf("%s sync cancelled", sport)
+	case errors.Is(err, goalserve.ErrAuthRejected):
+		log.Printf("Error syncing %s matches: Goalserve rejected the API key, check GOALSERVE_API_KEY: %v", sport, err)
+	case errors.Is(err, goalserve.ErrRateLimited):
+		log.Printf("Error syncing %s matches: Goalserve rate limit reached, will retry on next run: %v", sport, err)
+	default:
+		log.Printf("Error syncing %s matches: %v", sport, err)
+	}
+}
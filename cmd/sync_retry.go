@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/dusanbre/otg-sports-api/internal/services"
+	"github.com/joho/godotenv"
+	"github.com/spf13/cobra"
+)
+
+var (
+	retrySport string
+	retryLimit int
+)
+
+var syncRetryCmd = &cobra.Command{
+	Use:   "retry-failed",
+	Short: "Reprocess matches that failed to sync",
+	Long: `Run the matches parked in sync_dead_letters through the sync again,
+typically after a parser fix has been deployed. Matches that now sync are
+marked resolved; the rest stay with their latest error.
+
+Examples:
+  otg-sport-api sync retry-failed
+  otg-sport-api sync retry-failed --sport soccer --limit 100`,
+	Args: cobra.NoArgs,
+	Run:  runSyncRetry,
+}
+
+func init() {
+	syncCmd.AddCommand(syncRetryCmd)
+	syncRetryCmd.Flags().StringVarP(&retrySport, "sport", "s", "", "Sport to retry: soccer or basketball (default: both)")
+	syncRetryCmd.Flags().IntVarP(&retryLimit, "limit", "l", 1000, "Maximum dead letters to retry per sport")
+}
+
+func runSyncRetry(cmd *cobra.Command, args []string) {
+	// Load environment variables
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: .env file not found, using environment variables")
+	}
+
+	sports := []string{"soccer", "basketball"}
+	if retrySport != "" {
+		sport := strings.ToLower(strings.TrimSpace(retrySport))
+		if sport != "soccer" && sport != "basketball" {
+			log.Fatalf("Invalid sport: %s. Valid options: soccer, basketball", retrySport)
+		}
+		sports = []string{sport}
+	}
+	if retryLimit <= 0 {
+		log.Fatalf("--limit must be positive")
+	}
+
+	// Match seasons are named from the season_start of the standings rules
+	if err := services.LoadSeasonRules(); err != nil {
+		log.Fatalf("Failed to load standings rules: %v", err)
+	}
+
+	// Get database instance
+	db, err := database.GetInstance()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	failed := false
+	for _, sport := range sports {
+		retry := services.NewSoccerSyncService(db).RetryDeadLetters
+		if sport == "basketball" {
+			retry = services.NewBasketballSyncService(db).RetryDeadLetters
+		}
+
+		result, err := retry(ctx, retryLimit)
+		if err != nil {
+			log.Printf("Error retrying %s dead letters: %v", sport, err)
+			failed = true
+			continue
+		}
+
+		fmt.Printf("%s: %d retried, %d resolved, %d still failing\n",
+			sport, result.Total(), result.Total()-result.Failed, result.Failed)
+	}
+
+	if failed {
+		log.Fatal("Retry finished with errors")
+	}
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/joho/godotenv"
+	"github.com/spf13/cobra"
+)
+
+var syncStatusRecent int
+
+var syncStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show when each feed last synced",
+	Long: `Show the latest and the last successful run of every sport and feed,
+followed by the most recent runs, as recorded in sync_runs, and how many
+matches are waiting in sync_dead_letters.
+
+Examples:
+  otg-sport-api sync status
+  otg-sport-api sync status --recent 25`,
+	Args: cobra.NoArgs,
+	Run:  runSyncStatus,
+}
+
+func init() {
+	syncCmd.AddCommand(syncStatusCmd)
+	syncStatusCmd.Flags().IntVarP(&syncStatusRecent, "recent", "n", 10, "Number of recent runs to list (0 to skip)")
+}
+
+func runSyncStatus(cmd *cobra.Command, args []string) {
+	// Load environment variables
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: .env file not found, using environment variables")
+	}
+
+	// Get database instance
+	db, err := database.GetInstance()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	latest, err := db.GetLatestSyncRuns("")
+	if err != nil {
+		log.Fatalf("Failed to fetch sync runs: %v", err)
+	}
+	if len(latest) == 0 {
+		fmt.Println("No sync runs recorded yet.")
+		return
+	}
+
+	succeeded, err := db.GetLatestSyncRuns(database.SyncRunSucceeded)
+	if err != nil {
+		log.Fatalf("Failed to fetch sync runs: %v", err)
+	}
+	lastSuccess := make(map[string]database.SyncRun, len(succeeded))
+	for _, run := range succeeded {
+		lastSuccess[run.Sport+"/"+run.Feed] = run
+	}
+
+	if leader, err := db.GetSyncLeader(database.SyncLeadership); err != nil {
+		log.Printf("Warning: failed to fetch sync leader: %v", err)
+	} else if leader == nil {
+		fmt.Println("\nSync leader:   none")
+	} else {
+		health := "healthy"
+		if !leader.Healthy() {
+			health = "stale"
+		}
+		fmt.Printf("\nSync leader:   %s on %s, heartbeat %s ago (%s)\n",
+			leader.InstanceID, leader.Hostname.String, leader.HeartbeatAge.Round(time.Second), health)
+	}
+
+	if counts, err := db.CountDeadLetters(); err != nil {
+		log.Printf("Warning: failed to count dead letters: %v", err)
+	} else {
+		fmt.Printf("Dead letters:  soccer %d, basketball %d unresolved (retry with: sync retry-failed)\n",
+			counts["soccer"], counts["basketball"])
+	}
+
+	now := time.Now()
+
+	// Print header
+	fmt.Println()
+	fmt.Printf("%-12s %-8s %-10s %-22s %-22s\n", "SPORT", "FEED", "LAST RUN", "STARTED", "LAST SUCCESS")
+	fmt.Println(strings.Repeat("-", 78))
+
+	for _, run := range latest {
+		success := "never"
+		if ok, found := lastSuccess[run.Sport+"/"+run.Feed]; found {
+			success = formatAgo(now, ok.StartedAt)
+		}
+		fmt.Printf("%-12s %-8s %-10s %-22s %-22s\n",
+			run.Sport, run.Feed, run.Status, formatAgo(now, run.StartedAt), success)
+	}
+	fmt.Println()
+
+	if syncStatusRecent <= 0 {
+		return
+	}
+
+	recent, _, err := db.GetSyncRuns(database.SyncRunParams{Limit: syncStatusRecent})
+	if err != nil {
+		log.Fatalf("Failed to fetch sync runs: %v", err)
+	}
+
+	fmt.Printf("%-20s %-12s %-8s %-10s %-6s %-9s %-30s %s\n",
+		"STARTED (UTC)", "SPORT", "FEED", "STATUS", "HTTP", "DURATION", "INS/UPD/SAME/FAIL", "ERROR")
+	fmt.Println(strings.Repeat("-", 120))
+
+	for _, run := range recent {
+		httpStatus := "-"
+		if run.HTTPStatus.Valid {
+			httpStatus = fmt.Sprintf("%d", run.HTTPStatus.Int32)
+		}
+
+		duration := "-"
+		if run.FinishedAt.Valid {
+			duration = run.FinishedAt.Time.Sub(run.StartedAt).Round(100 * time.Millisecond).String()
+		}
+
+		errText := run.Error.String
+		if len(errText) > 60 {
+			errText = errText[:57] + "..."
+		}
+
+		fmt.Printf("%-20s %-12s %-8s %-10s %-6s %-9s %-30s %s\n",
+			run.StartedAt.UTC().Format("2006-01-02 15:04:05"), run.Sport, run.Feed, run.Status, httpStatus, duration,
+			fmt.Sprintf("%d/%d/%d/%d", run.Inserted, run.Updated, run.Unchanged, run.Failed), errText)
+	}
+	fmt.Println()
+}
+
+// formatAgo renders how long before now t was
+func formatAgo(now, t time.Time) string {
+	return fmt.Sprintf("%s ago", now.Sub(t).Round(time.Second))
+}
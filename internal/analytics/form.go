@@ -0,0 +1,153 @@
+// Package analytics derives team and league statistics from stored match results.
+package analytics
+
+import (
+	"slices"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+)
+
+// Outcomes of a result from the team's side, as used in Form.Results
+const (
+	Win  = 'W'
+	Draw = 'D'
+	Loss = 'L'
+)
+
+// FormOptions choose how many results each part of a Form covers
+type FormOptions struct {
+	Last   int      // Results in the form string
+	Window int      // Latest matches the record, averages and rates count
+	Line   *float64 // Total score line for over/under, nil to skip it
+}
+
+// Streaks are a team's current runs, counted back from its latest result
+type Streaks struct {
+	Winning  int
+	Unbeaten int
+	Losing   int
+	Winless  int
+}
+
+// OverUnder counts matches whose combined score is above or below Line.
+// A total equal to the line is neither.
+type OverUnder struct {
+	Line  float64
+	Over  int
+	Under int
+}
+
+// Form sums a team's latest results
+type Form struct {
+	Results       string // Outcome of each of the Last latest matches, oldest first, e.g. "WWDLW"
+	Streaks       Streaks
+	Played        int // Matches in the window
+	Won           int
+	Drawn         int
+	Lost          int
+	Scored        int // Goals, or points in basketball
+	Conceded      int
+	CleanSheets   int // Matches the opponent did not score in
+	FailedToScore int
+	OverUnder     *OverUnder // Nil when no line was asked for
+}
+
+// AvgScored is Scored per match played, zero before any match
+func (f Form) AvgScored() float64 {
+	if f.Played == 0 {
+		return 0
+	}
+	return float64(f.Scored) / float64(f.Played)
+}
+
+// AvgConceded is Conceded per match played, zero before any match
+func (f Form) AvgConceded() float64 {
+	if f.Played == 0 {
+		return 0
+	}
+	return float64(f.Conceded) / float64(f.Played)
+}
+
+// ComputeForm builds the form of team, given by Goalserve team ID, from its
+// finished matches latest first. Streaks run over every result given, the
+// rest over the latest opts.Last or opts.Window of them.
+func ComputeForm(teamID int64, results []database.MatchResult, opts FormOptions) Form {
+	var f Form
+	if opts.Line != nil {
+		f.OverUnder = &OverUnder{Line: *opts.Line}
+	}
+
+	outcomes := make([]byte, len(results)) // Latest first
+	for i, m := range results {
+		own, other := m.HomeScore, m.AwayScore
+		if m.HomeTeamID != teamID {
+			own, other = other, own
+		}
+		outcomes[i] = outcomeOf(own, other)
+		if i < opts.Window {
+			f.add(own, other, outcomes[i])
+		}
+	}
+
+	f.Streaks = Streaks{
+		Winning:  run(outcomes, func(o byte) bool { return o == Win }),
+		Unbeaten: run(outcomes, func(o byte) bool { return o != Loss }),
+		Losing:   run(outcomes, func(o byte) bool { return o == Loss }),
+		Winless:  run(outcomes, func(o byte) bool { return o != Win }),
+	}
+
+	last := slices.Clone(outcomes[:min(opts.Last, len(outcomes))])
+	slices.Reverse(last)
+	f.Results = string(last)
+	return f
+}
+
+// run counts the outcomes from the latest one for as long as they match
+func run(outcomes []byte, match func(byte) bool) int {
+	for i, o := range outcomes {
+		if !match(o) {
+			return i
+		}
+	}
+	return len(outcomes)
+}
+
+// add counts one match in the window
+func (f *Form) add(own, other int, outcome byte) {
+	f.Played++
+	f.Scored += own
+	f.Conceded += other
+	switch outcome {
+	case Win:
+		f.Won++
+	case Draw:
+		f.Drawn++
+	default:
+		f.Lost++
+	}
+	if other == 0 {
+		f.CleanSheets++
+	}
+	if own == 0 {
+		f.FailedToScore++
+	}
+	if f.OverUnder != nil {
+		total := float64(own + other)
+		switch {
+		case total > f.OverUnder.Line:
+			f.OverUnder.Over++
+		case total < f.OverUnder.Line:
+			f.OverUnder.Under++
+		}
+	}
+}
+
+func outcomeOf(own, other int) byte {
+	switch {
+	case own > other:
+		return Win
+	case own < other:
+		return Loss
+	}
+	return Draw
+}
@@ -0,0 +1,134 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+)
+
+// result is a finished match of team 1 against team 2, from team 1's side
+func result(own, other int, home bool) database.MatchResult {
+	if home {
+		return database.MatchResult{HomeTeamID: 1, AwayTeamID: 2, HomeScore: own, AwayScore: other}
+	}
+	return database.MatchResult{HomeTeamID: 2, AwayTeamID: 1, HomeScore: other, AwayScore: own}
+}
+
+func TestComputeForm(t *testing.T) {
+	line := 2.5
+	evenLine := 3.0
+
+	// Latest first: W 2-0, D 1-1 away, L 0-3, W 4-1 away, W 1-0
+	results := []database.MatchResult{
+		result(2, 0, true),
+		result(1, 1, false),
+		result(0, 3, true),
+		result(4, 1, false),
+		result(1, 0, true),
+	}
+
+	tests := []struct {
+		name    string
+		results []database.MatchResult
+		opts    FormOptions
+		want    Form
+		wantOU  *OverUnder
+	}{
+		{
+			name:    "no matches",
+			results: nil,
+			opts:    FormOptions{Last: 5, Window: 10},
+			want:    Form{},
+		},
+		{
+			name:    "every match",
+			results: results,
+			opts:    FormOptions{Last: 5, Window: 10, Line: &line},
+			want: Form{
+				Results: "WWLDW",
+				Streaks: Streaks{Winning: 1, Unbeaten: 2},
+				Played:  5, Won: 3, Drawn: 1, Lost: 1,
+				Scored: 8, Conceded: 5, CleanSheets: 2, FailedToScore: 1,
+			},
+			wantOU: &OverUnder{Line: 2.5, Over: 2, Under: 3},
+		},
+		{
+			name:    "window and form string shorter than the streaks",
+			results: results,
+			opts:    FormOptions{Last: 3, Window: 2},
+			want: Form{
+				Results: "LDW",
+				Streaks: Streaks{Winning: 1, Unbeaten: 2},
+				Played:  2, Won: 1, Drawn: 1,
+				Scored: 3, Conceded: 1, CleanSheets: 1,
+			},
+		},
+		{
+			name: "streaks run past the window",
+			results: []database.MatchResult{
+				result(0, 1, true),
+				result(0, 2, false),
+				result(1, 1, true),
+				result(0, 0, false),
+				result(3, 1, true),
+			},
+			opts: FormOptions{Last: 2, Window: 1},
+			want: Form{
+				Results: "LL",
+				Streaks: Streaks{Losing: 2, Winless: 4},
+				Played:  1, Lost: 1,
+				Conceded: 1, FailedToScore: 1,
+			},
+		},
+		{
+			name:    "total on the line is neither over nor under",
+			results: []database.MatchResult{result(2, 1, true), result(3, 1, false), result(1, 0, true)},
+			opts:    FormOptions{Last: 3, Window: 3, Line: &evenLine},
+			want: Form{
+				Results: "WWW",
+				Streaks: Streaks{Winning: 3, Unbeaten: 3},
+				Played:  3, Won: 3,
+				Scored: 6, Conceded: 2, CleanSheets: 1,
+			},
+			wantOU: &OverUnder{Line: 3, Over: 1, Under: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComputeForm(1, tt.results, tt.opts)
+
+			ou := got.OverUnder
+			got.OverUnder = nil
+			if got != tt.want {
+				t.Errorf("ComputeForm = %+v, want %+v", got, tt.want)
+			}
+			switch {
+			case (ou == nil) != (tt.wantOU == nil):
+				t.Errorf("OverUnder = %+v, want %+v", ou, tt.wantOU)
+			case ou != nil && *ou != *tt.wantOU:
+				t.Errorf("OverUnder = %+v, want %+v", *ou, *tt.wantOU)
+			}
+		})
+	}
+}
+
+func TestFormAverages(t *testing.T) {
+	tests := []struct {
+		name         string
+		form         Form
+		scored, conc float64
+	}{
+		{"no matches", Form{}, 0, 0},
+		{"three matches", Form{Played: 3, Scored: 7, Conceded: 3}, 7.0 / 3, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.form.AvgScored(); got != tt.scored {
+				t.Errorf("AvgScored = %v, want %v", got, tt.scored)
+			}
+			if got := tt.form.AvgConceded(); got != tt.conc {
+				t.Errorf("AvgConceded = %v, want %v", got, tt.conc)
+			}
+		})
+	}
+}
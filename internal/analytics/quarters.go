@@ -0,0 +1,139 @@
+package analytics
+
+import (
+	"github.com/dusanbre/otg-sports-api/internal/database"
+)
+
+// QuarterSplit sums one quarter over a team's matches
+type QuarterSplit struct {
+	Scored   int
+	Conceded int
+	Won      int // Quarters outscoring the opponent
+	Drawn    int
+	Lost     int
+}
+
+// TeamQuarters sums a basketball team's matches quarter by quarter
+type TeamQuarters struct {
+	Played          int
+	Quarters        [4]QuarterSplit
+	Overtimes       int // Matches level after Q4
+	OvertimesWon    int
+	TrailingAfterQ3 int
+	Comebacks       int // Matches won after trailing after Q3
+	LeadingAfterQ3  int
+	BlownLeads      int // Matches lost after leading after Q3
+}
+
+// ComputeTeamQuarters sums the quarters of team, given by Goalserve team ID,
+// over its matches
+func ComputeTeamQuarters(teamID int64, lines []database.QuarterLine) TeamQuarters {
+	var t TeamQuarters
+	for _, l := range lines {
+		own, other := l.Home, l.Away
+		ownOT, otherOT := l.HomeOT, l.AwayOT
+		if l.HomeTeamID != teamID {
+			own, other = other, own
+			ownOT, otherOT = otherOT, ownOT
+		}
+
+		t.Played++
+		for q := range own {
+			split := &t.Quarters[q]
+			split.Scored += own[q]
+			split.Conceded += other[q]
+			switch outcomeOf(own[q], other[q]) {
+			case Win:
+				split.Won++
+			case Draw:
+				split.Drawn++
+			default:
+				split.Lost++
+			}
+		}
+
+		afterQ3 := outcomeOf(sum(own[:3]), sum(other[:3]))
+		final := outcomeOf(sum(own[:])+ownOT, sum(other[:])+otherOT)
+		switch afterQ3 {
+		case Loss:
+			t.TrailingAfterQ3++
+			if final == Win {
+				t.Comebacks++
+			}
+		case Win:
+			t.LeadingAfterQ3++
+			if final == Loss {
+				t.BlownLeads++
+			}
+		}
+
+		if sum(own[:]) == sum(other[:]) {
+			t.Overtimes++
+			if final == Win {
+				t.OvertimesWon++
+			}
+		}
+	}
+	return t
+}
+
+// LeagueQuarter sums one quarter over a league's matches
+type LeagueQuarter struct {
+	HomePoints int
+	AwayPoints int
+	HomeWon    int
+	AwayWon    int
+	Tied       int
+}
+
+// LeagueQuarters sums a basketball league's matches quarter by quarter
+type LeagueQuarters struct {
+	Played     int
+	Quarters   [4]LeagueQuarter
+	Overtimes  int // Matches level after Q4
+	LedAfterQ3 int // Matches with a leader after Q3
+	Comebacks  int // Matches won by the team trailing after Q3
+}
+
+// ComputeLeagueQuarters sums the quarters of a league's matches
+func ComputeLeagueQuarters(lines []database.QuarterLine) LeagueQuarters {
+	var lq LeagueQuarters
+	for _, l := range lines {
+		lq.Played++
+		for q := range l.Home {
+			quarter := &lq.Quarters[q]
+			quarter.HomePoints += l.Home[q]
+			quarter.AwayPoints += l.Away[q]
+			switch outcomeOf(l.Home[q], l.Away[q]) {
+			case Win:
+				quarter.HomeWon++
+			case Loss:
+				quarter.AwayWon++
+			default:
+				quarter.Tied++
+			}
+		}
+
+		afterQ3 := outcomeOf(sum(l.Home[:3]), sum(l.Away[:3]))
+		final := outcomeOf(sum(l.Home[:])+l.HomeOT, sum(l.Away[:])+l.AwayOT)
+		if afterQ3 != Draw {
+			lq.LedAfterQ3++
+			if final != Draw && final != afterQ3 {
+				lq.Comebacks++
+			}
+		}
+
+		if sum(l.Home[:]) == sum(l.Away[:]) {
+			lq.Overtimes++
+		}
+	}
+	return lq
+}
+
+func sum(points []int) int {
+	total := 0
+	for _, p := range points {
+		total += p
+	}
+	return total
+}
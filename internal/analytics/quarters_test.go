@@ -0,0 +1,172 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+)
+
+// Matches of team 1 against team 2
+var (
+	// Team 1 at home leads after Q3 and wins in regulation
+	leadHeld = database.QuarterLine{HomeTeamID: 1, AwayTeamID: 2,
+		Home: [4]int{30, 25, 20, 20}, Away: [4]int{20, 20, 25, 20}}
+	// Team 1 away trails by 15 after Q3 and wins
+	awayComeback = database.QuarterLine{HomeTeamID: 2, AwayTeamID: 1,
+		Home: [4]int{25, 25, 25, 15}, Away: [4]int{20, 20, 20, 35}}
+	// Team 1 at home leads after Q3, is caught in Q4 and wins in overtime
+	overtimeWin = database.QuarterLine{HomeTeamID: 1, AwayTeamID: 2,
+		Home: [4]int{25, 20, 25, 20}, Away: [4]int{20, 20, 20, 30}, HomeOT: 10, AwayOT: 8}
+	// Team 1 at home leads after Q3, is caught in Q4 and loses in overtime
+	overtimeLoss = database.QuarterLine{HomeTeamID: 1, AwayTeamID: 2,
+		Home: [4]int{30, 20, 20, 20}, Away: [4]int{20, 20, 20, 30}, HomeOT: 5, AwayOT: 12}
+	// Level after Q3, team 1 away wins Q4
+	levelAfterQ3 = database.QuarterLine{HomeTeamID: 2, AwayTeamID: 1,
+		Home: [4]int{20, 20, 20, 20}, Away: [4]int{20, 20, 20, 25}}
+)
+
+func TestComputeTeamQuarters(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []database.QuarterLine
+		want  TeamQuarters
+	}{
+		{"no matches", nil, TeamQuarters{}},
+		{"lead held", []database.QuarterLine{leadHeld}, TeamQuarters{
+			Played: 1,
+			Quarters: [4]QuarterSplit{
+				{Scored: 30, Conceded: 20, Won: 1},
+				{Scored: 25, Conceded: 20, Won: 1},
+				{Scored: 20, Conceded: 25, Lost: 1},
+				{Scored: 20, Conceded: 20, Drawn: 1},
+			},
+			LeadingAfterQ3: 1,
+		}},
+		{"away comeback", []database.QuarterLine{awayComeback}, TeamQuarters{
+			Played: 1,
+			Quarters: [4]QuarterSplit{
+				{Scored: 20, Conceded: 25, Lost: 1},
+				{Scored: 20, Conceded: 25, Lost: 1},
+				{Scored: 20, Conceded: 25, Lost: 1},
+				{Scored: 35, Conceded: 15, Won: 1},
+			},
+			TrailingAfterQ3: 1,
+			Comebacks:       1,
+		}},
+		{"overtime win", []database.QuarterLine{overtimeWin}, TeamQuarters{
+			Played: 1,
+			Quarters: [4]QuarterSplit{
+				{Scored: 25, Conceded: 20, Won: 1},
+				{Scored: 20, Conceded: 20, Drawn: 1},
+				{Scored: 25, Conceded: 20, Won: 1},
+				{Scored: 20, Conceded: 30, Lost: 1},
+			},
+			Overtimes:      1,
+			OvertimesWon:   1,
+			LeadingAfterQ3: 1,
+		}},
+		{"lead blown in overtime", []database.QuarterLine{overtimeLoss}, TeamQuarters{
+			Played: 1,
+			Quarters: [4]QuarterSplit{
+				{Scored: 30, Conceded: 20, Won: 1},
+				{Scored: 20, Conceded: 20, Drawn: 1},
+				{Scored: 20, Conceded: 20, Drawn: 1},
+				{Scored: 20, Conceded: 30, Lost: 1},
+			},
+			Overtimes:      1,
+			LeadingAfterQ3: 1,
+			BlownLeads:     1,
+		}},
+		{"level after Q3", []database.QuarterLine{levelAfterQ3}, TeamQuarters{
+			Played: 1,
+			Quarters: [4]QuarterSplit{
+				{Scored: 20, Conceded: 20, Drawn: 1},
+				{Scored: 20, Conceded: 20, Drawn: 1},
+				{Scored: 20, Conceded: 20, Drawn: 1},
+				{Scored: 25, Conceded: 20, Won: 1},
+			},
+		}},
+		{"season", []database.QuarterLine{leadHeld, awayComeback, overtimeWin, overtimeLoss, levelAfterQ3}, TeamQuarters{
+			Played: 5,
+			Quarters: [4]QuarterSplit{
+				{Scored: 125, Conceded: 105, Won: 3, Drawn: 1, Lost: 1},
+				{Scored: 105, Conceded: 105, Won: 1, Drawn: 3, Lost: 1},
+				{Scored: 105, Conceded: 110, Won: 1, Drawn: 2, Lost: 2},
+				{Scored: 120, Conceded: 115, Won: 2, Drawn: 1, Lost: 2},
+			},
+			Overtimes:       2,
+			OvertimesWon:    1,
+			TrailingAfterQ3: 1,
+			Comebacks:       1,
+			LeadingAfterQ3:  3,
+			BlownLeads:      1,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ComputeTeamQuarters(1, tt.lines); got != tt.want {
+				t.Errorf("ComputeTeamQuarters = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeLeagueQuarters(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []database.QuarterLine
+		want  LeagueQuarters
+	}{
+		{"no matches", nil, LeagueQuarters{}},
+		{"lead held", []database.QuarterLine{leadHeld}, LeagueQuarters{
+			Played: 1,
+			Quarters: [4]LeagueQuarter{
+				{HomePoints: 30, AwayPoints: 20, HomeWon: 1},
+				{HomePoints: 25, AwayPoints: 20, HomeWon: 1},
+				{HomePoints: 20, AwayPoints: 25, AwayWon: 1},
+				{HomePoints: 20, AwayPoints: 20, Tied: 1},
+			},
+			LedAfterQ3: 1,
+		}},
+		{"comeback in overtime", []database.QuarterLine{overtimeLoss}, LeagueQuarters{
+			Played: 1,
+			Quarters: [4]LeagueQuarter{
+				{HomePoints: 30, AwayPoints: 20, HomeWon: 1},
+				{HomePoints: 20, AwayPoints: 20, Tied: 1},
+				{HomePoints: 20, AwayPoints: 20, Tied: 1},
+				{HomePoints: 20, AwayPoints: 30, AwayWon: 1},
+			},
+			Overtimes:  1,
+			LedAfterQ3: 1,
+			Comebacks:  1,
+		}},
+		{"level after Q3 is no comeback", []database.QuarterLine{levelAfterQ3}, LeagueQuarters{
+			Played: 1,
+			Quarters: [4]LeagueQuarter{
+				{HomePoints: 20, AwayPoints: 20, Tied: 1},
+				{HomePoints: 20, AwayPoints: 20, Tied: 1},
+				{HomePoints: 20, AwayPoints: 20, Tied: 1},
+				{HomePoints: 20, AwayPoints: 25, AwayWon: 1},
+			},
+		}},
+		{"season", []database.QuarterLine{leadHeld, awayComeback, overtimeWin, overtimeLoss, levelAfterQ3}, LeagueQuarters{
+			Played: 5,
+			Quarters: [4]LeagueQuarter{
+				{HomePoints: 130, AwayPoints: 100, HomeWon: 4, Tied: 1},
+				{HomePoints: 110, AwayPoints: 100, HomeWon: 2, Tied: 3},
+				{HomePoints: 110, AwayPoints: 105, HomeWon: 2, AwayWon: 1, Tied: 2},
+				{HomePoints: 95, AwayPoints: 140, AwayWon: 4, Tied: 1},
+			},
+			Overtimes:  2,
+			LedAfterQ3: 4,
+			Comebacks:  2,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ComputeLeagueQuarters(tt.lines); got != tt.want {
+				t.Errorf("ComputeLeagueQuarters = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
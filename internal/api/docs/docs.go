@@ -23,7 +23,7 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/basketball/leagues": {
+        "/admin/sync/runs": {
             "get": {
                 "security": [
                     {
@@ -33,7 +33,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a list of all available basketball leagues",
+                "description": "Returns recorded sync runs, newest first. Requires an admin API key.\nUse status=succeeded\u0026sport=soccer\u0026limit=1 to find the last successful soccer sync.",
                 "consumes": [
                     "application/json"
                 ],
@@ -41,9 +41,59 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "basketball"
+                    "admin"
+                ],
+                "summary": "List sync runs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum results (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Results to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "soccer",
+                            "basketball"
+                        ],
+                        "type": "string",
+                        "description": "Filter by sport",
+                        "name": "sport",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "today",
+                            "future",
+                            "retry",
+                            "reprocess",
+                            "backfill"
+                        ],
+                        "type": "string",
+                        "description": "Filter by feed",
+                        "name": "feed",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "running",
+                            "succeeded",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get basketball leagues",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -58,8 +108,29 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/database.LeagueInfo"
+                                                "$ref": "#/definitions/dto.SyncRunResponse"
                                             }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/middleware.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
                                         }
                                     }
                                 }
@@ -102,6 +173,24 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -123,7 +212,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/basketball/matches": {
+        "/basketball/h2h": {
             "get": {
                 "security": [
                     {
@@ -133,7 +222,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a paginated list of basketball matches with optional filtering",
+                "description": "Returns the finished meetings of two basketball teams, latest first, with wins and points overall and by home team. When few meetings are stored they are topped up from the cached Goalserve h2h feed; a missing or stale feed is fetched in the background and pending is true until it is",
                 "consumes": [
                     "application/json"
                 ],
@@ -143,38 +232,27 @@ const docTemplate = `{
                 "tags": [
                     "basketball"
                 ],
-                "summary": "List basketball matches",
+                "summary": "Get basketball head-to-head",
                 "parameters": [
                     {
                         "type": "integer",
-                        "default": 50,
-                        "description": "Maximum results (1-100)",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Team ID the tally is counted for",
+                        "name": "team_a",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "integer",
-                        "default": 0,
-                        "description": "Results to skip",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by date (YYYY-MM-DD)",
-                        "name": "date",
-                        "in": "query"
+                        "description": "Opponent team ID",
+                        "name": "team_b",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Filter by status (FT, Q1, Q2, Q3, Q4, OT, HT)",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Filter by league ID",
-                        "name": "league_id",
+                        "default": "UTC",
+                        "description": "IANA timezone for tip-off times",
+                        "name": "tz",
                         "in": "query"
                     }
                 ],
@@ -190,21 +268,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/dto.BasketballMatchResponse"
-                                            }
-                                        },
-                                        "meta": {
-                                            "$ref": "#/definitions/middleware.MetaInfo"
+                                            "$ref": "#/definitions/dto.H2HResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "allOf": [
                                 {
@@ -221,8 +293,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "allOf": [
                                 {
@@ -239,8 +311,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "429": {
-                        "description": "Too Many Requests",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "allOf": [
                                 {
@@ -278,7 +350,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/basketball/matches/live": {
+        "/basketball/leagues": {
             "get": {
                 "security": [
                     {
@@ -288,7 +360,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns all currently live basketball matches",
+                "description": "Returns all basketball leagues sync has seen, ordered by name",
                 "consumes": [
                     "application/json"
                 ],
@@ -298,7 +370,7 @@ const docTemplate = `{
                 "tags": [
                     "basketball"
                 ],
-                "summary": "Get live basketball matches",
+                "summary": "Get basketball leagues",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -313,7 +385,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/dto.BasketballMatchResponse"
+                                                "$ref": "#/definitions/dto.LeagueResponse"
                                             }
                                         }
                                     }
@@ -378,7 +450,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/basketball/matches/{id}": {
+        "/basketball/leagues/{id}": {
             "get": {
                 "security": [
                     {
@@ -388,7 +460,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a single basketball match by its match ID",
+                "description": "Returns a basketball league by its Goalserve ID, with the seasons sync has seen, latest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -398,11 +470,11 @@ const docTemplate = `{
                 "tags": [
                     "basketball"
                 ],
-                "summary": "Get basketball match by ID",
+                "summary": "Get a basketball league",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Match ID",
+                        "description": "League ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -420,7 +492,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.BasketballMatchResponse"
+                                            "$ref": "#/definitions/dto.LeagueDetailResponse"
                                         }
                                     }
                                 }
@@ -463,6 +535,24 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -480,26 +570,9 @@ const docTemplate = `{
                                 }
                             ]
                         }
-                    }
-                }
-            }
-        },
-        "/health": {
-            "get": {
-                "description": "Returns the health status of the API service (public endpoint)",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "health"
-                ],
-                "summary": "Health check",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "allOf": [
                                 {
@@ -508,11 +581,8 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "type": "object",
-                                            "additionalProperties": {
-                                                "type": "string"
-                                            }
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
                                         }
                                     }
                                 }
@@ -522,7 +592,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/soccer/leagues": {
+        "/basketball/leagues/{id}/quarters": {
             "get": {
                 "security": [
                     {
@@ -532,7 +602,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a list of all available soccer leagues",
+                "description": "Returns the average home and away points in each quarter of a basketball league, how often the home or away team wins each quarter, how often matches go to overtime, and how often the team trailing after Q3 wins. Counts finished matches with every quarter scored",
                 "consumes": [
                     "application/json"
                 ],
@@ -540,9 +610,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "soccer"
+                    "basketball"
+                ],
+                "summary": "Get a basketball league's quarter analytics",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "League ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First tip-off day (YYYY-MM-DD) in tz",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last tip-off day (YYYY-MM-DD) in tz",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "UTC",
+                        "description": "IANA timezone of from and to",
+                        "name": "tz",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get soccer leagues",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -555,10 +653,25 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/database.LeagueInfo"
-                                            }
+                                            "$ref": "#/definitions/dto.LeagueQuartersResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
                                         }
                                     }
                                 }
@@ -601,6 +714,24 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -622,7 +753,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/soccer/matches": {
+        "/basketball/leagues/{id}/standings": {
             "get": {
                 "security": [
                     {
@@ -632,7 +763,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a paginated list of soccer matches with optional filtering",
+                "description": "Returns a basketball league table built from finished matches, ranked by win percentage unless the competition's rules say otherwise. Defaults to the latest season",
                 "consumes": [
                     "application/json"
                 ],
@@ -640,40 +771,225 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "soccer"
+                    "basketball"
                 ],
-                "summary": "List soccer matches",
+                "summary": "Get basketball league standings",
                 "parameters": [
                     {
                         "type": "integer",
-                        "default": 50,
-                        "description": "Maximum results (1-100)",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "League ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Results to skip",
-                        "name": "offset",
+                        "type": "string",
+                        "description": "Season name, e.g. 2026/2027",
+                        "name": "season",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter by date (YYYY-MM-DD)",
-                        "name": "date",
+                        "description": "Only count matches tipping off on or before this day (YYYY-MM-DD) in tz",
+                        "name": "as_of",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter by status (FT, 1H, HT, 2H, NS)",
-                        "name": "status",
+                        "default": "UTC",
+                        "description": "IANA timezone of as_of",
+                        "name": "tz",
                         "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Filter by league ID",
-                        "name": "league_id",
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.StandingsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/basketball/matches": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a paginated list of basketball matches with optional filtering",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "basketball"
+                ],
+                "summary": "List basketball matches",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum results (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Results to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by kickoff day (YYYY-MM-DD) in tz",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "UTC",
+                        "description": "IANA timezone for date, start_date and start_time",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "scheduled",
+                            "live",
+                            "break",
+                            "finished",
+                            "postponed",
+                            "cancelled",
+                            "abandoned",
+                            "interrupted",
+                            "missing"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by league ID",
+                        "name": "league_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Related data to embed (prediction)",
+                        "name": "include",
                         "in": "query"
                     }
                 ],
@@ -691,7 +1007,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/dto.SoccerMatchResponse"
+                                                "$ref": "#/definitions/dto.BasketballMatchResponse"
                                             }
                                         },
                                         "meta": {
@@ -702,6 +1018,24 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -777,7 +1111,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/soccer/matches/live": {
+        "/basketball/matches/live": {
             "get": {
                 "security": [
                     {
@@ -787,7 +1121,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns all currently live soccer matches",
+                "description": "Returns all basketball matches in play (status live or break)",
                 "consumes": [
                     "application/json"
                 ],
@@ -795,9 +1129,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "soccer"
+                    "basketball"
+                ],
+                "summary": "Get live basketball matches",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "UTC",
+                        "description": "IANA timezone for start_date and start_time",
+                        "name": "tz",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get live soccer matches",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -812,7 +1155,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/dto.SoccerMatchResponse"
+                                                "$ref": "#/definitions/dto.BasketballMatchResponse"
                                             }
                                         }
                                     }
@@ -820,6 +1163,24 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -877,7 +1238,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/soccer/matches/{id}": {
+        "/basketball/matches/{id}": {
             "get": {
                 "security": [
                     {
@@ -887,7 +1248,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a single soccer match by its match ID",
+                "description": "Returns a single basketball match by its match ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -895,9 +1256,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "soccer"
+                    "basketball"
                 ],
-                "summary": "Get soccer match by ID",
+                "summary": "Get basketball match by ID",
                 "parameters": [
                     {
                         "type": "integer",
@@ -905,6 +1266,19 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "UTC",
+                        "description": "IANA timezone for start_date and start_time",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Related data to embed (prediction)",
+                        "name": "include",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -919,7 +1293,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.SoccerMatchResponse"
+                                            "$ref": "#/definitions/dto.BasketballMatchResponse"
                                         }
                                     }
                                 }
@@ -979,33 +1353,4343 @@ const docTemplate = `{
                                 }
                             ]
                         }
-                    }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "database.LeagueInfo": {
-            "type": "object",
-            "properties": {
-                "gid": {
-                    "type": "integer"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "name": {
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/basketball/matches/{id}/changes": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the field changes sync recorded for a basketball match, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "basketball"
+                ],
+                "summary": "Get basketball match change history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Match ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum results (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Results to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.MatchChangeResponse"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/middleware.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/basketball/ratings": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the current Elo rating of every rated basketball team, best first, with the number of matches rated. Ratings update after each sync.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "basketball"
+                ],
+                "summary": "List basketball team ratings",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only teams with matches in this league",
+                        "name": "league_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "UTC",
+                        "description": "IANA timezone for last_match_at",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum results (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Results to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.RatingResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/basketball/teams": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a paginated list of basketball teams ordered by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "basketball"
+                ],
+                "summary": "List basketball teams",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum results (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Results to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive part of the team name",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only teams with matches in this league",
+                        "name": "league_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.TeamResponse"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/middleware.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/basketball/teams/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a basketball team by its Goalserve ID, with the leagues it has matches in",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "basketball"
+                ],
+                "summary": "Get a basketball team",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TeamDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/basketball/teams/{id}/form": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a basketball team's latest results as a form string (oldest first) and its current winning, unbeaten, losing and winless streaks, with its record, average points and, when a line is given, over/under rate over its latest finished matches",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "basketball"
+                ],
+                "summary": "Get a basketball team's form",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Results in the form string (1-20)",
+                        "name": "last",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Latest matches the record, averages and rates count (1-100)",
+                        "name": "matches",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Over/under total points line, e.g. 215.5",
+                        "name": "line",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only count matches in this league",
+                        "name": "league_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TeamFormResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/basketball/teams/{id}/matches": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a paginated list of the basketball matches a team plays home or away, with its side and W/D/L result. when=upcoming lists scheduled and in-play matches soonest first, when=past finished matches latest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "basketball"
+                ],
+                "summary": "List a basketball team's matches",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "upcoming",
+                            "past"
+                        ],
+                        "type": "string",
+                        "description": "Upcoming or past matches only",
+                        "name": "when",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum results (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Results to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by kickoff day (YYYY-MM-DD) in tz",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "UTC",
+                        "description": "IANA timezone for date, start_date and start_time",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "scheduled",
+                            "live",
+                            "break",
+                            "finished",
+                            "postponed",
+                            "cancelled",
+                            "abandoned",
+                            "interrupted",
+                            "missing"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by league ID",
+                        "name": "league_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Related data to embed (prediction)",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.BasketballTeamMatchResponse"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/middleware.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/basketball/teams/{id}/quarters": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a basketball team's average points scored and conceded in each quarter, how often it wins each quarter, how often its matches go to overtime, and how often it wins after trailing after Q3 or loses after leading. Counts finished matches with every quarter scored",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "basketball"
+                ],
+                "summary": "Get a basketball team's quarter analytics",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First tip-off day (YYYY-MM-DD) in tz",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last tip-off day (YYYY-MM-DD) in tz",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "UTC",
+                        "description": "IANA timezone of from and to",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TeamQuartersResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/basketball/teams/{id}/ratings": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a basketball team's current Elo rating and its rating before and after each rated match, latest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "basketball"
+                ],
+                "summary": "Get a basketball team's rating history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "UTC",
+                        "description": "IANA timezone for kickoff_at",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum history entries (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Results to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TeamRatingsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Returns the health status of the API service and the sync worker currently holding leadership (public endpoint).\nStatus is degraded when the database can't be queried.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Health check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.HealthResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/soccer/h2h": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the finished meetings of two soccer teams, latest first, with wins, draws and goals overall and by home team. When few meetings are stored they are topped up from the cached Goalserve h2h feed; a missing or stale feed is fetched in the background and pending is true until it is",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "soccer"
+                ],
+                "summary": "Get soccer head-to-head",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID the tally is counted for",
+                        "name": "team_a",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Opponent team ID",
+                        "name": "team_b",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "UTC",
+                        "description": "IANA timezone for kickoff times",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.H2HResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/soccer/leagues": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns all soccer leagues sync has seen, ordered by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "soccer"
+                ],
+                "summary": "Get soccer leagues",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.LeagueResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/soccer/leagues/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a soccer league by its Goalserve ID, with the seasons sync has seen, latest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "soccer"
+                ],
+                "summary": "Get a soccer league",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "League ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.LeagueDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/soccer/leagues/{id}/standings": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a soccer league table built from finished matches, ranked by the competition's tie-break rules. Defaults to the latest season",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "soccer"
+                ],
+                "summary": "Get soccer league standings",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "League ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season name, e.g. 2026/2027",
+                        "name": "season",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count matches kicking off on or before this day (YYYY-MM-DD) in tz",
+                        "name": "as_of",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "UTC",
+                        "description": "IANA timezone of as_of",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.StandingsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/soccer/matches": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a paginated list of soccer matches with optional filtering",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "soccer"
+                ],
+                "summary": "List soccer matches",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum results (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Results to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by kickoff day (YYYY-MM-DD) in tz",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "UTC",
+                        "description": "IANA timezone for date, start_date and start_time",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "scheduled",
+                            "live",
+                            "break",
+                            "finished",
+                            "postponed",
+                            "cancelled",
+                            "abandoned",
+                            "interrupted",
+                            "missing"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by league ID",
+                        "name": "league_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Related data to embed (events, prediction)",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.SoccerMatchResponse"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/middleware.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/soccer/matches/live": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns all soccer matches in play (status live or break)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "soccer"
+                ],
+                "summary": "Get live soccer matches",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "UTC",
+                        "description": "IANA timezone for start_date and start_time",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Related data to embed (events)",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.SoccerMatchResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/soccer/matches/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a single soccer match by its match ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "soccer"
+                ],
+                "summary": "Get soccer match by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Match ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "UTC",
+                        "description": "IANA timezone for start_date and start_time",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Related data to embed (events, prediction)",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SoccerMatchResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/soccer/matches/{id}/changes": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the field changes sync recorded for a soccer match, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "soccer"
+                ],
+                "summary": "Get soccer match change history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Match ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum results (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Results to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.MatchChangeResponse"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/middleware.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/soccer/matches/{id}/events": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the goals, cards and substitutions of a soccer match in match order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "soccer"
+                ],
+                "summary": "Get soccer match events",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Match ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.SoccerEventResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/soccer/ratings": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the current Elo rating of every rated soccer team, best first, with the number of matches rated. Ratings update after each sync.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "soccer"
+                ],
+                "summary": "List soccer team ratings",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only teams with matches in this league",
+                        "name": "league_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "UTC",
+                        "description": "IANA timezone for last_match_at",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum results (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Results to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.RatingResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/soccer/teams": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a paginated list of soccer teams ordered by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "soccer"
+                ],
+                "summary": "List soccer teams",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum results (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Results to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive part of the team name",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only teams with matches in this league",
+                        "name": "league_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.TeamResponse"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/middleware.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/soccer/teams/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a soccer team by its Goalserve ID, with the leagues it has matches in",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "soccer"
+                ],
+                "summary": "Get a soccer team",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TeamDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/soccer/teams/{id}/form": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a soccer team's latest results as a form string (oldest first) and its current winning, unbeaten, losing and winless streaks, with its record, average goals, clean sheets and over/under rate over its latest finished matches",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "soccer"
+                ],
+                "summary": "Get a soccer team's form",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Results in the form string (1-20)",
+                        "name": "last",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Latest matches the record, averages and rates count (1-100)",
+                        "name": "matches",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "default": 2.5,
+                        "description": "Over/under total goals line",
+                        "name": "line",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only count matches in this league",
+                        "name": "league_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TeamFormResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/soccer/teams/{id}/matches": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a paginated list of the soccer matches a team plays home or away, with its side and W/D/L result. when=upcoming lists scheduled and in-play matches soonest first, when=past finished matches latest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "soccer"
+                ],
+                "summary": "List a soccer team's matches",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "upcoming",
+                            "past"
+                        ],
+                        "type": "string",
+                        "description": "Upcoming or past matches only",
+                        "name": "when",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum results (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Results to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by kickoff day (YYYY-MM-DD) in tz",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "UTC",
+                        "description": "IANA timezone for date, start_date and start_time",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "scheduled",
+                            "live",
+                            "break",
+                            "finished",
+                            "postponed",
+                            "cancelled",
+                            "abandoned",
+                            "interrupted",
+                            "missing"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by league ID",
+                        "name": "league_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Related data to embed (events, prediction)",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.SoccerTeamMatchResponse"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/middleware.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/soccer/teams/{id}/ratings": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a soccer team's current Elo rating and its rating before and after each rated match, latest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "soccer"
+                ],
+                "summary": "Get a soccer team's rating history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "UTC",
+                        "description": "IANA timezone for kickoff_at",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum history entries (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Results to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TeamRatingsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/middleware.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/middleware.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "database.SyncRunFailure": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "match_id": {
+                    "description": "Raw feed ID, it may be the reason the match failed",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BasketballMatchResponse": {
+            "type": "object",
+            "properties": {
+                "away_team": {
+                    "$ref": "#/definitions/dto.TeamInfo"
+                },
+                "file_group": {
+                    "type": "string"
+                },
+                "home_team": {
+                    "$ref": "#/definitions/dto.TeamInfo"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kickoff_at": {
+                    "description": "RFC3339 with the requested tz offset",
+                    "type": "string"
+                },
+                "last_changed_at": {
+                    "type": "string"
+                },
+                "league_gid": {
+                    "type": "integer"
+                },
+                "league_id": {
+                    "type": "integer"
+                },
+                "league_name": {
+                    "type": "string"
+                },
+                "match_id": {
+                    "type": "integer"
+                },
+                "missed_syncs": {
+                    "description": "Consecutive syncs of its day the match was absent from",
+                    "type": "integer"
+                },
+                "prediction": {
+                    "description": "Prediction is only filled when requested with include=prediction, for a\nscheduled match in a league with enough results",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.PredictionResponse"
+                        }
+                    ]
+                },
+                "previous_kickoff_at": {
+                    "description": "Kickoff before it last moved, RFC3339 in the requested tz",
+                    "type": "string"
+                },
+                "quarter_scores": {
+                    "$ref": "#/definitions/dto.QuarterScores"
+                },
+                "raw_status": {
+                    "description": "Goalserve status, e.g. \"3rd Quarter\"",
+                    "type": "string"
+                },
+                "rescheduled": {
+                    "description": "Kickoff has moved since the match was first seen",
+                    "type": "boolean"
+                },
+                "sport": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "description": "Kickoff day in the requested tz",
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "scheduled",
+                        "live",
+                        "break",
+                        "finished",
+                        "postponed",
+                        "cancelled",
+                        "abandoned",
+                        "interrupted",
+                        "missing"
+                    ]
+                },
+                "timer": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BasketballTeamMatchResponse": {
+            "type": "object",
+            "properties": {
+                "away_team": {
+                    "$ref": "#/definitions/dto.TeamInfo"
+                },
+                "file_group": {
+                    "type": "string"
+                },
+                "home_team": {
+                    "$ref": "#/definitions/dto.TeamInfo"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kickoff_at": {
+                    "description": "RFC3339 with the requested tz offset",
+                    "type": "string"
+                },
+                "last_changed_at": {
+                    "type": "string"
+                },
+                "league_gid": {
+                    "type": "integer"
+                },
+                "league_id": {
+                    "type": "integer"
+                },
+                "league_name": {
+                    "type": "string"
+                },
+                "match_id": {
+                    "type": "integer"
+                },
+                "missed_syncs": {
+                    "description": "Consecutive syncs of its day the match was absent from",
+                    "type": "integer"
+                },
+                "prediction": {
+                    "description": "Prediction is only filled when requested with include=prediction, for a\nscheduled match in a league with enough results",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.PredictionResponse"
+                        }
+                    ]
+                },
+                "previous_kickoff_at": {
+                    "description": "Kickoff before it last moved, RFC3339 in the requested tz",
+                    "type": "string"
+                },
+                "quarter_scores": {
+                    "$ref": "#/definitions/dto.QuarterScores"
+                },
+                "raw_status": {
+                    "description": "Goalserve status, e.g. \"3rd Quarter\"",
+                    "type": "string"
+                },
+                "rescheduled": {
+                    "description": "Kickoff has moved since the match was first seen",
+                    "type": "boolean"
+                },
+                "result": {
+                    "description": "Null until the match is finished with a score",
+                    "type": "string",
+                    "enum": [
+                        "W",
+                        "D",
+                        "L"
+                    ]
+                },
+                "side": {
+                    "type": "string",
+                    "enum": [
+                        "home",
+                        "away"
+                    ]
+                },
+                "sport": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "description": "Kickoff day in the requested tz",
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "scheduled",
+                        "live",
+                        "break",
+                        "finished",
+                        "postponed",
+                        "cancelled",
+                        "abandoned",
+                        "interrupted",
+                        "missing"
+                    ]
+                },
+                "timer": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ComebackResponse": {
+            "type": "object",
+            "properties": {
+                "chances": {
+                    "description": "Matches with the side in question after Q3",
+                    "type": "integer"
+                },
+                "turned": {
+                    "description": "Share of chances",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.RateResponse"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.H2HMeetingResponse": {
+            "type": "object",
+            "properties": {
+                "away": {
+                    "$ref": "#/definitions/dto.TeamInfo"
+                },
+                "home": {
+                    "$ref": "#/definitions/dto.TeamInfo"
+                },
+                "kickoff_at": {
+                    "description": "Midnight of the match day for meetings only known from Goalserve",
+                    "type": "string"
+                },
+                "league_id": {
+                    "type": "integer"
+                },
+                "league_name": {
+                    "type": "string"
+                },
+                "match_id": {
+                    "type": "integer"
+                },
+                "winner": {
+                    "description": "Team ID, null for a draw",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.H2HResponse": {
+            "type": "object",
+            "properties": {
+                "fetched_at": {
+                    "description": "When the Goalserve meetings were fetched",
+                    "type": "string"
+                },
+                "meetings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.H2HMeetingResponse"
+                    }
+                },
+                "pending": {
+                    "description": "The Goalserve meetings are being fetched; ask again later for them",
+                    "type": "boolean"
+                },
+                "source": {
+                    "description": "goalserve when stored matches were topped up from the Goalserve h2h feed",
+                    "type": "string",
+                    "enum": [
+                        "local",
+                        "goalserve"
+                    ]
+                },
+                "summary": {
+                    "$ref": "#/definitions/dto.H2HSummaryResponse"
+                },
+                "team_a": {
+                    "$ref": "#/definitions/dto.TeamResponse"
+                },
+                "team_b": {
+                    "$ref": "#/definitions/dto.TeamResponse"
+                }
+            }
+        },
+        "dto.H2HSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "overall": {
+                    "$ref": "#/definitions/dto.H2HTallyResponse"
+                },
+                "team_a_home": {
+                    "$ref": "#/definitions/dto.H2HTallyResponse"
+                },
+                "team_b_home": {
+                    "$ref": "#/definitions/dto.H2HTallyResponse"
+                }
+            }
+        },
+        "dto.H2HTallyResponse": {
+            "type": "object",
+            "properties": {
+                "draws": {
+                    "type": "integer"
+                },
+                "played": {
+                    "type": "integer"
+                },
+                "team_a_scored": {
+                    "description": "Goals, or points in basketball",
+                    "type": "integer"
+                },
+                "team_a_wins": {
+                    "type": "integer"
+                },
+                "team_b_scored": {
+                    "type": "integer"
+                },
+                "team_b_wins": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "service": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "healthy",
+                        "degraded"
+                    ]
+                },
+                "sync_leader": {
+                    "description": "null when no sync worker holds leadership",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.SyncLeaderResponse"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.LeagueDetailResponse": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "type": "string"
+                },
+                "gid": {
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "Goalserve league ID, as in league_id of matches",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "seasons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SeasonResponse"
+                    }
+                }
+            }
+        },
+        "dto.LeagueQuarterResponse": {
+            "type": "object",
+            "properties": {
+                "avg_away": {
+                    "type": "number"
+                },
+                "avg_home": {
+                    "description": "Rounded to 2 decimals",
+                    "type": "number"
+                },
+                "avg_total": {
+                    "type": "number"
+                },
+                "away_won": {
+                    "$ref": "#/definitions/dto.RateResponse"
+                },
+                "home_won": {
+                    "$ref": "#/definitions/dto.RateResponse"
+                },
+                "quarter": {
+                    "type": "string",
+                    "example": "q1"
+                },
+                "tied": {
+                    "$ref": "#/definitions/dto.RateResponse"
+                }
+            }
+        },
+        "dto.LeagueQuartersResponse": {
+            "type": "object",
+            "properties": {
+                "comebacks": {
+                    "description": "Matches won by the team trailing after Q3",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.ComebackResponse"
+                        }
+                    ]
+                },
+                "from": {
+                    "description": "Requested range, YYYY-MM-DD",
+                    "type": "string"
+                },
+                "league": {
+                    "$ref": "#/definitions/dto.LeagueResponse"
+                },
+                "overtime": {
+                    "$ref": "#/definitions/dto.OvertimeResponse"
+                },
+                "played": {
+                    "description": "Matches with every quarter scored",
+                    "type": "integer"
+                },
+                "quarters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LeagueQuarterResponse"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LeagueResponse": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "type": "string"
+                },
+                "gid": {
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "Goalserve league ID, as in league_id of matches",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MatchChangeResponse": {
+            "type": "object",
+            "properties": {
+                "changed_at": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "new_value": {
+                    "type": "string"
+                },
+                "old_value": {
+                    "type": "string"
+                },
+                "sync_run_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.OverUnderResponse": {
+            "type": "object",
+            "properties": {
+                "line": {
+                    "type": "number",
+                    "example": 2.5
+                },
+                "over": {
+                    "$ref": "#/definitions/dto.RateResponse"
+                },
+                "under": {
+                    "$ref": "#/definitions/dto.RateResponse"
+                }
+            }
+        },
+        "dto.OvertimeResponse": {
+            "type": "object",
+            "properties": {
+                "games": {
+                    "description": "Share of matches played",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.RateResponse"
+                        }
+                    ]
+                },
+                "won": {
+                    "description": "Overtime matches won, for a team",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.PredictionResponse": {
+            "type": "object",
+            "properties": {
+                "away_win": {
+                    "type": "number"
+                },
+                "draw": {
+                    "description": "Always 0 in basketball",
+                    "type": "number"
+                },
+                "expected_away_score": {
+                    "type": "number"
+                },
+                "expected_home_score": {
+                    "description": "Goals, or points in basketball, rounded to 2 decimals",
+                    "type": "number",
+                    "example": 1.63
+                },
+                "home_win": {
+                    "description": "Probabilities, rounded to 3 decimals",
+                    "type": "number",
+                    "example": 0.482
+                },
+                "model": {
+                    "type": "string",
+                    "enum": [
+                        "poisson",
+                        "elo"
+                    ]
+                }
+            }
+        },
+        "dto.QuarterScores": {
+            "type": "object",
+            "properties": {
+                "ot": {
+                    "$ref": "#/definitions/dto.ScorePair"
+                },
+                "q1": {
+                    "$ref": "#/definitions/dto.ScorePair"
+                },
+                "q2": {
+                    "$ref": "#/definitions/dto.ScorePair"
+                },
+                "q3": {
+                    "$ref": "#/definitions/dto.ScorePair"
+                },
+                "q4": {
+                    "$ref": "#/definitions/dto.ScorePair"
+                }
+            }
+        },
+        "dto.RateResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "rate": {
+                    "description": "Rounded to 3 decimals",
+                    "type": "number"
+                }
+            }
+        },
+        "dto.RatingHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "type": "number"
+                },
+                "kickoff_at": {
+                    "description": "RFC3339 in the requested tz",
+                    "type": "string"
+                },
+                "match_id": {
+                    "type": "integer"
+                },
+                "opponent": {
+                    "$ref": "#/definitions/dto.TeamResponse"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "rating_before": {
+                    "description": "After any regression over an off-season",
+                    "type": "number"
+                },
+                "season": {
+                    "type": "string"
+                },
+                "side": {
+                    "description": "home or away",
+                    "type": "string",
+                    "example": "home"
+                }
+            }
+        },
+        "dto.RatingResponse": {
+            "type": "object",
+            "properties": {
+                "last_match_at": {
+                    "description": "RFC3339 in the requested tz",
+                    "type": "string"
+                },
+                "played": {
+                    "description": "Matches rated",
+                    "type": "integer"
+                },
+                "rank": {
+                    "description": "Among the teams listed, 1 for the best rated",
+                    "type": "integer"
+                },
+                "rating": {
+                    "description": "Rounded to 1 decimal",
+                    "type": "number",
+                    "example": 1612.4
+                },
+                "team": {
+                    "$ref": "#/definitions/dto.TeamResponse"
+                }
+            }
+        },
+        "dto.ScorePair": {
+            "type": "object",
+            "properties": {
+                "away": {
+                    "type": "integer"
+                },
+                "home": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.SeasonResponse": {
+            "type": "object",
+            "properties": {
+                "ends_on": {
+                    "description": "Last match day seen in the season",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "2026/2027"
+                },
+                "starts_on": {
+                    "description": "First match day seen in the season",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SoccerEventResponse": {
+            "type": "object",
+            "properties": {
+                "extra_minute": {
+                    "type": "integer"
+                },
+                "minute": {
+                    "type": "integer"
+                },
+                "player": {
+                    "type": "string"
+                },
+                "player_id": {
+                    "type": "integer"
+                },
+                "result": {
+                    "type": "string"
+                },
+                "sequence": {
+                    "type": "integer"
+                },
+                "team_side": {
+                    "description": "\"home\" or \"away\"",
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SoccerMatchResponse": {
+            "type": "object",
+            "properties": {
+                "away_team": {
+                    "$ref": "#/definitions/dto.TeamInfo"
+                },
+                "events": {
+                    "description": "Events is only filled when requested with include=events and the match has events",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SoccerEventResponse"
+                    }
+                },
+                "full_time_score": {
+                    "type": "string"
+                },
+                "half_time_score": {
+                    "type": "string"
+                },
+                "home_team": {
+                    "$ref": "#/definitions/dto.TeamInfo"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kickoff_at": {
+                    "description": "RFC3339 with the requested tz offset",
+                    "type": "string"
+                },
+                "last_changed_at": {
+                    "type": "string"
+                },
+                "league_gid": {
+                    "type": "integer"
+                },
+                "league_id": {
+                    "type": "integer"
+                },
+                "league_name": {
+                    "type": "string"
+                },
+                "match_id": {
+                    "type": "integer"
+                },
+                "missed_syncs": {
+                    "description": "Consecutive syncs of its day the match was absent from",
+                    "type": "integer"
+                },
+                "prediction": {
+                    "description": "Prediction is only filled when requested with include=prediction, for a\nscheduled match in a league with enough results",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.PredictionResponse"
+                        }
+                    ]
+                },
+                "previous_kickoff_at": {
+                    "description": "Kickoff before it last moved, RFC3339 in the requested tz",
+                    "type": "string"
+                },
+                "raw_status": {
+                    "description": "Goalserve status, e.g. the minute (\"67\") while live",
+                    "type": "string"
+                },
+                "rescheduled": {
+                    "description": "Kickoff has moved since the match was first seen",
+                    "type": "boolean"
+                },
+                "sport": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "description": "Kickoff day in the requested tz",
                     "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "scheduled",
+                        "live",
+                        "break",
+                        "finished",
+                        "postponed",
+                        "cancelled",
+                        "abandoned",
+                        "interrupted",
+                        "missing"
+                    ]
                 }
             }
         },
-        "dto.BasketballMatchResponse": {
+        "dto.SoccerTeamMatchResponse": {
             "type": "object",
             "properties": {
                 "away_team": {
                     "$ref": "#/definitions/dto.TeamInfo"
                 },
-                "file_group": {
+                "events": {
+                    "description": "Events is only filled when requested with include=events and the match has events",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SoccerEventResponse"
+                    }
+                },
+                "full_time_score": {
+                    "type": "string"
+                },
+                "half_time_score": {
                     "type": "string"
                 },
                 "home_team": {
@@ -1014,6 +5698,13 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
+                "kickoff_at": {
+                    "description": "RFC3339 with the requested tz offset",
+                    "type": "string"
+                },
+                "last_changed_at": {
+                    "type": "string"
+                },
                 "league_gid": {
                     "type": "integer"
                 },
@@ -1026,101 +5717,368 @@ const docTemplate = `{
                 "match_id": {
                     "type": "integer"
                 },
-                "quarter_scores": {
-                    "$ref": "#/definitions/dto.QuarterScores"
+                "missed_syncs": {
+                    "description": "Consecutive syncs of its day the match was absent from",
+                    "type": "integer"
+                },
+                "prediction": {
+                    "description": "Prediction is only filled when requested with include=prediction, for a\nscheduled match in a league with enough results",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.PredictionResponse"
+                        }
+                    ]
+                },
+                "previous_kickoff_at": {
+                    "description": "Kickoff before it last moved, RFC3339 in the requested tz",
+                    "type": "string"
+                },
+                "raw_status": {
+                    "description": "Goalserve status, e.g. the minute (\"67\") while live",
+                    "type": "string"
+                },
+                "rescheduled": {
+                    "description": "Kickoff has moved since the match was first seen",
+                    "type": "boolean"
+                },
+                "result": {
+                    "description": "Null until the match is finished with a score",
+                    "type": "string",
+                    "enum": [
+                        "W",
+                        "D",
+                        "L"
+                    ]
+                },
+                "side": {
+                    "type": "string",
+                    "enum": [
+                        "home",
+                        "away"
+                    ]
                 },
                 "sport": {
                     "type": "string"
                 },
                 "start_date": {
+                    "description": "Kickoff day in the requested tz",
                     "type": "string"
                 },
                 "start_time": {
                     "type": "string"
                 },
                 "status": {
-                    "type": "string"
-                },
-                "timer": {
-                    "type": "string"
+                    "type": "string",
+                    "enum": [
+                        "scheduled",
+                        "live",
+                        "break",
+                        "finished",
+                        "postponed",
+                        "cancelled",
+                        "abandoned",
+                        "interrupted",
+                        "missing"
+                    ]
                 }
             }
         },
-        "dto.QuarterScores": {
+        "dto.StandingRecordResponse": {
             "type": "object",
             "properties": {
-                "ot": {
-                    "$ref": "#/definitions/dto.ScorePair"
+                "against": {
+                    "type": "integer"
                 },
-                "q1": {
-                    "$ref": "#/definitions/dto.ScorePair"
+                "difference": {
+                    "type": "integer"
                 },
-                "q2": {
-                    "$ref": "#/definitions/dto.ScorePair"
+                "drawn": {
+                    "type": "integer"
                 },
-                "q3": {
-                    "$ref": "#/definitions/dto.ScorePair"
+                "for": {
+                    "description": "Goals, or points in basketball",
+                    "type": "integer"
                 },
-                "q4": {
-                    "$ref": "#/definitions/dto.ScorePair"
+                "lost": {
+                    "type": "integer"
+                },
+                "played": {
+                    "type": "integer"
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "win_pct": {
+                    "description": "Draws count half, rounded to 3 decimals",
+                    "type": "number"
+                },
+                "won": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.ScorePair": {
+        "dto.StandingRowResponse": {
             "type": "object",
             "properties": {
+                "against": {
+                    "type": "integer"
+                },
                 "away": {
+                    "$ref": "#/definitions/dto.StandingRecordResponse"
+                },
+                "difference": {
+                    "type": "integer"
+                },
+                "drawn": {
+                    "type": "integer"
+                },
+                "for": {
+                    "description": "Goals, or points in basketball",
                     "type": "integer"
                 },
                 "home": {
+                    "$ref": "#/definitions/dto.StandingRecordResponse"
+                },
+                "lost": {
+                    "type": "integer"
+                },
+                "played": {
+                    "type": "integer"
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "team": {
+                    "$ref": "#/definitions/dto.TeamResponse"
+                },
+                "win_pct": {
+                    "description": "Draws count half, rounded to 3 decimals",
+                    "type": "number"
+                },
+                "won": {
                     "type": "integer"
                 }
             }
         },
-        "dto.SoccerMatchResponse": {
+        "dto.StandingsResponse": {
             "type": "object",
             "properties": {
-                "away_team": {
-                    "$ref": "#/definitions/dto.TeamInfo"
+                "as_of": {
+                    "description": "Requested as_of day; matches kicking off after it are left out",
+                    "type": "string"
                 },
-                "full_time_score": {
+                "league": {
+                    "$ref": "#/definitions/dto.LeagueResponse"
+                },
+                "season": {
+                    "type": "string",
+                    "example": "2026/2027"
+                },
+                "table": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.StandingRowResponse"
+                    }
+                },
+                "tie_breakers": {
+                    "description": "Ranking criteria in order, the first is the main ranking",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.StreaksResponse": {
+            "type": "object",
+            "properties": {
+                "losing": {
+                    "type": "integer"
+                },
+                "unbeaten": {
+                    "type": "integer"
+                },
+                "winless": {
+                    "type": "integer"
+                },
+                "winning": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.SyncLeaderResponse": {
+            "type": "object",
+            "properties": {
+                "acquired_at": {
                     "type": "string"
                 },
-                "half_time_score": {
+                "healthy": {
+                    "description": "false once the heartbeat is older than the lease",
+                    "type": "boolean"
+                },
+                "heartbeat_age_seconds": {
+                    "type": "number"
+                },
+                "heartbeat_at": {
                     "type": "string"
                 },
-                "home_team": {
-                    "$ref": "#/definitions/dto.TeamInfo"
+                "hostname": {
+                    "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "instance_id": {
+                    "type": "string"
                 },
-                "league_gid": {
+                "pid": {
                     "type": "integer"
+                }
+            }
+        },
+        "dto.SyncRunResponse": {
+            "type": "object",
+            "properties": {
+                "duration_seconds": {
+                    "type": "number"
                 },
-                "league_id": {
+                "error": {
+                    "type": "string"
+                },
+                "failed": {
                     "type": "integer"
                 },
-                "league_name": {
+                "failures": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/database.SyncRunFailure"
+                    }
+                },
+                "feed": {
+                    "type": "string",
+                    "enum": [
+                        "today",
+                        "future",
+                        "retry",
+                        "reprocess",
+                        "backfill"
+                    ]
+                },
+                "finished_at": {
                     "type": "string"
                 },
-                "match_id": {
+                "http_status": {
+                    "description": "Goalserve response status, null when none was received",
                     "type": "integer"
                 },
-                "sport": {
+                "id": {
                     "type": "string"
                 },
-                "start_date": {
+                "inserted": {
+                    "type": "integer"
+                },
+                "sport": {
                     "type": "string"
                 },
-                "start_time": {
+                "started_at": {
                     "type": "string"
                 },
                 "status": {
+                    "type": "string",
+                    "enum": [
+                        "running",
+                        "succeeded",
+                        "failed"
+                    ]
+                },
+                "unchanged": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.TeamDetailResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "Goalserve team ID, as in the home_team and away_team of matches",
+                    "type": "integer"
+                },
+                "leagues": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LeagueResponse"
+                    }
+                },
+                "name": {
                     "type": "string"
                 }
             }
         },
+        "dto.TeamFormResponse": {
+            "type": "object",
+            "properties": {
+                "avg_conceded": {
+                    "type": "number"
+                },
+                "avg_scored": {
+                    "description": "Per match, rounded to 2 decimals",
+                    "type": "number"
+                },
+                "clean_sheets": {
+                    "description": "Soccer only",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.RateResponse"
+                        }
+                    ]
+                },
+                "conceded": {
+                    "type": "integer"
+                },
+                "drawn": {
+                    "type": "integer"
+                },
+                "failed_to_score": {
+                    "$ref": "#/definitions/dto.RateResponse"
+                },
+                "form": {
+                    "description": "Latest results, oldest first",
+                    "type": "string",
+                    "example": "WWDLW"
+                },
+                "lost": {
+                    "type": "integer"
+                },
+                "over_under": {
+                    "description": "Present when a line applies",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.OverUnderResponse"
+                        }
+                    ]
+                },
+                "played": {
+                    "description": "Matches the figures below count",
+                    "type": "integer"
+                },
+                "scored": {
+                    "description": "Goals, or points in basketball",
+                    "type": "integer"
+                },
+                "streaks": {
+                    "$ref": "#/definitions/dto.StreaksResponse"
+                },
+                "team": {
+                    "$ref": "#/definitions/dto.TeamResponse"
+                },
+                "won": {
+                    "type": "integer"
+                }
+            }
+        },
         "dto.TeamInfo": {
             "type": "object",
             "properties": {
@@ -1135,6 +6093,109 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.TeamQuarterResponse": {
+            "type": "object",
+            "properties": {
+                "avg_conceded": {
+                    "type": "number"
+                },
+                "avg_scored": {
+                    "description": "Rounded to 2 decimals",
+                    "type": "number"
+                },
+                "drawn": {
+                    "$ref": "#/definitions/dto.RateResponse"
+                },
+                "lost": {
+                    "$ref": "#/definitions/dto.RateResponse"
+                },
+                "quarter": {
+                    "type": "string",
+                    "example": "q1"
+                },
+                "won": {
+                    "$ref": "#/definitions/dto.RateResponse"
+                }
+            }
+        },
+        "dto.TeamQuartersResponse": {
+            "type": "object",
+            "properties": {
+                "blown_leads": {
+                    "description": "Matches lost after leading after Q3",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.ComebackResponse"
+                        }
+                    ]
+                },
+                "comebacks": {
+                    "description": "Matches won after trailing after Q3",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.ComebackResponse"
+                        }
+                    ]
+                },
+                "from": {
+                    "description": "Requested range, YYYY-MM-DD",
+                    "type": "string"
+                },
+                "overtime": {
+                    "$ref": "#/definitions/dto.OvertimeResponse"
+                },
+                "played": {
+                    "description": "Matches with every quarter scored",
+                    "type": "integer"
+                },
+                "quarters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TeamQuarterResponse"
+                    }
+                },
+                "team": {
+                    "$ref": "#/definitions/dto.TeamResponse"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TeamRatingsResponse": {
+            "type": "object",
+            "properties": {
+                "history": {
+                    "description": "Latest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RatingHistoryResponse"
+                    }
+                },
+                "played": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "description": "Current rating, null before the team's first rated match",
+                    "type": "number"
+                },
+                "team": {
+                    "$ref": "#/definitions/dto.TeamResponse"
+                }
+            }
+        },
+        "dto.TeamResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "Goalserve team ID, as in the home_team and away_team of matches",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
         "middleware.ErrorInfo": {
             "type": "object",
             "properties": {
@@ -1,6 +1,10 @@
 package dto
 
-import "github.com/dusanbre/otg-sports-api/internal/database"
+import (
+	"time"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+)
 
 // QuarterScores represents quarter-by-quarter scores for basketball
 type QuarterScores struct {
@@ -19,29 +23,43 @@ type ScorePair struct {
 
 // BasketballMatchResponse is the API response for a basketball match
 type BasketballMatchResponse struct {
-	ID            int64          `json:"id"`
-	MatchID       int64          `json:"match_id"`
-	Sport         string         `json:"sport"`
-	LeagueID      int64          `json:"league_id"`
-	LeagueGID     int64          `json:"league_gid"`
-	LeagueName    string         `json:"league_name"`
-	FileGroup     string         `json:"file_group,omitempty"`
-	Status        string         `json:"status"`
-	StartDate     string         `json:"start_date"`
-	StartTime     string         `json:"start_time"`
-	Timer         string         `json:"timer,omitempty"`
-	HomeTeam      TeamInfo       `json:"home_team"`
-	AwayTeam      TeamInfo       `json:"away_team"`
-	QuarterScores *QuarterScores `json:"quarter_scores,omitempty"`
+	ID                int64          `json:"id"`
+	MatchID           int64          `json:"match_id"`
+	Sport             string         `json:"sport"`
+	LeagueID          int64          `json:"league_id"`
+	LeagueGID         int64          `json:"league_gid"`
+	LeagueName        string         `json:"league_name"`
+	FileGroup         string         `json:"file_group,omitempty"`
+	Status            string         `json:"status" enums:"scheduled,live,break,finished,postponed,cancelled,abandoned,interrupted,missing"`
+	RawStatus         string         `json:"raw_status,omitempty"` // Goalserve status, e.g. "3rd Quarter"
+	StartDate         string         `json:"start_date"`           // Kickoff day in the requested tz
+	StartTime         string         `json:"start_time"`
+	KickoffAt         string         `json:"kickoff_at,omitempty"`          // RFC3339 with the requested tz offset
+	PreviousKickoffAt string         `json:"previous_kickoff_at,omitempty"` // Kickoff before it last moved, RFC3339 in the requested tz
+	Rescheduled       bool           `json:"rescheduled"`                   // Kickoff has moved since the match was first seen
+	MissedSyncs       int            `json:"missed_syncs,omitempty"`        // Consecutive syncs of its day the match was absent from
+	Timer             string         `json:"timer,omitempty"`
+	HomeTeam          TeamInfo       `json:"home_team"`
+	AwayTeam          TeamInfo       `json:"away_team"`
+	QuarterScores     *QuarterScores `json:"quarter_scores,omitempty"`
+	LastChangedAt     string         `json:"last_changed_at"`
+
+	// Prediction is only filled when requested with include=prediction, for a
+	// scheduled match in a league with enough results
+	Prediction *PredictionResponse `json:"prediction,omitempty"`
 }
 
-// BasketballMatchFromModel converts a database model to API response
-func BasketballMatchFromModel(m *database.BasketballMatch) BasketballMatchResponse {
+// BasketballMatchFromModel converts a database model to API response, with the
+// start date and time given in loc
+func BasketballMatchFromModel(m *database.BasketballMatch, loc *time.Location) BasketballMatchResponse {
 	response := BasketballMatchResponse{
-		ID:         m.ID,
-		Sport:      "basketball",
-		LeagueName: m.LeagueName.String,
-		Status:     m.MatchStatus.String,
+		ID:            m.ID,
+		Sport:         "basketball",
+		LeagueName:    m.LeagueName.String,
+		Status:        m.Status,
+		RawStatus:     m.MatchStatus.String,
+		MissedSyncs:   m.MissedSyncs,
+		LastChangedAt: m.UpdatedAt.Format(time.RFC3339),
 	}
 
 	if m.MatchID.Valid {
@@ -56,11 +74,23 @@ func BasketballMatchFromModel(m *database.BasketballMatch) BasketballMatchRespon
 	if m.FileGroup.Valid {
 		response.FileGroup = m.FileGroup.String
 	}
-	if m.MatchDate.Valid {
-		response.StartDate = m.MatchDate.Time.Format("2006-01-02")
+	if m.PreviousKickoffAt.Valid {
+		response.PreviousKickoffAt = m.PreviousKickoffAt.Time.In(loc).Format(time.RFC3339)
+		response.Rescheduled = true
 	}
-	if m.MatchTime.Valid {
-		response.StartTime = m.MatchTime.String
+	if m.KickoffAt.Valid {
+		kickoff := m.KickoffAt.Time.In(loc)
+		response.KickoffAt = kickoff.Format(time.RFC3339)
+		response.StartDate = kickoff.Format("2006-01-02")
+		response.StartTime = kickoff.Format("15:04:05")
+	} else {
+		// Rows without a kickoff instant only have the feed's local date and time
+		if m.MatchDate.Valid {
+			response.StartDate = m.MatchDate.Time.Format("2006-01-02")
+		}
+		if m.MatchTime.Valid {
+			response.StartTime = m.MatchTime.String
+		}
 	}
 	if m.Timer.Valid {
 		response.Timer = m.Timer.String
@@ -0,0 +1,101 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/dusanbre/otg-sports-api/internal/h2h"
+)
+
+// H2HResponse lists the meetings of two teams, latest first, with their tally
+type H2HResponse struct {
+	TeamA     TeamResponse         `json:"team_a"`
+	TeamB     TeamResponse         `json:"team_b"`
+	Source    string               `json:"source" enums:"local,goalserve"` // goalserve when stored matches were topped up from the Goalserve h2h feed
+	FetchedAt *string              `json:"fetched_at,omitempty"`           // When the Goalserve meetings were fetched
+	Pending   bool                 `json:"pending"`                        // The Goalserve meetings are being fetched; ask again later for them
+	Summary   H2HSummaryResponse   `json:"summary"`
+	Meetings  []H2HMeetingResponse `json:"meetings"`
+}
+
+// H2HSummaryResponse splits the tally by which team was at home
+type H2HSummaryResponse struct {
+	Overall   H2HTallyResponse `json:"overall"`
+	TeamAHome H2HTallyResponse `json:"team_a_home"`
+	TeamBHome H2HTallyResponse `json:"team_b_home"`
+}
+
+// H2HTallyResponse counts meetings from team A's side
+type H2HTallyResponse struct {
+	Played      int `json:"played"`
+	TeamAWins   int `json:"team_a_wins"`
+	Draws       int `json:"draws"`
+	TeamBWins   int `json:"team_b_wins"`
+	TeamAScored int `json:"team_a_scored"` // Goals, or points in basketball
+	TeamBScored int `json:"team_b_scored"`
+}
+
+// H2HMeetingResponse is one finished meeting
+type H2HMeetingResponse struct {
+	MatchID    int64    `json:"match_id,omitempty"`
+	KickoffAt  string   `json:"kickoff_at"` // Midnight of the match day for meetings only known from Goalserve
+	LeagueID   int64    `json:"league_id,omitempty"`
+	LeagueName string   `json:"league_name,omitempty"`
+	Home       TeamInfo `json:"home"`
+	Away       TeamInfo `json:"away"`
+	Winner     *int64   `json:"winner"` // Team ID, null for a draw
+}
+
+// H2HFromModel converts a head-to-head result to API response, with times given in loc
+func H2HFromModel(r *h2h.Result, loc *time.Location) H2HResponse {
+	response := H2HResponse{
+		TeamA:   TeamResponse{ID: r.TeamA.ID, Name: r.TeamA.Name},
+		TeamB:   TeamResponse{ID: r.TeamB.ID, Name: r.TeamB.Name},
+		Source:  r.Source,
+		Pending: r.Pending,
+		Summary: H2HSummaryResponse{
+			Overall:   h2hTally(r.Summary.Overall),
+			TeamAHome: h2hTally(r.Summary.TeamAHome),
+			TeamBHome: h2hTally(r.Summary.TeamBHome),
+		},
+		Meetings: make([]H2HMeetingResponse, len(r.Meetings)),
+	}
+	if r.FetchedAt != nil {
+		fetchedAt := r.FetchedAt.In(loc).Format(time.RFC3339)
+		response.FetchedAt = &fetchedAt
+	}
+	for i, m := range r.Meetings {
+		response.Meetings[i] = h2hMeeting(m, loc)
+	}
+	return response
+}
+
+func h2hTally(t h2h.Tally) H2HTallyResponse {
+	return H2HTallyResponse{
+		Played:      t.Played,
+		TeamAWins:   t.TeamAWins,
+		Draws:       t.Draws,
+		TeamBWins:   t.TeamBWins,
+		TeamAScored: t.TeamAScored,
+		TeamBScored: t.TeamBScored,
+	}
+}
+
+func h2hMeeting(m database.MatchResult, loc *time.Location) H2HMeetingResponse {
+	homeScore, awayScore := m.HomeScore, m.AwayScore
+	meeting := H2HMeetingResponse{
+		MatchID:    m.MatchID,
+		KickoffAt:  m.KickoffAt.In(loc).Format(time.RFC3339),
+		LeagueID:   m.LeagueID,
+		LeagueName: m.LeagueName,
+		Home:       TeamInfo{ID: m.HomeTeamID, Name: m.HomeTeamName, Score: &homeScore},
+		Away:       TeamInfo{ID: m.AwayTeamID, Name: m.AwayTeamName, Score: &awayScore},
+	}
+	switch {
+	case homeScore > awayScore:
+		meeting.Winner = &meeting.Home.ID
+	case awayScore > homeScore:
+		meeting.Winner = &meeting.Away.ID
+	}
+	return meeting
+}
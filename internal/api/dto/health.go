@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+)
+
+// HealthResponse is the API response for the health check
+type HealthResponse struct {
+	Status     string              `json:"status" enums:"healthy,degraded"`
+	Service    string              `json:"service"`
+	SyncLeader *SyncLeaderResponse `json:"sync_leader"` // null when no sync worker holds leadership
+}
+
+// SyncLeaderResponse describes the sync worker currently holding leadership
+type SyncLeaderResponse struct {
+	InstanceID          string  `json:"instance_id"`
+	Hostname            string  `json:"hostname,omitempty"`
+	PID                 *int    `json:"pid,omitempty"`
+	AcquiredAt          string  `json:"acquired_at"`
+	HeartbeatAt         string  `json:"heartbeat_at"`
+	HeartbeatAgeSeconds float64 `json:"heartbeat_age_seconds"`
+	Healthy             bool    `json:"healthy"` // false once the heartbeat is older than the lease
+}
+
+// SyncLeaderFromModel converts a database model to API response
+func SyncLeaderFromModel(l *database.SyncLeader) *SyncLeaderResponse {
+	response := &SyncLeaderResponse{
+		InstanceID:          l.InstanceID,
+		Hostname:            l.Hostname.String,
+		AcquiredAt:          l.AcquiredAt.Format(time.RFC3339),
+		HeartbeatAt:         l.HeartbeatAt.Format(time.RFC3339),
+		HeartbeatAgeSeconds: l.HeartbeatAge.Round(time.Millisecond).Seconds(),
+		Healthy:             l.Healthy(),
+	}
+
+	if l.PID.Valid {
+		pid := int(l.PID.Int32)
+		response.PID = &pid
+	}
+
+	return response
+}
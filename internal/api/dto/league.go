@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"github.com/dusanbre/otg-sports-api/internal/database"
+)
+
+// LeagueResponse represents a league in the API response
+type LeagueResponse struct {
+	ID      int64   `json:"id"` // Goalserve league ID, as in league_id of matches
+	GID     int64   `json:"gid"`
+	Name    string  `json:"name"`
+	Country *string `json:"country"`
+}
+
+// SeasonResponse represents a league season in the API response
+type SeasonResponse struct {
+	Name     string `json:"name" example:"2026/2027"`
+	StartsOn string `json:"starts_on"` // First match day seen in the season
+	EndsOn   string `json:"ends_on"`   // Last match day seen in the season
+}
+
+// LeagueDetailResponse is a league with its seasons, latest first
+type LeagueDetailResponse struct {
+	LeagueResponse
+	Seasons []SeasonResponse `json:"seasons"`
+}
+
+// LeagueFromModel converts a database model to API response
+func LeagueFromModel(l *database.League) LeagueResponse {
+	response := LeagueResponse{
+		ID:   l.LeagueID,
+		GID:  l.GID.Int64,
+		Name: l.Name,
+	}
+
+	if l.Country.Valid {
+		response.Country = &l.Country.String
+	}
+
+	return response
+}
+
+// SeasonFromModel converts a database model to API response
+func SeasonFromModel(s *database.Season) SeasonResponse {
+	return SeasonResponse{
+		Name:     s.Name,
+		StartsOn: s.StartsOn.Format("2006-01-02"),
+		EndsOn:   s.EndsOn.Format("2006-01-02"),
+	}
+}
@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+)
+
+// MatchChangeResponse is the API response for one recorded field change
+type MatchChangeResponse struct {
+	Field     string  `json:"field"`
+	OldValue  *string `json:"old_value"`
+	NewValue  *string `json:"new_value"`
+	ChangedAt string  `json:"changed_at"`
+	SyncRunID string  `json:"sync_run_id"`
+}
+
+// MatchChangeFromModel converts a database model to API response
+func MatchChangeFromModel(c *database.MatchChange) MatchChangeResponse {
+	response := MatchChangeResponse{
+		Field:     c.Field,
+		ChangedAt: c.ChangedAt.Format(time.RFC3339),
+		SyncRunID: c.SyncRunID,
+	}
+
+	if c.OldValue.Valid {
+		response.OldValue = &c.OldValue.String
+	}
+	if c.NewValue.Valid {
+		response.NewValue = &c.NewValue.String
+	}
+
+	return response
+}
@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"math"
+
+	"github.com/dusanbre/otg-sports-api/internal/predictions"
+)
+
+// PredictionResponse is the expected outcome of a scheduled match
+type PredictionResponse struct {
+	Model             string  `json:"model" enums:"poisson,elo"`
+	HomeWin           float64 `json:"home_win" example:"0.482"` // Probabilities, rounded to 3 decimals
+	Draw              float64 `json:"draw"`                     // Always 0 in basketball
+	AwayWin           float64 `json:"away_win"`
+	ExpectedHomeScore float64 `json:"expected_home_score" example:"1.63"` // Goals, or points in basketball, rounded to 2 decimals
+	ExpectedAwayScore float64 `json:"expected_away_score"`
+}
+
+// PredictionFromModel converts a match prediction to API response
+func PredictionFromModel(p *predictions.Prediction) *PredictionResponse {
+	return &PredictionResponse{
+		Model:             p.Model,
+		HomeWin:           math.Round(p.Home*1000) / 1000,
+		Draw:              math.Round(p.Draw*1000) / 1000,
+		AwayWin:           math.Round(p.Away*1000) / 1000,
+		ExpectedHomeScore: math.Round(p.HomeScore*100) / 100,
+		ExpectedAwayScore: math.Round(p.AwayScore*100) / 100,
+	}
+}
@@ -0,0 +1,123 @@
+package dto
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dusanbre/otg-sports-api/internal/analytics"
+)
+
+// TeamQuartersResponse is a basketball team's scoring by quarter
+type TeamQuartersResponse struct {
+	Team       TeamResponse          `json:"team"`
+	From       string                `json:"from,omitempty"` // Requested range, YYYY-MM-DD
+	To         string                `json:"to,omitempty"`
+	Played     int                   `json:"played"` // Matches with every quarter scored
+	Quarters   []TeamQuarterResponse `json:"quarters"`
+	Overtime   OvertimeResponse      `json:"overtime"`
+	Comebacks  ComebackResponse      `json:"comebacks"`   // Matches won after trailing after Q3
+	BlownLeads ComebackResponse      `json:"blown_leads"` // Matches lost after leading after Q3
+}
+
+// TeamQuarterResponse is one quarter from a team's side
+type TeamQuarterResponse struct {
+	Quarter     string       `json:"quarter" example:"q1"`
+	AvgScored   float64      `json:"avg_scored"` // Rounded to 2 decimals
+	AvgConceded float64      `json:"avg_conceded"`
+	Won         RateResponse `json:"won"`
+	Drawn       RateResponse `json:"drawn"`
+	Lost        RateResponse `json:"lost"`
+}
+
+// OvertimeResponse counts matches level after Q4
+type OvertimeResponse struct {
+	Games RateResponse `json:"games"`         // Share of matches played
+	Won   *int         `json:"won,omitempty"` // Overtime matches won, for a team
+}
+
+// ComebackResponse counts matches that turned around in Q4 or overtime
+type ComebackResponse struct {
+	Chances int          `json:"chances"` // Matches with the side in question after Q3
+	Turned  RateResponse `json:"turned"`  // Share of chances
+}
+
+// LeagueQuartersResponse is a basketball league's scoring by quarter
+type LeagueQuartersResponse struct {
+	League    LeagueResponse          `json:"league"`
+	From      string                  `json:"from,omitempty"` // Requested range, YYYY-MM-DD
+	To        string                  `json:"to,omitempty"`
+	Played    int                     `json:"played"` // Matches with every quarter scored
+	Quarters  []LeagueQuarterResponse `json:"quarters"`
+	Overtime  OvertimeResponse        `json:"overtime"`
+	Comebacks ComebackResponse        `json:"comebacks"` // Matches won by the team trailing after Q3
+}
+
+// LeagueQuarterResponse is one quarter over a league's matches
+type LeagueQuarterResponse struct {
+	Quarter  string       `json:"quarter" example:"q1"`
+	AvgHome  float64      `json:"avg_home"` // Rounded to 2 decimals
+	AvgAway  float64      `json:"avg_away"`
+	AvgTotal float64      `json:"avg_total"`
+	HomeWon  RateResponse `json:"home_won"`
+	AwayWon  RateResponse `json:"away_won"`
+	Tied     RateResponse `json:"tied"`
+}
+
+// TeamQuartersFromModel converts a team's quarter sums to API response
+func TeamQuartersFromModel(team TeamResponse, t *analytics.TeamQuarters, from, to string) TeamQuartersResponse {
+	overtimesWon := t.OvertimesWon
+	response := TeamQuartersResponse{
+		Team:       team,
+		From:       from,
+		To:         to,
+		Played:     t.Played,
+		Quarters:   make([]TeamQuarterResponse, len(t.Quarters)),
+		Overtime:   OvertimeResponse{Games: *rate(t.Overtimes, t.Played), Won: &overtimesWon},
+		Comebacks:  ComebackResponse{Chances: t.TrailingAfterQ3, Turned: *rate(t.Comebacks, t.TrailingAfterQ3)},
+		BlownLeads: ComebackResponse{Chances: t.LeadingAfterQ3, Turned: *rate(t.BlownLeads, t.LeadingAfterQ3)},
+	}
+	for i, q := range t.Quarters {
+		response.Quarters[i] = TeamQuarterResponse{
+			Quarter:     fmt.Sprintf("q%d", i+1),
+			AvgScored:   average(q.Scored, t.Played),
+			AvgConceded: average(q.Conceded, t.Played),
+			Won:         *rate(q.Won, t.Played),
+			Drawn:       *rate(q.Drawn, t.Played),
+			Lost:        *rate(q.Lost, t.Played),
+		}
+	}
+	return response
+}
+
+// LeagueQuartersFromModel converts a league's quarter sums to API response
+func LeagueQuartersFromModel(league LeagueResponse, lq *analytics.LeagueQuarters, from, to string) LeagueQuartersResponse {
+	response := LeagueQuartersResponse{
+		League:    league,
+		From:      from,
+		To:        to,
+		Played:    lq.Played,
+		Quarters:  make([]LeagueQuarterResponse, len(lq.Quarters)),
+		Overtime:  OvertimeResponse{Games: *rate(lq.Overtimes, lq.Played)},
+		Comebacks: ComebackResponse{Chances: lq.LedAfterQ3, Turned: *rate(lq.Comebacks, lq.LedAfterQ3)},
+	}
+	for i, q := range lq.Quarters {
+		response.Quarters[i] = LeagueQuarterResponse{
+			Quarter:  fmt.Sprintf("q%d", i+1),
+			AvgHome:  average(q.HomePoints, lq.Played),
+			AvgAway:  average(q.AwayPoints, lq.Played),
+			AvgTotal: average(q.HomePoints+q.AwayPoints, lq.Played),
+			HomeWon:  *rate(q.HomeWon, lq.Played),
+			AwayWon:  *rate(q.AwayWon, lq.Played),
+			Tied:     *rate(q.Tied, lq.Played),
+		}
+	}
+	return response
+}
+
+// average divides total by n, rounded to 2 decimals; zero when n is
+func average(total, n int) float64 {
+	if n == 0 {
+		return 0
+	}
+	return math.Round(float64(total)/float64(n)*100) / 100
+}
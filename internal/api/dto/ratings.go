@@ -0,0 +1,70 @@
+package dto
+
+import (
+	"math"
+	"time"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+)
+
+// RatingResponse is a team's current Elo rating
+type RatingResponse struct {
+	Rank        int          `json:"rank"` // Among the teams listed, 1 for the best rated
+	Team        TeamResponse `json:"team"`
+	Rating      float64      `json:"rating" example:"1612.4"` // Rounded to 1 decimal
+	Played      int          `json:"played"`                  // Matches rated
+	LastMatchAt string       `json:"last_match_at"`           // RFC3339 in the requested tz
+}
+
+// TeamRatingsResponse is a team's current Elo rating and its history
+type TeamRatingsResponse struct {
+	Team    TeamResponse            `json:"team"`
+	Rating  *float64                `json:"rating"` // Current rating, null before the team's first rated match
+	Played  int                     `json:"played"`
+	History []RatingHistoryResponse `json:"history"` // Latest first
+}
+
+// RatingHistoryResponse is the change one match made to a team's rating
+type RatingHistoryResponse struct {
+	MatchID      int64        `json:"match_id"`
+	KickoffAt    string       `json:"kickoff_at"` // RFC3339 in the requested tz
+	Opponent     TeamResponse `json:"opponent"`
+	Side         string       `json:"side" example:"home"` // home or away
+	Season       string       `json:"season,omitempty"`
+	RatingBefore float64      `json:"rating_before"` // After any regression over an off-season
+	Rating       float64      `json:"rating"`
+	Change       float64      `json:"change"`
+}
+
+// RatingFromModel converts a team's current rating to API response
+func RatingFromModel(r *database.RatedTeam, loc *time.Location) RatingResponse {
+	return RatingResponse{
+		Rank:        r.Rank,
+		Team:        TeamResponse{ID: r.TeamID, Name: r.Name},
+		Rating:      roundRating(r.Rating),
+		Played:      r.Played,
+		LastMatchAt: r.LastMatchAt.In(loc).Format(time.RFC3339),
+	}
+}
+
+// RatingHistoryFromModel converts a team's rating after a match to API response
+func RatingHistoryFromModel(r *database.TeamRating, loc *time.Location) RatingHistoryResponse {
+	side := "away"
+	if r.Home {
+		side = "home"
+	}
+	return RatingHistoryResponse{
+		MatchID:      r.MatchID,
+		KickoffAt:    r.KickoffAt.In(loc).Format(time.RFC3339),
+		Opponent:     TeamResponse{ID: r.OpponentID, Name: r.OpponentName},
+		Side:         side,
+		Season:       r.Season,
+		RatingBefore: roundRating(r.RatingBefore),
+		Rating:       roundRating(r.Rating),
+		Change:       roundRating(r.Rating - r.RatingBefore),
+	}
+}
+
+func roundRating(rating float64) float64 {
+	return math.Round(rating*10) / 10
+}
@@ -1,6 +1,10 @@
 package dto
 
-import "github.com/dusanbre/otg-sports-api/internal/database"
+import (
+	"time"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+)
 
 // TeamInfo represents a team in the API response
 type TeamInfo struct {
@@ -11,28 +15,45 @@ type TeamInfo struct {
 
 // SoccerMatchResponse is the API response for a soccer match
 type SoccerMatchResponse struct {
-	ID            int64    `json:"id"`
-	MatchID       int64    `json:"match_id"`
-	Sport         string   `json:"sport"`
-	LeagueID      int64    `json:"league_id"`
-	LeagueGID     int64    `json:"league_gid"`
-	LeagueName    string   `json:"league_name"`
-	Status        string   `json:"status"`
-	StartDate     string   `json:"start_date"`
-	StartTime     string   `json:"start_time"`
-	HomeTeam      TeamInfo `json:"home_team"`
-	AwayTeam      TeamInfo `json:"away_team"`
-	HalfTimeScore string   `json:"half_time_score,omitempty"`
-	FullTimeScore string   `json:"full_time_score,omitempty"`
+	ID                int64    `json:"id"`
+	MatchID           int64    `json:"match_id"`
+	Sport             string   `json:"sport"`
+	LeagueID          int64    `json:"league_id"`
+	LeagueGID         int64    `json:"league_gid"`
+	LeagueName        string   `json:"league_name"`
+	Status            string   `json:"status" enums:"scheduled,live,break,finished,postponed,cancelled,abandoned,interrupted,missing"`
+	RawStatus         string   `json:"raw_status,omitempty"` // Goalserve status, e.g. the minute ("67") while live
+	StartDate         string   `json:"start_date"`           // Kickoff day in the requested tz
+	StartTime         string   `json:"start_time"`
+	KickoffAt         string   `json:"kickoff_at,omitempty"`          // RFC3339 with the requested tz offset
+	PreviousKickoffAt string   `json:"previous_kickoff_at,omitempty"` // Kickoff before it last moved, RFC3339 in the requested tz
+	Rescheduled       bool     `json:"rescheduled"`                   // Kickoff has moved since the match was first seen
+	MissedSyncs       int      `json:"missed_syncs,omitempty"`        // Consecutive syncs of its day the match was absent from
+	HomeTeam          TeamInfo `json:"home_team"`
+	AwayTeam          TeamInfo `json:"away_team"`
+	HalfTimeScore     string   `json:"half_time_score,omitempty"`
+	FullTimeScore     string   `json:"full_time_score,omitempty"`
+	LastChangedAt     string   `json:"last_changed_at"`
+
+	// Events is only filled when requested with include=events and the match has events
+	Events []SoccerEventResponse `json:"events,omitempty"`
+
+	// Prediction is only filled when requested with include=prediction, for a
+	// scheduled match in a league with enough results
+	Prediction *PredictionResponse `json:"prediction,omitempty"`
 }
 
-// SoccerMatchFromModel converts a database model to API response
-func SoccerMatchFromModel(m *database.SoccerMatch) SoccerMatchResponse {
+// SoccerMatchFromModel converts a database model to API response, with the
+// start date and time given in loc
+func SoccerMatchFromModel(m *database.SoccerMatch, loc *time.Location) SoccerMatchResponse {
 	response := SoccerMatchResponse{
-		ID:         m.ID,
-		Sport:      "soccer",
-		LeagueName: m.LeagueName.String,
-		Status:     m.MatchStatus.String,
+		ID:            m.ID,
+		Sport:         "soccer",
+		LeagueName:    m.LeagueName.String,
+		Status:        m.Status,
+		RawStatus:     m.MatchStatus.String,
+		MissedSyncs:   m.MissedSyncs,
+		LastChangedAt: m.UpdatedAt.Format(time.RFC3339),
 	}
 
 	if m.MatchID.Valid {
@@ -44,11 +65,23 @@ func SoccerMatchFromModel(m *database.SoccerMatch) SoccerMatchResponse {
 	if m.LeagueGID.Valid {
 		response.LeagueGID = m.LeagueGID.Int64
 	}
-	if m.MatchStartDate.Valid {
-		response.StartDate = m.MatchStartDate.Time.Format("2006-01-02")
+	if m.PreviousKickoffAt.Valid {
+		response.PreviousKickoffAt = m.PreviousKickoffAt.Time.In(loc).Format(time.RFC3339)
+		response.Rescheduled = true
 	}
-	if m.MatchStartTime.Valid {
-		response.StartTime = m.MatchStartTime.String
+	if m.KickoffAt.Valid {
+		kickoff := m.KickoffAt.Time.In(loc)
+		response.KickoffAt = kickoff.Format(time.RFC3339)
+		response.StartDate = kickoff.Format("2006-01-02")
+		response.StartTime = kickoff.Format("15:04:05")
+	} else {
+		// Rows without a kickoff instant only have the feed's local date and time
+		if m.MatchStartDate.Valid {
+			response.StartDate = m.MatchStartDate.Time.Format("2006-01-02")
+		}
+		if m.MatchStartTime.Valid {
+			response.StartTime = m.MatchStartTime.String
+		}
 	}
 
 	// Home team
@@ -85,10 +118,3 @@ func SoccerMatchFromModel(m *database.SoccerMatch) SoccerMatchResponse {
 
 	return response
 }
-
-// LeagueInfo represents a league in the API response
-type LeagueInfo struct {
-	ID   int64  `json:"id"`
-	GID  int64  `json:"gid"`
-	Name string `json:"name"`
-}
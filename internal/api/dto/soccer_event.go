@@ -0,0 +1,51 @@
+package dto
+
+import "github.com/dusanbre/otg-sports-api/internal/database"
+
+// SoccerEventResponse is the API response for a goal, card or substitution
+type SoccerEventResponse struct {
+	Sequence    int     `json:"sequence"`
+	Type        string  `json:"type"`
+	TeamSide    string  `json:"team_side,omitempty"` // "home" or "away"
+	Player      string  `json:"player,omitempty"`
+	PlayerID    *int64  `json:"player_id,omitempty"`
+	Minute      *int    `json:"minute,omitempty"`
+	ExtraMinute *int    `json:"extra_minute,omitempty"`
+	Result      *string `json:"result,omitempty"`
+}
+
+// SoccerEventFromModel converts a database model to API response
+func SoccerEventFromModel(e *database.SoccerMatchEvent) SoccerEventResponse {
+	response := SoccerEventResponse{
+		Sequence: e.Sequence,
+		Type:     e.Type,
+		TeamSide: e.TeamSide.String,
+		Player:   e.Player.String,
+	}
+
+	if e.PlayerID.Valid {
+		response.PlayerID = &e.PlayerID.Int64
+	}
+	if e.Minute.Valid {
+		minute := int(e.Minute.Int32)
+		response.Minute = &minute
+	}
+	if e.ExtraMinute.Valid {
+		extra := int(e.ExtraMinute.Int32)
+		response.ExtraMinute = &extra
+	}
+	if e.Result.Valid {
+		response.Result = &e.Result.String
+	}
+
+	return response
+}
+
+// SoccerEventsFromModels converts a match's events to API responses
+func SoccerEventsFromModels(events []database.SoccerMatchEvent) []SoccerEventResponse {
+	response := make([]SoccerEventResponse, len(events))
+	for i, e := range events {
+		response[i] = SoccerEventFromModel(&e)
+	}
+	return response
+}
@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"math"
+
+	"github.com/dusanbre/otg-sports-api/internal/standings"
+)
+
+// StandingsResponse is a league table at a point in a season
+type StandingsResponse struct {
+	League      LeagueResponse        `json:"league"`
+	Season      string                `json:"season" example:"2026/2027"`
+	AsOf        string                `json:"as_of,omitempty"` // Requested as_of day; matches kicking off after it are left out
+	TieBreakers []string              `json:"tie_breakers"`    // Ranking criteria in order, the first is the main ranking
+	Table       []StandingRowResponse `json:"table"`
+}
+
+// StandingRecordResponse sums a team's results
+type StandingRecordResponse struct {
+	Played     int     `json:"played"`
+	Won        int     `json:"won"`
+	Drawn      int     `json:"drawn"`
+	Lost       int     `json:"lost"`
+	For        int     `json:"for"` // Goals, or points in basketball
+	Against    int     `json:"against"`
+	Difference int     `json:"difference"`
+	Points     int     `json:"points"`
+	WinPct     float64 `json:"win_pct"` // Draws count half, rounded to 3 decimals
+}
+
+// StandingRowResponse is one team's line of a table, with home and away splits
+type StandingRowResponse struct {
+	Position int          `json:"position"`
+	Team     TeamResponse `json:"team"`
+	StandingRecordResponse
+	Home StandingRecordResponse `json:"home"`
+	Away StandingRecordResponse `json:"away"`
+}
+
+// StandingRowFromModel converts a computed table row to API response
+func StandingRowFromModel(r *standings.Row) StandingRowResponse {
+	return StandingRowResponse{
+		Position:               r.Position,
+		Team:                   TeamResponse{ID: r.TeamID, Name: r.TeamName},
+		StandingRecordResponse: standingRecord(r.Record),
+		Home:                   standingRecord(r.Home),
+		Away:                   standingRecord(r.Away),
+	}
+}
+
+func standingRecord(r standings.Record) StandingRecordResponse {
+	return StandingRecordResponse{
+		Played:     r.Played,
+		Won:        r.Won,
+		Drawn:      r.Drawn,
+		Lost:       r.Lost,
+		For:        r.For,
+		Against:    r.Against,
+		Difference: r.Difference(),
+		Points:     r.Points,
+		WinPct:     math.Round(r.WinPct()*1000) / 1000,
+	}
+}
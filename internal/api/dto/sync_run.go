@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+)
+
+// SyncRunResponse is the API response for one recorded sync run
+type SyncRunResponse struct {
+	ID              string                    `json:"id"`
+	Sport           string                    `json:"sport"`
+	Feed            string                    `json:"feed" enums:"today,future,retry,reprocess,backfill"`
+	Status          string                    `json:"status" enums:"running,succeeded,failed"`
+	StartedAt       string                    `json:"started_at"`
+	FinishedAt      *string                   `json:"finished_at"`
+	DurationSeconds *float64                  `json:"duration_seconds"`
+	HTTPStatus      *int                      `json:"http_status"` // Goalserve response status, null when none was received
+	Inserted        int                       `json:"inserted"`
+	Updated         int                       `json:"updated"`
+	Unchanged       int                       `json:"unchanged"`
+	Failed          int                       `json:"failed"`
+	Failures        []database.SyncRunFailure `json:"failures"`
+	Error           *string                   `json:"error"`
+}
+
+// SyncRunFromModel converts a database model to API response
+func SyncRunFromModel(r *database.SyncRun) SyncRunResponse {
+	response := SyncRunResponse{
+		ID:        r.ID,
+		Sport:     r.Sport,
+		Feed:      r.Feed,
+		Status:    r.Status,
+		StartedAt: r.StartedAt.Format(time.RFC3339),
+		Inserted:  r.Inserted,
+		Updated:   r.Updated,
+		Unchanged: r.Unchanged,
+		Failed:    r.Failed,
+		Failures:  r.Failures,
+	}
+
+	if r.FinishedAt.Valid {
+		finishedAt := r.FinishedAt.Time.Format(time.RFC3339)
+		response.FinishedAt = &finishedAt
+		duration := r.FinishedAt.Time.Sub(r.StartedAt).Round(time.Millisecond).Seconds()
+		response.DurationSeconds = &duration
+	}
+	if r.HTTPStatus.Valid {
+		status := int(r.HTTPStatus.Int32)
+		response.HTTPStatus = &status
+	}
+	if r.Error.Valid {
+		response.Error = &r.Error.String
+	}
+
+	return response
+}
@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"github.com/dusanbre/otg-sports-api/internal/database"
+)
+
+// TeamResponse represents a team in the API response
+type TeamResponse struct {
+	ID   int64  `json:"id"` // Goalserve team ID, as in the home_team and away_team of matches
+	Name string `json:"name"`
+}
+
+// TeamDetailResponse is a team with the leagues it has matches in
+type TeamDetailResponse struct {
+	TeamResponse
+	Leagues []LeagueResponse `json:"leagues"`
+}
+
+// TeamFromModel converts a database model to API response
+func TeamFromModel(t *database.Team) TeamResponse {
+	return TeamResponse{
+		ID:   t.TeamID,
+		Name: t.Name,
+	}
+}
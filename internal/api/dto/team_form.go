@@ -0,0 +1,90 @@
+package dto
+
+import (
+	"math"
+
+	"github.com/dusanbre/otg-sports-api/internal/analytics"
+)
+
+// TeamFormResponse is a team's recent form, streaks and scoring rates
+type TeamFormResponse struct {
+	Team          TeamResponse       `json:"team"`
+	Form          string             `json:"form" example:"WWDLW"` // Latest results, oldest first
+	Streaks       StreaksResponse    `json:"streaks"`
+	Played        int                `json:"played"` // Matches the figures below count
+	Won           int                `json:"won"`
+	Drawn         int                `json:"drawn"`
+	Lost          int                `json:"lost"`
+	Scored        int                `json:"scored"` // Goals, or points in basketball
+	Conceded      int                `json:"conceded"`
+	AvgScored     float64            `json:"avg_scored"` // Per match, rounded to 2 decimals
+	AvgConceded   float64            `json:"avg_conceded"`
+	CleanSheets   *RateResponse      `json:"clean_sheets,omitempty"` // Soccer only
+	FailedToScore *RateResponse      `json:"failed_to_score,omitempty"`
+	OverUnder     *OverUnderResponse `json:"over_under,omitempty"` // Present when a line applies
+}
+
+// StreaksResponse holds a team's current runs of results
+type StreaksResponse struct {
+	Winning  int `json:"winning"`
+	Unbeaten int `json:"unbeaten"`
+	Losing   int `json:"losing"`
+	Winless  int `json:"winless"`
+}
+
+// RateResponse counts the matches meeting a condition and their share of those played
+type RateResponse struct {
+	Count int     `json:"count"`
+	Rate  float64 `json:"rate"` // Rounded to 3 decimals
+}
+
+// OverUnderResponse counts matches by combined score against a line
+type OverUnderResponse struct {
+	Line  float64      `json:"line" example:"2.5"`
+	Over  RateResponse `json:"over"`
+	Under RateResponse `json:"under"`
+}
+
+// TeamFormFromModel converts a team's computed form to API response.
+// cleanSheets adds the clean sheet and failed to score counts, which only
+// mean something in low scoring sports.
+func TeamFormFromModel(team TeamResponse, f *analytics.Form, cleanSheets bool) TeamFormResponse {
+	response := TeamFormResponse{
+		Team: team,
+		Form: f.Results,
+		Streaks: StreaksResponse{
+			Winning:  f.Streaks.Winning,
+			Unbeaten: f.Streaks.Unbeaten,
+			Losing:   f.Streaks.Losing,
+			Winless:  f.Streaks.Winless,
+		},
+		Played:      f.Played,
+		Won:         f.Won,
+		Drawn:       f.Drawn,
+		Lost:        f.Lost,
+		Scored:      f.Scored,
+		Conceded:    f.Conceded,
+		AvgScored:   math.Round(f.AvgScored()*100) / 100,
+		AvgConceded: math.Round(f.AvgConceded()*100) / 100,
+	}
+	if cleanSheets {
+		response.CleanSheets = rate(f.CleanSheets, f.Played)
+		response.FailedToScore = rate(f.FailedToScore, f.Played)
+	}
+	if f.OverUnder != nil {
+		response.OverUnder = &OverUnderResponse{
+			Line:  f.OverUnder.Line,
+			Over:  *rate(f.OverUnder.Over, f.Played),
+			Under: *rate(f.OverUnder.Under, f.Played),
+		}
+	}
+	return response
+}
+
+func rate(count, played int) *RateResponse {
+	r := &RateResponse{Count: count}
+	if played > 0 {
+		r.Rate = math.Round(float64(count)/float64(played)*1000) / 1000
+	}
+	return r
+}
@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"github.com/dusanbre/otg-sports-api/internal/database"
+)
+
+// Outcomes of a finished match from one team's side
+const (
+	ResultWin  = "W"
+	ResultDraw = "D"
+	ResultLoss = "L"
+)
+
+// TeamPerspective describes a match from the side of the requested team
+type TeamPerspective struct {
+	Side   string  `json:"side" enums:"home,away"`
+	Result *string `json:"result" enums:"W,D,L"` // Null until the match is finished with a score
+}
+
+// SoccerTeamMatchResponse is a soccer match as listed for one team
+type SoccerTeamMatchResponse struct {
+	SoccerMatchResponse
+	TeamPerspective
+}
+
+// BasketballTeamMatchResponse is a basketball match as listed for one team
+type BasketballTeamMatchResponse struct {
+	BasketballMatchResponse
+	TeamPerspective
+}
+
+// NewTeamPerspective reads the side and result of teamID from a match's
+// status and teams, as given in its response
+func NewTeamPerspective(teamID int64, status string, home, away TeamInfo) TeamPerspective {
+	p := TeamPerspective{Side: "home"}
+	own, other := home.Score, away.Score
+	if away.ID == teamID && home.ID != teamID {
+		p.Side = "away"
+		own, other = other, own
+	}
+
+	if status != database.StatusFinished || own == nil || other == nil {
+		return p
+	}
+
+	result := ResultDraw
+	switch {
+	case *own > *other:
+		result = ResultWin
+	case *own < *other:
+		result = ResultLoss
+	}
+	p.Result = &result
+	return p
+}
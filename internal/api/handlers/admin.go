@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/dusanbre/otg-sports-api/internal/api/dto"
+	"github.com/dusanbre/otg-sports-api/internal/api/middleware"
+	"github.com/dusanbre/otg-sports-api/internal/database"
+)
+
+// AdminHandler handles operational endpoints for admin API keys
+type AdminHandler struct {
+	db *database.DB
+}
+
+// NewAdminHandler creates a new admin handler
+func NewAdminHandler(db *database.DB) *AdminHandler {
+	return &AdminHandler{db: db}
+}
+
+// GetSyncRuns godoc
+//
+//	@Summary		List sync runs
+//	@Description	Returns recorded sync runs, newest first. Requires an admin API key.
+//	@Description	Use status=succeeded&sport=soccer&limit=1 to find the last successful soccer sync.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			limit	query		int		false	"Maximum results (1-100)"	default(50)
+//	@Param			offset	query		int		false	"Results to skip"			default(0)
+//	@Param			sport	query		string	false	"Filter by sport"	Enums(soccer, basketball)
+//	@Param			feed	query		string	false	"Filter by feed"	Enums(today, future, retry, reprocess, backfill)
+//	@Param			status	query		string	false	"Filter by status"	Enums(running, succeeded, failed)
+//	@Success		200		{object}	middleware.Response{data=[]dto.SyncRunResponse,meta=middleware.MetaInfo}
+//	@Failure		400		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		401		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		403		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		429		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		500		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/admin/sync/runs [get]
+func (h *AdminHandler) GetSyncRuns(w http.ResponseWriter, r *http.Request) {
+	// Limit and offset are parsed the same way as for matches
+	query := parseQueryParams(r)
+	params := database.SyncRunParams{
+		Limit:  query.Limit,
+		Offset: query.Offset,
+		Sport:  strings.ToLower(strings.TrimSpace(r.URL.Query().Get("sport"))),
+		Feed:   strings.ToLower(strings.TrimSpace(r.URL.Query().Get("feed"))),
+		Status: query.Status,
+	}
+
+	if params.Sport != "" && params.Sport != "soccer" && params.Sport != "basketball" {
+		middleware.RespondError(w, http.StatusBadRequest, "INVALID_SPORT", "Invalid sport, expected soccer or basketball")
+		return
+	}
+	if params.Feed != "" && !slices.Contains(database.SyncFeeds, params.Feed) {
+		middleware.RespondError(w, http.StatusBadRequest, "INVALID_FEED",
+			"Invalid feed, expected one of: "+strings.Join(database.SyncFeeds, ", "))
+		return
+	}
+	if params.Status != "" && !slices.Contains(database.SyncRunStatuses, params.Status) {
+		middleware.RespondError(w, http.StatusBadRequest, "INVALID_STATUS",
+			"Invalid status, expected one of: "+strings.Join(database.SyncRunStatuses, ", "))
+		return
+	}
+
+	runs, total, err := h.db.GetSyncRuns(params)
+	if err != nil {
+		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch sync runs")
+		return
+	}
+
+	response := make([]dto.SyncRunResponse, len(runs))
+	for i, run := range runs {
+		response[i] = dto.SyncRunFromModel(&run)
+	}
+
+	middleware.RespondJSONWithMeta(w, http.StatusOK, response, &middleware.MetaInfo{
+		Total:  total,
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	})
+}
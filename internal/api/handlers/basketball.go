@@ -4,20 +4,23 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/dusanbre/otg-sports-api/internal/analytics"
 	"github.com/dusanbre/otg-sports-api/internal/api/dto"
 	"github.com/dusanbre/otg-sports-api/internal/api/middleware"
 	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/dusanbre/otg-sports-api/internal/predictions"
 	"github.com/go-chi/chi/v5"
 )
 
 // BasketballHandler handles basketball-related endpoints
 type BasketballHandler struct {
-	db *database.DB
+	db          *database.DB
+	predictions *predictions.Service
 }
 
 // NewBasketballHandler creates a new basketball handler
-func NewBasketballHandler(db *database.DB) *BasketballHandler {
-	return &BasketballHandler{db: db}
+func NewBasketballHandler(db *database.DB, predictionService *predictions.Service) *BasketballHandler {
+	return &BasketballHandler{db: db, predictions: predictionService}
 }
 
 // GetMatches godoc
@@ -29,10 +32,13 @@ func NewBasketballHandler(db *database.DB) *BasketballHandler {
 //	@Produce		json
 //	@Param			limit		query		int		false	"Maximum results (1-100)"	default(50)
 //	@Param			offset		query		int		false	"Results to skip"			default(0)
-//	@Param			date		query		string	false	"Filter by date (YYYY-MM-DD)"
-//	@Param			status		query		string	false	"Filter by status (FT, Q1, Q2, Q3, Q4, OT, HT)"
+//	@Param			date		query		string	false	"Filter by kickoff day (YYYY-MM-DD) in tz"
+//	@Param			tz			query		string	false	"IANA timezone for date, start_date and start_time"	default(UTC)
+//	@Param			status		query		string	false	"Filter by status"	Enums(scheduled, live, break, finished, postponed, cancelled, abandoned, interrupted, missing)
 //	@Param			league_id	query		int		false	"Filter by league ID"
+//	@Param			include		query		string	false	"Related data to embed (prediction)"
 //	@Success		200			{object}	middleware.Response{data=[]dto.BasketballMatchResponse,meta=middleware.MetaInfo}
+//	@Failure		400			{object}	middleware.Response{error=middleware.ErrorInfo}
 //	@Failure		401			{object}	middleware.Response{error=middleware.ErrorInfo}
 //	@Failure		403			{object}	middleware.Response{error=middleware.ErrorInfo}
 //	@Failure		429			{object}	middleware.Response{error=middleware.ErrorInfo}
@@ -42,7 +48,10 @@ func NewBasketballHandler(db *database.DB) *BasketballHandler {
 //	@Router			/basketball/matches [get]
 func (h *BasketballHandler) GetMatches(w http.ResponseWriter, r *http.Request) {
 	// Parse query parameters
-	params := parseQueryParams(r)
+	params, ok := parseMatchParams(w, r)
+	if !ok {
+		return
+	}
 
 	// Fetch matches from database
 	matches, total, err := h.db.GetBasketballMatchesFiltered(params)
@@ -54,7 +63,14 @@ func (h *BasketballHandler) GetMatches(w http.ResponseWriter, r *http.Request) {
 	// Convert to DTOs
 	response := make([]dto.BasketballMatchResponse, len(matches))
 	for i, m := range matches {
-		response[i] = dto.BasketballMatchFromModel(&m)
+		response[i] = dto.BasketballMatchFromModel(&m, params.Location)
+	}
+
+	if parseInclude(r)["prediction"] {
+		if err := h.attachPredictions(response); err != nil {
+			middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to predict matches")
+			return
+		}
 	}
 
 	middleware.RespondJSONWithMeta(w, http.StatusOK, response, &middleware.MetaInfo{
@@ -71,11 +87,14 @@ func (h *BasketballHandler) GetMatches(w http.ResponseWriter, r *http.Request) {
 //	@Tags			basketball
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path		int	true	"Match ID"
-//	@Success		200	{object}	middleware.Response{data=dto.BasketballMatchResponse}
-//	@Failure		400	{object}	middleware.Response{error=middleware.ErrorInfo}
-//	@Failure		401	{object}	middleware.Response{error=middleware.ErrorInfo}
-//	@Failure		404	{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Param			id		path		int		true	"Match ID"
+//	@Param			tz		query		string	false	"IANA timezone for start_date and start_time"	default(UTC)
+//	@Param			include	query		string	false	"Related data to embed (prediction)"
+//	@Success		200		{object}	middleware.Response{data=dto.BasketballMatchResponse}
+//	@Failure		400		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		401		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		404		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		500		{object}	middleware.Response{error=middleware.ErrorInfo}
 //	@Security		ApiKeyAuth
 //	@Security		BearerAuth
 //	@Router			/basketball/matches/{id} [get]
@@ -87,24 +106,84 @@ func (h *BasketballHandler) GetMatch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	loc, ok := parseLocation(w, r)
+	if !ok {
+		return
+	}
+
 	match, err := h.db.GetBasketballMatchByID(id)
 	if err != nil {
 		middleware.RespondError(w, http.StatusNotFound, "NOT_FOUND", "Match not found")
 		return
 	}
 
-	response := dto.BasketballMatchFromModel(match)
-	middleware.RespondJSON(w, http.StatusOK, response)
+	response := []dto.BasketballMatchResponse{dto.BasketballMatchFromModel(match, loc)}
+
+	if parseInclude(r)["prediction"] {
+		if err := h.attachPredictions(response); err != nil {
+			middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to predict matches")
+			return
+		}
+	}
+
+	middleware.RespondJSON(w, http.StatusOK, response[0])
+}
+
+// GetMatchChanges godoc
+//
+//	@Summary		Get basketball match change history
+//	@Description	Returns the field changes sync recorded for a basketball match, newest first
+//	@Tags			basketball
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int	true	"Match ID"
+//	@Param			limit	query		int	false	"Maximum results (1-100)"	default(50)
+//	@Param			offset	query		int	false	"Results to skip"			default(0)
+//	@Success		200		{object}	middleware.Response{data=[]dto.MatchChangeResponse,meta=middleware.MetaInfo}
+//	@Failure		400		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		401		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		500		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/basketball/matches/{id}/changes [get]
+func (h *BasketballHandler) GetMatchChanges(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		middleware.RespondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid match ID")
+		return
+	}
+
+	params := parseQueryParams(r)
+
+	changes, total, err := h.db.GetMatchChanges("basketball", id, params)
+	if err != nil {
+		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch match changes")
+		return
+	}
+
+	response := make([]dto.MatchChangeResponse, len(changes))
+	for i, c := range changes {
+		response[i] = dto.MatchChangeFromModel(&c)
+	}
+
+	middleware.RespondJSONWithMeta(w, http.StatusOK, response, &middleware.MetaInfo{
+		Total:  total,
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	})
 }
 
 // GetLiveMatches godoc
 //
 //	@Summary		Get live basketball matches
-//	@Description	Returns all currently live basketball matches
+//	@Description	Returns all basketball matches in play (status live or break)
 //	@Tags			basketball
 //	@Accept			json
 //	@Produce		json
+//	@Param			tz	query		string	false	"IANA timezone for start_date and start_time"	default(UTC)
 //	@Success		200	{object}	middleware.Response{data=[]dto.BasketballMatchResponse}
+//	@Failure		400	{object}	middleware.Response{error=middleware.ErrorInfo}
 //	@Failure		401	{object}	middleware.Response{error=middleware.ErrorInfo}
 //	@Failure		403	{object}	middleware.Response{error=middleware.ErrorInfo}
 //	@Failure		500	{object}	middleware.Response{error=middleware.ErrorInfo}
@@ -112,6 +191,11 @@ func (h *BasketballHandler) GetMatch(w http.ResponseWriter, r *http.Request) {
 //	@Security		BearerAuth
 //	@Router			/basketball/matches/live [get]
 func (h *BasketballHandler) GetLiveMatches(w http.ResponseWriter, r *http.Request) {
+	loc, ok := parseLocation(w, r)
+	if !ok {
+		return
+	}
+
 	matches, err := h.db.GetLiveBasketballMatches()
 	if err != nil {
 		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch live matches")
@@ -120,7 +204,7 @@ func (h *BasketballHandler) GetLiveMatches(w http.ResponseWriter, r *http.Reques
 
 	response := make([]dto.BasketballMatchResponse, len(matches))
 	for i, m := range matches {
-		response[i] = dto.BasketballMatchFromModel(&m)
+		response[i] = dto.BasketballMatchFromModel(&m, loc)
 	}
 
 	middleware.RespondJSON(w, http.StatusOK, response)
@@ -129,11 +213,11 @@ func (h *BasketballHandler) GetLiveMatches(w http.ResponseWriter, r *http.Reques
 // GetLeagues godoc
 //
 //	@Summary		Get basketball leagues
-//	@Description	Returns a list of all available basketball leagues
+//	@Description	Returns all basketball leagues sync has seen, ordered by name
 //	@Tags			basketball
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{object}	middleware.Response{data=[]database.LeagueInfo}
+//	@Success		200	{object}	middleware.Response{data=[]dto.LeagueResponse}
 //	@Failure		401	{object}	middleware.Response{error=middleware.ErrorInfo}
 //	@Failure		403	{object}	middleware.Response{error=middleware.ErrorInfo}
 //	@Failure		500	{object}	middleware.Response{error=middleware.ErrorInfo}
@@ -141,11 +225,331 @@ func (h *BasketballHandler) GetLiveMatches(w http.ResponseWriter, r *http.Reques
 //	@Security		BearerAuth
 //	@Router			/basketball/leagues [get]
 func (h *BasketballHandler) GetLeagues(w http.ResponseWriter, r *http.Request) {
-	leagues, err := h.db.GetBasketballLeagues()
+	respondLeagues(w, h.db, "basketball")
+}
+
+// GetLeague godoc
+//
+//	@Summary		Get a basketball league
+//	@Description	Returns a basketball league by its Goalserve ID, with the seasons sync has seen, latest first
+//	@Tags			basketball
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"League ID"
+//	@Success		200	{object}	middleware.Response{data=dto.LeagueDetailResponse}
+//	@Failure		400	{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		401	{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		403	{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		404	{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		500	{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/basketball/leagues/{id} [get]
+func (h *BasketballHandler) GetLeague(w http.ResponseWriter, r *http.Request) {
+	respondLeague(w, r, h.db, "basketball")
+}
+
+// GetTeams godoc
+//
+//	@Summary		List basketball teams
+//	@Description	Returns a paginated list of basketball teams ordered by name
+//	@Tags			basketball
+//	@Accept			json
+//	@Produce		json
+//	@Param			limit		query		int		false	"Maximum results (1-100)"	default(50)
+//	@Param			offset		query		int		false	"Results to skip"			default(0)
+//	@Param			search		query		string	false	"Case-insensitive part of the team name"
+//	@Param			league_id	query		int		false	"Only teams with matches in this league"
+//	@Success		200			{object}	middleware.Response{data=[]dto.TeamResponse,meta=middleware.MetaInfo}
+//	@Failure		401			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		403			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		500			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/basketball/teams [get]
+func (h *BasketballHandler) GetTeams(w http.ResponseWriter, r *http.Request) {
+	respondTeams(w, r, h.db, "basketball")
+}
+
+// GetTeam godoc
+//
+//	@Summary		Get a basketball team
+//	@Description	Returns a basketball team by its Goalserve ID, with the leagues it has matches in
+//	@Tags			basketball
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Team ID"
+//	@Success		200	{object}	middleware.Response{data=dto.TeamDetailResponse}
+//	@Failure		400	{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		401	{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		403	{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		404	{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		500	{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/basketball/teams/{id} [get]
+func (h *BasketballHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
+	respondTeam(w, r, h.db, "basketball")
+}
+
+// GetTeamMatches godoc
+//
+//	@Summary		List a basketball team's matches
+//	@Description	Returns a paginated list of the basketball matches a team plays home or away, with its side and W/D/L result. when=upcoming lists scheduled and in-play matches soonest first, when=past finished matches latest first
+//	@Tags			basketball
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int		true	"Team ID"
+//	@Param			when		query		string	false	"Upcoming or past matches only"	Enums(upcoming, past)
+//	@Param			limit		query		int		false	"Maximum results (1-100)"	default(50)
+//	@Param			offset		query		int		false	"Results to skip"			default(0)
+//	@Param			date		query		string	false	"Filter by kickoff day (YYYY-MM-DD) in tz"
+//	@Param			tz			query		string	false	"IANA timezone for date, start_date and start_time"	default(UTC)
+//	@Param			status		query		string	false	"Filter by status"	Enums(scheduled, live, break, finished, postponed, cancelled, abandoned, interrupted, missing)
+//	@Param			league_id	query		int		false	"Filter by league ID"
+//	@Param			include		query		string	false	"Related data to embed (prediction)"
+//	@Success		200			{object}	middleware.Response{data=[]dto.BasketballTeamMatchResponse,meta=middleware.MetaInfo}
+//	@Failure		400			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		401			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		403			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		404			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		500			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/basketball/teams/{id}/matches [get]
+func (h *BasketballHandler) GetTeamMatches(w http.ResponseWriter, r *http.Request) {
+	params, team, ok := parseTeamMatchParams(w, r, h.db, "basketball")
+	if !ok {
+		return
+	}
+
+	matches, total, err := h.db.GetBasketballMatchesFiltered(params)
+	if err != nil {
+		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch matches")
+		return
+	}
+
+	base := make([]dto.BasketballMatchResponse, len(matches))
+	for i, m := range matches {
+		base[i] = dto.BasketballMatchFromModel(&m, params.Location)
+	}
+
+	if parseInclude(r)["prediction"] {
+		if err := h.attachPredictions(base); err != nil {
+			middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to predict matches")
+			return
+		}
+	}
+
+	response := make([]dto.BasketballTeamMatchResponse, len(base))
+	for i, m := range base {
+		response[i] = dto.BasketballTeamMatchResponse{
+			BasketballMatchResponse: m,
+			TeamPerspective:         dto.NewTeamPerspective(team.TeamID, m.Status, m.HomeTeam, m.AwayTeam),
+		}
+	}
+
+	middleware.RespondJSONWithMeta(w, http.StatusOK, response, &middleware.MetaInfo{
+		Total:  total,
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	})
+}
+
+// GetTeamForm godoc
+//
+//	@Summary		Get a basketball team's form
+//	@Description	Returns a basketball team's latest results as a form string (oldest first) and its current winning, unbeaten, losing and winless streaks, with its record, average points and, when a line is given, over/under rate over its latest finished matches
+//	@Tags			basketball
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int		true	"Team ID"
+//	@Param			last		query		int		false	"Results in the form string (1-20)"	default(5)
+//	@Param			matches		query		int		false	"Latest matches the record, averages and rates count (1-100)"	default(10)
+//	@Param			line		query		number	false	"Over/under total points line, e.g. 215.5"
+//	@Param			league_id	query		int		false	"Only count matches in this league"
+//	@Success		200			{object}	middleware.Response{data=dto.TeamFormResponse}
+//	@Failure		400			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		401			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		403			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		404			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		500			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/basketball/teams/{id}/form [get]
+func (h *BasketballHandler) GetTeamForm(w http.ResponseWriter, r *http.Request) {
+	respondTeamForm(w, r, h.db, "basketball", nil, false)
+}
+
+// GetRatings godoc
+//
+//	@Summary		List basketball team ratings
+//	@Description	Returns the current Elo rating of every rated basketball team, best first, with the number of matches rated. Ratings update after each sync.
+//	@Tags			basketball
+//	@Accept			json
+//	@Produce		json
+//	@Param			league_id	query		int		false	"Only teams with matches in this league"
+//	@Param			tz			query		string	false	"IANA timezone for last_match_at"	default(UTC)
+//	@Param			limit		query		int		false	"Maximum results (1-100)"	default(50)
+//	@Param			offset		query		int		false	"Results to skip"			default(0)
+//	@Success		200			{object}	middleware.Response{data=[]dto.RatingResponse}
+//	@Failure		400			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		401			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		403			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		500			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/basketball/ratings [get]
+func (h *BasketballHandler) GetRatings(w http.ResponseWriter, r *http.Request) {
+	respondRatings(w, r, h.db, "basketball")
+}
+
+// GetTeamRatings godoc
+//
+//	@Summary		Get a basketball team's rating history
+//	@Description	Returns a basketball team's current Elo rating and its rating before and after each rated match, latest first
+//	@Tags			basketball
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int		true	"Team ID"
+//	@Param			tz		query		string	false	"IANA timezone for kickoff_at"	default(UTC)
+//	@Param			limit	query		int		false	"Maximum history entries (1-100)"	default(50)
+//	@Param			offset	query		int		false	"Results to skip"					default(0)
+//	@Success		200		{object}	middleware.Response{data=dto.TeamRatingsResponse}
+//	@Failure		400		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		401		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		403		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		404		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		500		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/basketball/teams/{id}/ratings [get]
+func (h *BasketballHandler) GetTeamRatings(w http.ResponseWriter, r *http.Request) {
+	respondTeamRatings(w, r, h.db, "basketball")
+}
+
+// GetTeamQuarters godoc
+//
+//	@Summary		Get a basketball team's quarter analytics
+//	@Description	Returns a basketball team's average points scored and conceded in each quarter, how often it wins each quarter, how often its matches go to overtime, and how often it wins after trailing after Q3 or loses after leading. Counts finished matches with every quarter scored
+//	@Tags			basketball
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int		true	"Team ID"
+//	@Param			from	query		string	false	"First tip-off day (YYYY-MM-DD) in tz"
+//	@Param			to		query		string	false	"Last tip-off day (YYYY-MM-DD) in tz"
+//	@Param			tz		query		string	false	"IANA timezone of from and to"	default(UTC)
+//	@Success		200		{object}	middleware.Response{data=dto.TeamQuartersResponse}
+//	@Failure		400		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		401		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		403		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		404		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		500		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/basketball/teams/{id}/quarters [get]
+func (h *BasketballHandler) GetTeamQuarters(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid team ID")
+		return
+	}
+
+	from, to, ok := parseDateRange(w, r)
+	if !ok {
+		return
+	}
+
+	team, err := h.db.GetTeam("basketball", id)
+	if err != nil {
+		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch team")
+		return
+	}
+	if team == nil {
+		middleware.RespondError(w, http.StatusNotFound, "NOT_FOUND", "Team not found")
+		return
+	}
+
+	lines, err := h.db.GetBasketballQuarterLines(database.QuarterParams{TeamRef: &team.ID, From: from, To: to})
+	if err != nil {
+		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch matches")
+		return
+	}
+
+	quarters := analytics.ComputeTeamQuarters(team.TeamID, lines)
+	middleware.RespondJSON(w, http.StatusOK, dto.TeamQuartersFromModel(dto.TeamFromModel(team), &quarters,
+		r.URL.Query().Get("from"), r.URL.Query().Get("to")))
+}
+
+// GetLeagueQuarters godoc
+//
+//	@Summary		Get a basketball league's quarter analytics
+//	@Description	Returns the average home and away points in each quarter of a basketball league, how often the home or away team wins each quarter, how often matches go to overtime, and how often the team trailing after Q3 wins. Counts finished matches with every quarter scored
+//	@Tags			basketball
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int		true	"League ID"
+//	@Param			from	query		string	false	"First tip-off day (YYYY-MM-DD) in tz"
+//	@Param			to		query		string	false	"Last tip-off day (YYYY-MM-DD) in tz"
+//	@Param			tz		query		string	false	"IANA timezone of from and to"	default(UTC)
+//	@Success		200		{object}	middleware.Response{data=dto.LeagueQuartersResponse}
+//	@Failure		400		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		401		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		403		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		404		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		500		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/basketball/leagues/{id}/quarters [get]
+func (h *BasketballHandler) GetLeagueQuarters(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid league ID")
+		return
+	}
+
+	from, to, ok := parseDateRange(w, r)
+	if !ok {
+		return
+	}
+
+	league, err := h.db.GetLeague("basketball", id)
+	if err != nil {
+		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch league")
+		return
+	}
+	if league == nil {
+		middleware.RespondError(w, http.StatusNotFound, "NOT_FOUND", "League not found")
+		return
+	}
+
+	lines, err := h.db.GetBasketballQuarterLines(database.QuarterParams{LeagueID: &league.LeagueID, From: from, To: to})
 	if err != nil {
-		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch leagues")
+		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch matches")
 		return
 	}
 
-	middleware.RespondJSON(w, http.StatusOK, leagues)
+	quarters := analytics.ComputeLeagueQuarters(lines)
+	middleware.RespondJSON(w, http.StatusOK, dto.LeagueQuartersFromModel(dto.LeagueFromModel(league), &quarters,
+		r.URL.Query().Get("from"), r.URL.Query().Get("to")))
+}
+
+// attachPredictions embeds the predictions of the scheduled matches
+func (h *BasketballHandler) attachPredictions(matches []dto.BasketballMatchResponse) error {
+	ids := make([]int64, len(matches))
+	for i, m := range matches {
+		ids[i] = m.MatchID
+	}
+
+	predicted, err := h.predictions.Predict("basketball", ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range matches {
+		if p, ok := predicted[matches[i].MatchID]; ok {
+			matches[i].Prediction = dto.PredictionFromModel(&p)
+		}
+	}
+	return nil
 }
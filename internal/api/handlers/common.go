@@ -3,7 +3,10 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/dusanbre/otg-sports-api/internal/api/middleware"
 	"github.com/dusanbre/otg-sports-api/internal/database"
 )
 
@@ -31,8 +34,8 @@ func parseQueryParams(r *http.Request) database.QueryParams {
 	// Parse date filter
 	params.Date = r.URL.Query().Get("date")
 
-	// Parse status filter
-	params.Status = r.URL.Query().Get("status")
+	// Parse status filter (canonical status, checked by parseMatchParams)
+	params.Status = strings.ToLower(strings.TrimSpace(r.URL.Query().Get("status")))
 
 	// Parse league_id filter
 	if leagueIDStr := r.URL.Query().Get("league_id"); leagueIDStr != "" {
@@ -43,3 +46,97 @@ func parseQueryParams(r *http.Request) database.QueryParams {
 
 	return params
 }
+
+// parseMatchParams parses the match list filters, including the tz they apply in.
+// It responds with 400 and returns false when a filter is invalid.
+func parseMatchParams(w http.ResponseWriter, r *http.Request) (database.QueryParams, bool) {
+	params := parseQueryParams(r)
+
+	loc, ok := parseLocation(w, r)
+	if !ok {
+		return params, false
+	}
+	params.Location = loc
+
+	if params.Date != "" {
+		if _, err := time.Parse("2006-01-02", params.Date); err != nil {
+			middleware.RespondError(w, http.StatusBadRequest, "INVALID_DATE", "Invalid date, expected YYYY-MM-DD")
+			return params, false
+		}
+	}
+
+	if params.Status != "" && !database.IsMatchStatus(params.Status) {
+		middleware.RespondError(w, http.StatusBadRequest, "INVALID_STATUS",
+			"Invalid status, expected one of: "+strings.Join(database.MatchStatuses, ", "))
+		return params, false
+	}
+
+	return params, true
+}
+
+// parseLocation reads the tz query parameter (an IANA name such as
+// Europe/Belgrade, default UTC). It responds with 400 and returns false when
+// the zone is unknown.
+func parseLocation(w http.ResponseWriter, r *http.Request) (*time.Location, bool) {
+	name := strings.TrimSpace(r.URL.Query().Get("tz"))
+	if name == "" {
+		return time.UTC, true
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil || name == "Local" {
+		middleware.RespondError(w, http.StatusBadRequest, "INVALID_TIMEZONE", "Invalid tz, expected an IANA timezone such as Europe/London")
+		return nil, false
+	}
+	return loc, true
+}
+
+// parseDateRange reads the optional from and to days (YYYY-MM-DD, both
+// included) in the tz parameter's timezone as a kickoff range ending before
+// the day after to. It responds with an error and returns false when either
+// is invalid or they are out of order.
+func parseDateRange(w http.ResponseWriter, r *http.Request) (from, to time.Time, ok bool) {
+	loc, ok := parseLocation(w, r)
+	if !ok {
+		return from, to, false
+	}
+
+	for _, bound := range []struct {
+		name   string
+		target *time.Time
+	}{{"from", &from}, {"to", &to}} {
+		value := strings.TrimSpace(r.URL.Query().Get(bound.name))
+		if value == "" {
+			continue
+		}
+		day, err := time.ParseInLocation("2006-01-02", value, loc)
+		if err != nil {
+			middleware.RespondError(w, http.StatusBadRequest, "INVALID_DATE", "Invalid "+bound.name+", expected YYYY-MM-DD")
+			return from, to, false
+		}
+		*bound.target = day
+	}
+
+	if !to.IsZero() {
+		to = to.AddDate(0, 0, 1)
+		if !from.IsZero() && !from.Before(to) {
+			middleware.RespondError(w, http.StatusBadRequest, "INVALID_DATE", "from must not be after to")
+			return from, to, false
+		}
+	}
+	return from, to, true
+}
+
+// parseInclude returns the set of optional relations requested with
+// include=a,b (or repeated include parameters)
+func parseInclude(r *http.Request) map[string]bool {
+	include := make(map[string]bool)
+	for _, value := range r.URL.Query()["include"] {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+				include[name] = true
+			}
+		}
+	}
+	return include
+}
@@ -0,0 +1,310 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dusanbre/otg-sports-api/internal/analytics"
+	"github.com/dusanbre/otg-sports-api/internal/api/dto"
+	"github.com/dusanbre/otg-sports-api/internal/api/middleware"
+	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/go-chi/chi/v5"
+)
+
+// The league and team endpoints are the same for every sport. SoccerHandler
+// and BasketballHandler document them per sport and delegate here.
+
+// respondLeagues lists the leagues of sport
+func respondLeagues(w http.ResponseWriter, db *database.DB, sport string) {
+	leagues, err := db.GetLeagues(sport)
+	if err != nil {
+		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch leagues")
+		return
+	}
+
+	response := make([]dto.LeagueResponse, len(leagues))
+	for i, l := range leagues {
+		response[i] = dto.LeagueFromModel(&l)
+	}
+
+	middleware.RespondJSON(w, http.StatusOK, response)
+}
+
+// respondLeague returns the league of sport named by the id path parameter, with its seasons
+func respondLeague(w http.ResponseWriter, r *http.Request, db *database.DB, sport string) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid league ID")
+		return
+	}
+
+	league, err := db.GetLeague(sport, id)
+	if err != nil {
+		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch league")
+		return
+	}
+	if league == nil {
+		middleware.RespondError(w, http.StatusNotFound, "NOT_FOUND", "League not found")
+		return
+	}
+
+	seasons, err := db.GetSeasons(league.ID)
+	if err != nil {
+		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch seasons")
+		return
+	}
+
+	response := dto.LeagueDetailResponse{
+		LeagueResponse: dto.LeagueFromModel(league),
+		Seasons:        make([]dto.SeasonResponse, len(seasons)),
+	}
+	for i, s := range seasons {
+		response.Seasons[i] = dto.SeasonFromModel(&s)
+	}
+
+	middleware.RespondJSON(w, http.StatusOK, response)
+}
+
+// respondTeams lists the teams of sport, filtered by the search and league_id parameters
+func respondTeams(w http.ResponseWriter, r *http.Request, db *database.DB, sport string) {
+	common := parseQueryParams(r)
+	params := database.TeamParams{
+		Limit:    common.Limit,
+		Offset:   common.Offset,
+		Search:   strings.TrimSpace(r.URL.Query().Get("search")),
+		LeagueID: common.LeagueID,
+	}
+
+	teams, total, err := db.GetTeams(sport, params)
+	if err != nil {
+		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch teams")
+		return
+	}
+
+	response := make([]dto.TeamResponse, len(teams))
+	for i, t := range teams {
+		response[i] = dto.TeamFromModel(&t)
+	}
+
+	middleware.RespondJSONWithMeta(w, http.StatusOK, response, &middleware.MetaInfo{
+		Total:  total,
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	})
+}
+
+// respondTeam returns the team of sport named by the id path parameter, with its leagues
+func respondTeam(w http.ResponseWriter, r *http.Request, db *database.DB, sport string) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid team ID")
+		return
+	}
+
+	team, err := db.GetTeam(sport, id)
+	if err != nil {
+		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch team")
+		return
+	}
+	if team == nil {
+		middleware.RespondError(w, http.StatusNotFound, "NOT_FOUND", "Team not found")
+		return
+	}
+
+	leagues, err := db.GetTeamLeagues(sport, team.ID)
+	if err != nil {
+		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch team leagues")
+		return
+	}
+
+	response := dto.TeamDetailResponse{
+		TeamResponse: dto.TeamFromModel(team),
+		Leagues:      make([]dto.LeagueResponse, len(leagues)),
+	}
+	for i, l := range leagues {
+		response.Leagues[i] = dto.LeagueFromModel(&l)
+	}
+
+	middleware.RespondJSON(w, http.StatusOK, response)
+}
+
+// parseTeamMatchParams parses the match filters of a team's match list and
+// looks up the team named by the id path parameter. It responds with an error
+// and returns false when a filter is invalid or the team is unknown.
+func parseTeamMatchParams(w http.ResponseWriter, r *http.Request, db *database.DB, sport string) (database.QueryParams, *database.Team, bool) {
+	params, ok := parseMatchParams(w, r)
+	if !ok {
+		return params, nil, false
+	}
+
+	params.When = strings.ToLower(strings.TrimSpace(r.URL.Query().Get("when")))
+	if params.When != "" && params.When != database.WhenUpcoming && params.When != database.WhenPast {
+		middleware.RespondError(w, http.StatusBadRequest, "INVALID_WHEN", "Invalid when, expected upcoming or past")
+		return params, nil, false
+	}
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid team ID")
+		return params, nil, false
+	}
+
+	team, err := db.GetTeam(sport, id)
+	if err != nil {
+		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch team")
+		return params, nil, false
+	}
+	if team == nil {
+		middleware.RespondError(w, http.StatusNotFound, "NOT_FOUND", "Team not found")
+		return params, nil, false
+	}
+
+	params.TeamRef = &team.ID
+	return params, team, true
+}
+
+// formLookback is how many of a team's latest results the form endpoints
+// read; streaks longer than this are reported at this length
+const formLookback = 100
+
+// respondTeamForm returns the form of the team of sport named by the id path
+// parameter. line is the default over/under line, nil for none; cleanSheets
+// adds clean sheet counts.
+func respondTeamForm(w http.ResponseWriter, r *http.Request, db *database.DB, sport string, line *float64, cleanSheets bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid team ID")
+		return
+	}
+
+	opts := analytics.FormOptions{Last: 5, Window: 10, Line: line}
+	if last, err := strconv.Atoi(r.URL.Query().Get("last")); err == nil && last > 0 && last <= 20 {
+		opts.Last = last
+	}
+	if window, err := strconv.Atoi(r.URL.Query().Get("matches")); err == nil && window > 0 && window <= formLookback {
+		opts.Window = window
+	}
+	if lineStr := strings.TrimSpace(r.URL.Query().Get("line")); lineStr != "" {
+		value, err := strconv.ParseFloat(lineStr, 64)
+		if err != nil || value < 0 || math.IsInf(value, 0) {
+			middleware.RespondError(w, http.StatusBadRequest, "INVALID_LINE", "Invalid line, expected a non-negative number such as 2.5")
+			return
+		}
+		opts.Line = &value
+	}
+
+	var leagueID *int64
+	if leagueIDStr := r.URL.Query().Get("league_id"); leagueIDStr != "" {
+		if id, err := strconv.ParseInt(leagueIDStr, 10, 64); err == nil {
+			leagueID = &id
+		}
+	}
+
+	team, err := db.GetTeam(sport, id)
+	if err != nil {
+		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch team")
+		return
+	}
+	if team == nil {
+		middleware.RespondError(w, http.StatusNotFound, "NOT_FOUND", "Team not found")
+		return
+	}
+
+	results, err := db.GetTeamResults(sport, team.ID, leagueID, formLookback)
+	if err != nil {
+		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch results")
+		return
+	}
+
+	form := analytics.ComputeForm(team.TeamID, results, opts)
+	middleware.RespondJSON(w, http.StatusOK, dto.TeamFormFromModel(dto.TeamFromModel(team), &form, cleanSheets))
+}
+
+// respondRatings lists the current ratings of sport, best first
+func respondRatings(w http.ResponseWriter, r *http.Request, db *database.DB, sport string) {
+	query := parseQueryParams(r)
+	loc, ok := parseLocation(w, r)
+	if !ok {
+		return
+	}
+
+	params := database.RatingParams{Limit: query.Limit, Offset: query.Offset, LeagueID: query.LeagueID}
+	ratings, total, err := db.GetRatings(sport, params)
+	if err != nil {
+		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch ratings")
+		return
+	}
+
+	response := make([]dto.RatingResponse, len(ratings))
+	for i, rt := range ratings {
+		response[i] = dto.RatingFromModel(&rt, loc)
+	}
+
+	middleware.RespondJSONWithMeta(w, http.StatusOK, response, &middleware.MetaInfo{
+		Total:  total,
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	})
+}
+
+// respondTeamRatings returns the current rating of the team of sport named
+// by the id path parameter, with a page of its rating history
+func respondTeamRatings(w http.ResponseWriter, r *http.Request, db *database.DB, sport string) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid team ID")
+		return
+	}
+
+	params := parseQueryParams(r)
+	loc, ok := parseLocation(w, r)
+	if !ok {
+		return
+	}
+
+	team, err := db.GetTeam(sport, id)
+	if err != nil {
+		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch team")
+		return
+	}
+	if team == nil {
+		middleware.RespondError(w, http.StatusNotFound, "NOT_FOUND", "Team not found")
+		return
+	}
+
+	history, total, err := db.GetTeamRatingHistory(sport, team.ID, params.Limit, params.Offset)
+	if err != nil {
+		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch ratings")
+		return
+	}
+
+	// The current rating heads the history, which a later page skips
+	latest := history
+	if params.Offset > 0 && total > 0 {
+		if latest, _, err = db.GetTeamRatingHistory(sport, team.ID, 1, 0); err != nil {
+			middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch ratings")
+			return
+		}
+	}
+
+	response := dto.TeamRatingsResponse{
+		Team:    dto.TeamFromModel(team),
+		Played:  total,
+		History: make([]dto.RatingHistoryResponse, len(history)),
+	}
+	if len(latest) > 0 {
+		rating := dto.RatingHistoryFromModel(&latest[0], loc).Rating
+		response.Rating = &rating
+	}
+	for i, h := range history {
+		response.History[i] = dto.RatingHistoryFromModel(&h, loc)
+	}
+
+	middleware.RespondJSONWithMeta(w, http.StatusOK, response, &middleware.MetaInfo{
+		Total:  total,
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	})
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dusanbre/otg-sports-api/internal/api/dto"
+	"github.com/dusanbre/otg-sports-api/internal/api/middleware"
+	"github.com/dusanbre/otg-sports-api/internal/h2h"
+)
+
+// H2HHandler serves the meetings between two teams
+type H2HHandler struct {
+	h2h *h2h.Service
+}
+
+// NewH2HHandler creates a new head-to-head handler
+func NewH2HHandler(service *h2h.Service) *H2HHandler {
+	return &H2HHandler{h2h: service}
+}
+
+// GetSoccerH2H godoc
+//
+//	@Summary		Get soccer head-to-head
+//	@Description	Returns the finished meetings of two soccer teams, latest first, with wins, draws and goals overall and by home team. When few meetings are stored they are topped up from the cached Goalserve h2h feed; a missing or stale feed is fetched in the background and pending is true until it is
+//	@Tags			soccer
+//	@Accept			json
+//	@Produce		json
+//	@Param			team_a	query		int		true	"Team ID the tally is counted for"
+//	@Param			team_b	query		int		true	"Opponent team ID"
+//	@Param			tz		query		string	false	"IANA timezone for kickoff times"	default(UTC)
+//	@Success		200		{object}	middleware.Response{data=dto.H2HResponse}
+//	@Failure		400		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		401		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		403		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		500		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/soccer/h2h [get]
+func (h *H2HHandler) GetSoccerH2H(w http.ResponseWriter, r *http.Request) {
+	h.respondH2H(w, r, "soccer")
+}
+
+// GetBasketballH2H godoc
+//
+//	@Summary		Get basketball head-to-head
+//	@Description	Returns the finished meetings of two basketball teams, latest first, with wins and points overall and by home team. When few meetings are stored they are topped up from the cached Goalserve h2h feed; a missing or stale feed is fetched in the background and pending is true until it is
+//	@Tags			basketball
+//	@Accept			json
+//	@Produce		json
+//	@Param			team_a	query		int		true	"Team ID the tally is counted for"
+//	@Param			team_b	query		int		true	"Opponent team ID"
+//	@Param			tz		query		string	false	"IANA timezone for tip-off times"	default(UTC)
+//	@Success		200		{object}	middleware.Response{data=dto.H2HResponse}
+//	@Failure		400		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		401		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		403		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		500		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/basketball/h2h [get]
+func (h *H2HHandler) GetBasketballH2H(w http.ResponseWriter, r *http.Request) {
+	h.respondH2H(w, r, "basketball")
+}
+
+// respondH2H answers a head-to-head query of sport
+func (h *H2HHandler) respondH2H(w http.ResponseWriter, r *http.Request, sport string) {
+	teamA, ok := parseTeamParam(w, r, "team_a")
+	if !ok {
+		return
+	}
+	teamB, ok := parseTeamParam(w, r, "team_b")
+	if !ok {
+		return
+	}
+	if teamA == teamB {
+		middleware.RespondError(w, http.StatusBadRequest, "INVALID_TEAMS", "team_a and team_b must be different teams")
+		return
+	}
+
+	loc, ok := parseLocation(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.h2h.Get(r.Context(), sport, teamA, teamB)
+	if err != nil {
+		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch head-to-head")
+		return
+	}
+
+	middleware.RespondJSON(w, http.StatusOK, dto.H2HFromModel(result, loc))
+}
+
+// parseTeamParam reads a required team ID query parameter, responding with an
+// error and returning false when it is missing or invalid
+func parseTeamParam(w http.ResponseWriter, r *http.Request, name string) (int64, bool) {
+	value := strings.TrimSpace(r.URL.Query().Get(name))
+	if value == "" {
+		middleware.RespondError(w, http.StatusBadRequest, "MISSING_PARAM", name+" is required")
+		return 0, false
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id <= 0 {
+		middleware.RespondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid "+name+", expected a team ID")
+		return 0, false
+	}
+	return id, true
+}
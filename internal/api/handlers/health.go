@@ -1,31 +1,48 @@
 package handlers
 
 import (
+	"log"
 	"net/http"
 
+	"github.com/dusanbre/otg-sports-api/internal/api/dto"
 	"github.com/dusanbre/otg-sports-api/internal/api/middleware"
+	"github.com/dusanbre/otg-sports-api/internal/database"
 )
 
 // HealthHandler handles health check endpoints
-type HealthHandler struct{}
+type HealthHandler struct {
+	db *database.DB
+}
 
 // NewHealthHandler creates a new health handler
-func NewHealthHandler() *HealthHandler {
-	return &HealthHandler{}
+func NewHealthHandler(db *database.DB) *HealthHandler {
+	return &HealthHandler{db: db}
 }
 
 // Health godoc
 //
 //	@Summary		Health check
-//	@Description	Returns the health status of the API service (public endpoint)
+//	@Description	Returns the health status of the API service and the sync worker currently holding leadership (public endpoint).
+//	@Description	Status is degraded when the database can't be queried.
 //	@Tags			health
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{object}	middleware.Response{data=map[string]string}
+//	@Success		200	{object}	middleware.Response{data=dto.HealthResponse}
 //	@Router			/health [get]
 func (h *HealthHandler) Health(w http.ResponseWriter, r *http.Request) {
-	middleware.RespondJSON(w, http.StatusOK, map[string]string{
-		"status":  "healthy",
-		"service": "otg-sport-api",
-	})
+	response := dto.HealthResponse{
+		Status:  "healthy",
+		Service: "otg-sport-api",
+	}
+
+	leader, err := h.db.GetSyncLeader(database.SyncLeadership)
+	switch {
+	case err != nil:
+		log.Printf("Health check failed to read sync leader: %v", err)
+		response.Status = "degraded"
+	case leader != nil:
+		response.SyncLeader = dto.SyncLeaderFromModel(leader)
+	}
+
+	middleware.RespondJSON(w, http.StatusOK, response)
 }
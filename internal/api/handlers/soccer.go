@@ -7,17 +7,19 @@ import (
 	"github.com/dusanbre/otg-sports-api/internal/api/dto"
 	"github.com/dusanbre/otg-sports-api/internal/api/middleware"
 	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/dusanbre/otg-sports-api/internal/predictions"
 	"github.com/go-chi/chi/v5"
 )
 
 // SoccerHandler handles soccer-related endpoints
 type SoccerHandler struct {
-	db *database.DB
+	db          *database.DB
+	predictions *predictions.Service
 }
 
 // NewSoccerHandler creates a new soccer handler
-func NewSoccerHandler(db *database.DB) *SoccerHandler {
-	return &SoccerHandler{db: db}
+func NewSoccerHandler(db *database.DB, predictionService *predictions.Service) *SoccerHandler {
+	return &SoccerHandler{db: db, predictions: predictionService}
 }
 
 // GetMatches godoc
@@ -29,10 +31,13 @@ func NewSoccerHandler(db *database.DB) *SoccerHandler {
 //	@Produce		json
 //	@Param			limit		query		int		false	"Maximum results (1-100)"	default(50)
 //	@Param			offset		query		int		false	"Results to skip"			default(0)
-//	@Param			date		query		string	false	"Filter by date (YYYY-MM-DD)"
-//	@Param			status		query		string	false	"Filter by status (FT, 1H, HT, 2H, NS)"
+//	@Param			date		query		string	false	"Filter by kickoff day (YYYY-MM-DD) in tz"
+//	@Param			tz			query		string	false	"IANA timezone for date, start_date and start_time"	default(UTC)
+//	@Param			status		query		string	false	"Filter by status"	Enums(scheduled, live, break, finished, postponed, cancelled, abandoned, interrupted, missing)
 //	@Param			league_id	query		int		false	"Filter by league ID"
+//	@Param			include		query		string	false	"Related data to embed (events, prediction)"
 //	@Success		200			{object}	middleware.Response{data=[]dto.SoccerMatchResponse,meta=middleware.MetaInfo}
+//	@Failure		400			{object}	middleware.Response{error=middleware.ErrorInfo}
 //	@Failure		401			{object}	middleware.Response{error=middleware.ErrorInfo}
 //	@Failure		403			{object}	middleware.Response{error=middleware.ErrorInfo}
 //	@Failure		429			{object}	middleware.Response{error=middleware.ErrorInfo}
@@ -42,7 +47,10 @@ func NewSoccerHandler(db *database.DB) *SoccerHandler {
 //	@Router			/soccer/matches [get]
 func (h *SoccerHandler) GetMatches(w http.ResponseWriter, r *http.Request) {
 	// Parse query parameters
-	params := parseQueryParams(r)
+	params, ok := parseMatchParams(w, r)
+	if !ok {
+		return
+	}
 
 	// Fetch matches from database
 	matches, total, err := h.db.GetSoccerMatchesFiltered(params)
@@ -54,7 +62,22 @@ func (h *SoccerHandler) GetMatches(w http.ResponseWriter, r *http.Request) {
 	// Convert to DTOs
 	response := make([]dto.SoccerMatchResponse, len(matches))
 	for i, m := range matches {
-		response[i] = dto.SoccerMatchFromModel(&m)
+		response[i] = dto.SoccerMatchFromModel(&m, params.Location)
+	}
+
+	include := parseInclude(r)
+	if include["events"] {
+		if err := h.attachEvents(response); err != nil {
+			middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch match events")
+			return
+		}
+	}
+
+	if include["prediction"] {
+		if err := h.attachPredictions(response); err != nil {
+			middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to predict matches")
+			return
+		}
 	}
 
 	middleware.RespondJSONWithMeta(w, http.StatusOK, response, &middleware.MetaInfo{
@@ -71,11 +94,14 @@ func (h *SoccerHandler) GetMatches(w http.ResponseWriter, r *http.Request) {
 //	@Tags			soccer
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path		int	true	"Match ID"
-//	@Success		200	{object}	middleware.Response{data=dto.SoccerMatchResponse}
-//	@Failure		400	{object}	middleware.Response{error=middleware.ErrorInfo}
-//	@Failure		401	{object}	middleware.Response{error=middleware.ErrorInfo}
-//	@Failure		404	{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Param			id		path		int		true	"Match ID"
+//	@Param			tz		query		string	false	"IANA timezone for start_date and start_time"	default(UTC)
+//	@Param			include	query		string	false	"Related data to embed (events, prediction)"
+//	@Success		200		{object}	middleware.Response{data=dto.SoccerMatchResponse}
+//	@Failure		400		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		401		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		404		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		500		{object}	middleware.Response{error=middleware.ErrorInfo}
 //	@Security		ApiKeyAuth
 //	@Security		BearerAuth
 //	@Router			/soccer/matches/{id} [get]
@@ -87,31 +113,143 @@ func (h *SoccerHandler) GetMatch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	loc, ok := parseLocation(w, r)
+	if !ok {
+		return
+	}
+
 	match, err := h.db.GetMatchByID(id)
 	if err != nil {
 		middleware.RespondError(w, http.StatusNotFound, "NOT_FOUND", "Match not found")
 		return
 	}
 
-	response := dto.SoccerMatchFromModel(match)
-	middleware.RespondJSON(w, http.StatusOK, response)
+	response := []dto.SoccerMatchResponse{dto.SoccerMatchFromModel(match, loc)}
+
+	include := parseInclude(r)
+	if include["events"] {
+		if err := h.attachEvents(response); err != nil {
+			middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch match events")
+			return
+		}
+	}
+
+	if include["prediction"] {
+		if err := h.attachPredictions(response); err != nil {
+			middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to predict matches")
+			return
+		}
+	}
+
+	middleware.RespondJSON(w, http.StatusOK, response[0])
 }
 
-// GetLiveMatches godoc
+// GetMatchEvents godoc
 //
-//	@Summary		Get live soccer matches
-//	@Description	Returns all currently live soccer matches
+//	@Summary		Get soccer match events
+//	@Description	Returns the goals, cards and substitutions of a soccer match in match order
 //	@Tags			soccer
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{object}	middleware.Response{data=[]dto.SoccerMatchResponse}
+//	@Param			id	path		int	true	"Match ID"
+//	@Success		200	{object}	middleware.Response{data=[]dto.SoccerEventResponse}
+//	@Failure		400	{object}	middleware.Response{error=middleware.ErrorInfo}
 //	@Failure		401	{object}	middleware.Response{error=middleware.ErrorInfo}
-//	@Failure		403	{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		404	{object}	middleware.Response{error=middleware.ErrorInfo}
 //	@Failure		500	{object}	middleware.Response{error=middleware.ErrorInfo}
 //	@Security		ApiKeyAuth
 //	@Security		BearerAuth
+//	@Router			/soccer/matches/{id}/events [get]
+func (h *SoccerHandler) GetMatchEvents(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		middleware.RespondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid match ID")
+		return
+	}
+
+	if _, err := h.db.GetMatchByID(id); err != nil {
+		middleware.RespondError(w, http.StatusNotFound, "NOT_FOUND", "Match not found")
+		return
+	}
+
+	events, err := h.db.GetSoccerMatchEvents([]int64{id})
+	if err != nil {
+		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch match events")
+		return
+	}
+
+	middleware.RespondJSON(w, http.StatusOK, dto.SoccerEventsFromModels(events[id]))
+}
+
+// GetMatchChanges godoc
+//
+//	@Summary		Get soccer match change history
+//	@Description	Returns the field changes sync recorded for a soccer match, newest first
+//	@Tags			soccer
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int	true	"Match ID"
+//	@Param			limit	query		int	false	"Maximum results (1-100)"	default(50)
+//	@Param			offset	query		int	false	"Results to skip"			default(0)
+//	@Success		200		{object}	middleware.Response{data=[]dto.MatchChangeResponse,meta=middleware.MetaInfo}
+//	@Failure		400		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		401		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		500		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/soccer/matches/{id}/changes [get]
+func (h *SoccerHandler) GetMatchChanges(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		middleware.RespondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid match ID")
+		return
+	}
+
+	params := parseQueryParams(r)
+
+	changes, total, err := h.db.GetMatchChanges("soccer", id, params)
+	if err != nil {
+		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch match changes")
+		return
+	}
+
+	response := make([]dto.MatchChangeResponse, len(changes))
+	for i, c := range changes {
+		response[i] = dto.MatchChangeFromModel(&c)
+	}
+
+	middleware.RespondJSONWithMeta(w, http.StatusOK, response, &middleware.MetaInfo{
+		Total:  total,
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	})
+}
+
+// GetLiveMatches godoc
+//
+//	@Summary		Get live soccer matches
+//	@Description	Returns all soccer matches in play (status live or break)
+//	@Tags			soccer
+//	@Accept			json
+//	@Produce		json
+//	@Param			tz		query		string	false	"IANA timezone for start_date and start_time"	default(UTC)
+//	@Param			include	query		string	false	"Related data to embed (events)"
+//	@Success		200		{object}	middleware.Response{data=[]dto.SoccerMatchResponse}
+//	@Failure		400		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		401		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		403		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		500		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
 //	@Router			/soccer/matches/live [get]
 func (h *SoccerHandler) GetLiveMatches(w http.ResponseWriter, r *http.Request) {
+	loc, ok := parseLocation(w, r)
+	if !ok {
+		return
+	}
+
 	matches, err := h.db.GetLiveSoccerMatches()
 	if err != nil {
 		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch live matches")
@@ -120,7 +258,14 @@ func (h *SoccerHandler) GetLiveMatches(w http.ResponseWriter, r *http.Request) {
 
 	response := make([]dto.SoccerMatchResponse, len(matches))
 	for i, m := range matches {
-		response[i] = dto.SoccerMatchFromModel(&m)
+		response[i] = dto.SoccerMatchFromModel(&m, loc)
+	}
+
+	if parseInclude(r)["events"] {
+		if err := h.attachEvents(response); err != nil {
+			middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch match events")
+			return
+		}
 	}
 
 	middleware.RespondJSON(w, http.StatusOK, response)
@@ -129,11 +274,11 @@ func (h *SoccerHandler) GetLiveMatches(w http.ResponseWriter, r *http.Request) {
 // GetLeagues godoc
 //
 //	@Summary		Get soccer leagues
-//	@Description	Returns a list of all available soccer leagues
+//	@Description	Returns all soccer leagues sync has seen, ordered by name
 //	@Tags			soccer
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{object}	middleware.Response{data=[]database.LeagueInfo}
+//	@Success		200	{object}	middleware.Response{data=[]dto.LeagueResponse}
 //	@Failure		401	{object}	middleware.Response{error=middleware.ErrorInfo}
 //	@Failure		403	{object}	middleware.Response{error=middleware.ErrorInfo}
 //	@Failure		500	{object}	middleware.Response{error=middleware.ErrorInfo}
@@ -141,11 +286,252 @@ func (h *SoccerHandler) GetLiveMatches(w http.ResponseWriter, r *http.Request) {
 //	@Security		BearerAuth
 //	@Router			/soccer/leagues [get]
 func (h *SoccerHandler) GetLeagues(w http.ResponseWriter, r *http.Request) {
-	leagues, err := h.db.GetSoccerLeagues()
+	respondLeagues(w, h.db, "soccer")
+}
+
+// GetLeague godoc
+//
+//	@Summary		Get a soccer league
+//	@Description	Returns a soccer league by its Goalserve ID, with the seasons sync has seen, latest first
+//	@Tags			soccer
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"League ID"
+//	@Success		200	{object}	middleware.Response{data=dto.LeagueDetailResponse}
+//	@Failure		400	{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		401	{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		403	{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		404	{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		500	{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/soccer/leagues/{id} [get]
+func (h *SoccerHandler) GetLeague(w http.ResponseWriter, r *http.Request) {
+	respondLeague(w, r, h.db, "soccer")
+}
+
+// GetTeams godoc
+//
+//	@Summary		List soccer teams
+//	@Description	Returns a paginated list of soccer teams ordered by name
+//	@Tags			soccer
+//	@Accept			json
+//	@Produce		json
+//	@Param			limit		query		int		false	"Maximum results (1-100)"	default(50)
+//	@Param			offset		query		int		false	"Results to skip"			default(0)
+//	@Param			search		query		string	false	"Case-insensitive part of the team name"
+//	@Param			league_id	query		int		false	"Only teams with matches in this league"
+//	@Success		200			{object}	middleware.Response{data=[]dto.TeamResponse,meta=middleware.MetaInfo}
+//	@Failure		401			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		403			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		500			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/soccer/teams [get]
+func (h *SoccerHandler) GetTeams(w http.ResponseWriter, r *http.Request) {
+	respondTeams(w, r, h.db, "soccer")
+}
+
+// GetTeam godoc
+//
+//	@Summary		Get a soccer team
+//	@Description	Returns a soccer team by its Goalserve ID, with the leagues it has matches in
+//	@Tags			soccer
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Team ID"
+//	@Success		200	{object}	middleware.Response{data=dto.TeamDetailResponse}
+//	@Failure		400	{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		401	{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		403	{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		404	{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		500	{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/soccer/teams/{id} [get]
+func (h *SoccerHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
+	respondTeam(w, r, h.db, "soccer")
+}
+
+// GetTeamMatches godoc
+//
+//	@Summary		List a soccer team's matches
+//	@Description	Returns a paginated list of the soccer matches a team plays home or away, with its side and W/D/L result. when=upcoming lists scheduled and in-play matches soonest first, when=past finished matches latest first
+//	@Tags			soccer
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int		true	"Team ID"
+//	@Param			when		query		string	false	"Upcoming or past matches only"	Enums(upcoming, past)
+//	@Param			limit		query		int		false	"Maximum results (1-100)"	default(50)
+//	@Param			offset		query		int		false	"Results to skip"			default(0)
+//	@Param			date		query		string	false	"Filter by kickoff day (YYYY-MM-DD) in tz"
+//	@Param			tz			query		string	false	"IANA timezone for date, start_date and start_time"	default(UTC)
+//	@Param			status		query		string	false	"Filter by status"	Enums(scheduled, live, break, finished, postponed, cancelled, abandoned, interrupted, missing)
+//	@Param			league_id	query		int		false	"Filter by league ID"
+//	@Param			include		query		string	false	"Related data to embed (events, prediction)"
+//	@Success		200			{object}	middleware.Response{data=[]dto.SoccerTeamMatchResponse,meta=middleware.MetaInfo}
+//	@Failure		400			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		401			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		403			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		404			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		500			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/soccer/teams/{id}/matches [get]
+func (h *SoccerHandler) GetTeamMatches(w http.ResponseWriter, r *http.Request) {
+	params, team, ok := parseTeamMatchParams(w, r, h.db, "soccer")
+	if !ok {
+		return
+	}
+
+	matches, total, err := h.db.GetSoccerMatchesFiltered(params)
 	if err != nil {
-		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch leagues")
+		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch matches")
 		return
 	}
 
-	middleware.RespondJSON(w, http.StatusOK, leagues)
+	base := make([]dto.SoccerMatchResponse, len(matches))
+	for i, m := range matches {
+		base[i] = dto.SoccerMatchFromModel(&m, params.Location)
+	}
+
+	include := parseInclude(r)
+	if include["events"] {
+		if err := h.attachEvents(base); err != nil {
+			middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch match events")
+			return
+		}
+	}
+
+	if include["prediction"] {
+		if err := h.attachPredictions(base); err != nil {
+			middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to predict matches")
+			return
+		}
+	}
+
+	response := make([]dto.SoccerTeamMatchResponse, len(base))
+	for i, m := range base {
+		response[i] = dto.SoccerTeamMatchResponse{
+			SoccerMatchResponse: m,
+			TeamPerspective:     dto.NewTeamPerspective(team.TeamID, m.Status, m.HomeTeam, m.AwayTeam),
+		}
+	}
+
+	middleware.RespondJSONWithMeta(w, http.StatusOK, response, &middleware.MetaInfo{
+		Total:  total,
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	})
+}
+
+// GetTeamForm godoc
+//
+//	@Summary		Get a soccer team's form
+//	@Description	Returns a soccer team's latest results as a form string (oldest first) and its current winning, unbeaten, losing and winless streaks, with its record, average goals, clean sheets and over/under rate over its latest finished matches
+//	@Tags			soccer
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int		true	"Team ID"
+//	@Param			last		query		int		false	"Results in the form string (1-20)"	default(5)
+//	@Param			matches		query		int		false	"Latest matches the record, averages and rates count (1-100)"	default(10)
+//	@Param			line		query		number	false	"Over/under total goals line"	default(2.5)
+//	@Param			league_id	query		int		false	"Only count matches in this league"
+//	@Success		200			{object}	middleware.Response{data=dto.TeamFormResponse}
+//	@Failure		400			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		401			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		403			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		404			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		500			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/soccer/teams/{id}/form [get]
+func (h *SoccerHandler) GetTeamForm(w http.ResponseWriter, r *http.Request) {
+	line := 2.5
+	respondTeamForm(w, r, h.db, "soccer", &line, true)
+}
+
+// GetRatings godoc
+//
+//	@Summary		List soccer team ratings
+//	@Description	Returns the current Elo rating of every rated soccer team, best first, with the number of matches rated. Ratings update after each sync.
+//	@Tags			soccer
+//	@Accept			json
+//	@Produce		json
+//	@Param			league_id	query		int		false	"Only teams with matches in this league"
+//	@Param			tz			query		string	false	"IANA timezone for last_match_at"	default(UTC)
+//	@Param			limit		query		int		false	"Maximum results (1-100)"	default(50)
+//	@Param			offset		query		int		false	"Results to skip"			default(0)
+//	@Success		200			{object}	middleware.Response{data=[]dto.RatingResponse}
+//	@Failure		400			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		401			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		403			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		500			{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/soccer/ratings [get]
+func (h *SoccerHandler) GetRatings(w http.ResponseWriter, r *http.Request) {
+	respondRatings(w, r, h.db, "soccer")
+}
+
+// GetTeamRatings godoc
+//
+//	@Summary		Get a soccer team's rating history
+//	@Description	Returns a soccer team's current Elo rating and its rating before and after each rated match, latest first
+//	@Tags			soccer
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int		true	"Team ID"
+//	@Param			tz		query		string	false	"IANA timezone for kickoff_at"	default(UTC)
+//	@Param			limit	query		int		false	"Maximum history entries (1-100)"	default(50)
+//	@Param			offset	query		int		false	"Results to skip"					default(0)
+//	@Success		200		{object}	middleware.Response{data=dto.TeamRatingsResponse}
+//	@Failure		400		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		401		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		403		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		404		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		500		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/soccer/teams/{id}/ratings [get]
+func (h *SoccerHandler) GetTeamRatings(w http.ResponseWriter, r *http.Request) {
+	respondTeamRatings(w, r, h.db, "soccer")
+}
+
+// attachEvents loads the events of all matches in one query and embeds them
+func (h *SoccerHandler) attachEvents(matches []dto.SoccerMatchResponse) error {
+	ids := make([]int64, len(matches))
+	for i, m := range matches {
+		ids[i] = m.MatchID
+	}
+
+	events, err := h.db.GetSoccerMatchEvents(ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range matches {
+		matches[i].Events = dto.SoccerEventsFromModels(events[matches[i].MatchID])
+	}
+	return nil
+}
+
+// attachPredictions embeds the predictions of the scheduled matches
+func (h *SoccerHandler) attachPredictions(matches []dto.SoccerMatchResponse) error {
+	ids := make([]int64, len(matches))
+	for i, m := range matches {
+		ids[i] = m.MatchID
+	}
+
+	predicted, err := h.predictions.Predict("soccer", ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range matches {
+		if p, ok := predicted[matches[i].MatchID]; ok {
+			matches[i].Prediction = dto.PredictionFromModel(&p)
+		}
+	}
+	return nil
 }
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dusanbre/otg-sports-api/internal/api/dto"
+	"github.com/dusanbre/otg-sports-api/internal/api/middleware"
+	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/dusanbre/otg-sports-api/internal/standings"
+	"github.com/go-chi/chi/v5"
+)
+
+// StandingsHandler serves league tables computed from stored results
+type StandingsHandler struct {
+	db     *database.DB
+	config *standings.Config
+}
+
+// NewStandingsHandler creates a new standings handler
+func NewStandingsHandler(db *database.DB, config *standings.Config) *StandingsHandler {
+	return &StandingsHandler{db: db, config: config}
+}
+
+// GetSoccerStandings godoc
+//
+//	@Summary		Get soccer league standings
+//	@Description	Returns a soccer league table built from finished matches, ranked by the competition's tie-break rules. Defaults to the latest season
+//	@Tags			soccer
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int		true	"League ID"
+//	@Param			season	query		string	false	"Season name, e.g. 2026/2027"
+//	@Param			as_of	query		string	false	"Only count matches kicking off on or before this day (YYYY-MM-DD) in tz"
+//	@Param			tz		query		string	false	"IANA timezone of as_of"	default(UTC)
+//	@Success		200		{object}	middleware.Response{data=dto.StandingsResponse}
+//	@Failure		400		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		401		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		403		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		404		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		500		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/soccer/leagues/{id}/standings [get]
+func (h *StandingsHandler) GetSoccerStandings(w http.ResponseWriter, r *http.Request) {
+	h.respondStandings(w, r, "soccer")
+}
+
+// GetBasketballStandings godoc
+//
+//	@Summary		Get basketball league standings
+//	@Description	Returns a basketball league table built from finished matches, ranked by win percentage unless the competition's rules say otherwise. Defaults to the latest season
+//	@Tags			basketball
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int		true	"League ID"
+//	@Param			season	query		string	false	"Season name, e.g. 2026/2027"
+//	@Param			as_of	query		string	false	"Only count matches tipping off on or before this day (YYYY-MM-DD) in tz"
+//	@Param			tz		query		string	false	"IANA timezone of as_of"	default(UTC)
+//	@Success		200		{object}	middleware.Response{data=dto.StandingsResponse}
+//	@Failure		400		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		401		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		403		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		404		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Failure		500		{object}	middleware.Response{error=middleware.ErrorInfo}
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/basketball/leagues/{id}/standings [get]
+func (h *StandingsHandler) GetBasketballStandings(w http.ResponseWriter, r *http.Request) {
+	h.respondStandings(w, r, "basketball")
+}
+
+// respondStandings builds the table of the league of sport named by the id path parameter
+func (h *StandingsHandler) respondStandings(w http.ResponseWriter, r *http.Request, sport string) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid league ID")
+		return
+	}
+
+	loc, ok := parseLocation(w, r)
+	if !ok {
+		return
+	}
+
+	// The table counts matches kicking off before the end of the as_of day
+	var asOf, before time.Time
+	asOfStr := strings.TrimSpace(r.URL.Query().Get("as_of"))
+	if asOfStr != "" {
+		if asOf, err = time.ParseInLocation("2006-01-02", asOfStr, loc); err != nil {
+			middleware.RespondError(w, http.StatusBadRequest, "INVALID_DATE", "Invalid as_of, expected YYYY-MM-DD")
+			return
+		}
+		before = asOf.AddDate(0, 0, 1)
+	}
+
+	league, err := h.db.GetLeague(sport, id)
+	if err != nil {
+		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch league")
+		return
+	}
+	if league == nil {
+		middleware.RespondError(w, http.StatusNotFound, "NOT_FOUND", "League not found")
+		return
+	}
+
+	seasons, err := h.db.GetSeasons(league.ID)
+	if err != nil {
+		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch seasons")
+		return
+	}
+	season := pickSeason(seasons, strings.TrimSpace(r.URL.Query().Get("season")), asOf)
+	if season == nil {
+		middleware.RespondError(w, http.StatusNotFound, "NOT_FOUND", "Season not found")
+		return
+	}
+
+	results, err := h.db.GetSeasonResults(sport, season.ID, before)
+	if err != nil {
+		middleware.RespondError(w, http.StatusInternalServerError, "DB_ERROR", "Failed to fetch results")
+		return
+	}
+
+	rules := h.config.Rules(sport, league.LeagueID, league.Name)
+	table := standings.Compute(results, rules)
+
+	response := dto.StandingsResponse{
+		League:      dto.LeagueFromModel(league),
+		Season:      season.Name,
+		AsOf:        asOfStr,
+		TieBreakers: rules.TieBreakers,
+		Table:       make([]dto.StandingRowResponse, len(table)),
+	}
+	for i, row := range table {
+		response.Table[i] = dto.StandingRowFromModel(&row)
+	}
+
+	middleware.RespondJSON(w, http.StatusOK, response)
+}
+
+// pickSeason returns the season called name, else the latest season started by
+// asOf, else the latest season. seasons are ordered latest first.
+func pickSeason(seasons []database.Season, name string, asOf time.Time) *database.Season {
+	for i, s := range seasons {
+		switch {
+		case name != "":
+			if s.Name == name {
+				return &seasons[i]
+			}
+		case !asOf.IsZero():
+			// Compare calendar days, starts_on has no timezone
+			if s.StartsOn.Format("2006-01-02") <= asOf.Format("2006-01-02") {
+				return &seasons[i]
+			}
+		default:
+			return &seasons[i]
+		}
+	}
+	return nil
+}
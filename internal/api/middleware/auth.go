@@ -88,6 +88,24 @@ func RequireSport(sport string) func(http.Handler) http.Handler {
 	}
 }
 
+// RequireAdmin middleware checks if the API key may use the admin endpoints
+func RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKey, ok := r.Context().Value(APIKeyContextKey).(*database.ApiKey)
+		if !ok {
+			respondUnauthorized(w, "API key not found in context")
+			return
+		}
+
+		if !apiKey.IsAdmin {
+			respondForbidden(w, "API key does not have admin access")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 // extractAPIKey extracts the API key from the request
 func extractAPIKey(r *http.Request) string {
 	// Check Authorization header: "Bearer sk_live_..."
@@ -11,6 +11,9 @@ import (
 	"github.com/dusanbre/otg-sports-api/internal/api/handlers"
 	"github.com/dusanbre/otg-sports-api/internal/api/middleware"
 	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/dusanbre/otg-sports-api/internal/h2h"
+	"github.com/dusanbre/otg-sports-api/internal/predictions"
+	"github.com/dusanbre/otg-sports-api/internal/standings"
 	"github.com/go-chi/chi/v5"
 	chiMiddleware "github.com/go-chi/chi/v5/middleware"
 	httpSwagger "github.com/swaggo/http-swagger/v2"
@@ -18,16 +21,22 @@ import (
 
 // Server represents the API server
 type Server struct {
-	db     *database.DB
-	port   string
-	server *http.Server
+	db          *database.DB
+	port        string
+	standings   *standings.Config
+	h2h         *h2h.Service
+	predictions *predictions.Service
+	server      *http.Server
 }
 
 // NewServer creates a new API server
-func NewServer(db *database.DB, port string) *Server {
+func NewServer(db *database.DB, port string, standingsConfig *standings.Config, h2hService *h2h.Service, predictionService *predictions.Service) *Server {
 	return &Server{
-		db:   db,
-		port: port,
+		db:          db,
+		port:        port,
+		standings:   standingsConfig,
+		h2h:         h2hService,
+		predictions: predictionService,
 	}
 }
 
@@ -70,9 +79,12 @@ func (s *Server) setupRouter() *chi.Mux {
 	r.Use(middleware.CORS(corsConfig))
 
 	// Create handlers
-	healthHandler := handlers.NewHealthHandler()
-	soccerHandler := handlers.NewSoccerHandler(s.db)
-	basketballHandler := handlers.NewBasketballHandler(s.db)
+	healthHandler := handlers.NewHealthHandler(s.db)
+	soccerHandler := handlers.NewSoccerHandler(s.db, s.predictions)
+	basketballHandler := handlers.NewBasketballHandler(s.db, s.predictions)
+	adminHandler := handlers.NewAdminHandler(s.db)
+	standingsHandler := handlers.NewStandingsHandler(s.db, s.standings)
+	h2hHandler := handlers.NewH2HHandler(s.h2h)
 
 	// Create rate limiter
 	rateLimiter := middleware.NewRateLimiter(s.getDefaultRateLimit())
@@ -96,8 +108,19 @@ func (s *Server) setupRouter() *chi.Mux {
 			r.Use(middleware.RequireSport("soccer"))
 			r.Get("/matches", soccerHandler.GetMatches)
 			r.Get("/matches/{id}", soccerHandler.GetMatch)
+			r.Get("/matches/{id}/changes", soccerHandler.GetMatchChanges)
+			r.Get("/matches/{id}/events", soccerHandler.GetMatchEvents)
 			r.Get("/matches/live", soccerHandler.GetLiveMatches)
 			r.Get("/leagues", soccerHandler.GetLeagues)
+			r.Get("/leagues/{id}", soccerHandler.GetLeague)
+			r.Get("/leagues/{id}/standings", standingsHandler.GetSoccerStandings)
+			r.Get("/teams", soccerHandler.GetTeams)
+			r.Get("/teams/{id}", soccerHandler.GetTeam)
+			r.Get("/teams/{id}/matches", soccerHandler.GetTeamMatches)
+			r.Get("/teams/{id}/form", soccerHandler.GetTeamForm)
+			r.Get("/teams/{id}/ratings", soccerHandler.GetTeamRatings)
+			r.Get("/ratings", soccerHandler.GetRatings)
+			r.Get("/h2h", h2hHandler.GetSoccerH2H)
 		})
 
 		// Basketball routes
@@ -105,8 +128,26 @@ func (s *Server) setupRouter() *chi.Mux {
 			r.Use(middleware.RequireSport("basketball"))
 			r.Get("/matches", basketballHandler.GetMatches)
 			r.Get("/matches/{id}", basketballHandler.GetMatch)
+			r.Get("/matches/{id}/changes", basketballHandler.GetMatchChanges)
 			r.Get("/matches/live", basketballHandler.GetLiveMatches)
 			r.Get("/leagues", basketballHandler.GetLeagues)
+			r.Get("/leagues/{id}", basketballHandler.GetLeague)
+			r.Get("/leagues/{id}/standings", standingsHandler.GetBasketballStandings)
+			r.Get("/leagues/{id}/quarters", basketballHandler.GetLeagueQuarters)
+			r.Get("/teams", basketballHandler.GetTeams)
+			r.Get("/teams/{id}", basketballHandler.GetTeam)
+			r.Get("/teams/{id}/matches", basketballHandler.GetTeamMatches)
+			r.Get("/teams/{id}/form", basketballHandler.GetTeamForm)
+			r.Get("/teams/{id}/quarters", basketballHandler.GetTeamQuarters)
+			r.Get("/teams/{id}/ratings", basketballHandler.GetTeamRatings)
+			r.Get("/ratings", basketballHandler.GetRatings)
+			r.Get("/h2h", h2hHandler.GetBasketballH2H)
+		})
+
+		// Admin routes
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(middleware.RequireAdmin)
+			r.Get("/sync/runs", adminHandler.GetSyncRuns)
 		})
 	})
 
@@ -2,62 +2,108 @@ package database
 
 import (
 	"database/sql"
+	"encoding/json"
+	"fmt"
 	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+)
+
+// Canonical match statuses stored in the status column of both match tables.
+// The raw Goalserve value stays in match_status.
+const (
+	StatusScheduled   = "scheduled"
+	StatusLive        = "live"
+	StatusBreak       = "break" // Half time, quarter breaks, before extra time
+	StatusFinished    = "finished"
+	StatusPostponed   = "postponed"
+	StatusCancelled   = "cancelled"
+	StatusAbandoned   = "abandoned"
+	StatusInterrupted = "interrupted" // Suspended, may resume
+	StatusMissing     = "missing"     // Was scheduled, but dropped from its day's feed
 )
 
+// MatchStatuses lists every canonical status
+var MatchStatuses = []string{
+	StatusScheduled, StatusLive, StatusBreak, StatusFinished,
+	StatusPostponed, StatusCancelled, StatusAbandoned, StatusInterrupted, StatusMissing,
+}
+
+// InPlayStatuses are the statuses returned by the live endpoints
+var InPlayStatuses = []string{StatusLive, StatusBreak}
+
+// IsMatchStatus reports whether s is a canonical status
+func IsMatchStatus(s string) bool {
+	for _, status := range MatchStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
 // SoccerMatch represents a soccer match record
 type SoccerMatch struct {
-	ID             int64          `json:"id"`
-	MatchID        sql.NullInt64  `json:"match_id"`
-	LeagueGID      sql.NullInt64  `json:"league_gid"`
-	LeagueID       sql.NullInt64  `json:"league_id"`
-	LeagueName     sql.NullString `json:"league_name"`
-	MatchStatus    sql.NullString `json:"match_status"`
-	MatchStartDate sql.NullTime   `json:"match_start_date"`
-	MatchStartTime sql.NullString `json:"match_start_time"`
-	HTeamID        sql.NullInt64  `json:"h_team_id"`
-	ATeamID        sql.NullInt64  `json:"a_team_id"`
-	HTeamName      sql.NullString `json:"h_team_name"`
-	ATeamName      sql.NullString `json:"a_team_name"`
-	HTeamGoals     sql.NullInt32  `json:"h_team_goals"`
-	ATeamGoals     sql.NullInt32  `json:"a_team_goals"`
-	HTScore        sql.NullString `json:"ht_score"`
-	FTScore        sql.NullString `json:"ft_score"`
-	Events         sql.NullString `json:"events"` // JSON stored as string
-	CreatedAt      time.Time      `json:"created_at"`
-	UpdatedAt      time.Time      `json:"updated_at"`
+	ID                int64          `json:"id"`
+	MatchID           sql.NullInt64  `json:"match_id"`
+	LeagueGID         sql.NullInt64  `json:"league_gid"`
+	LeagueID          sql.NullInt64  `json:"league_id"`
+	LeagueName        sql.NullString `json:"league_name"`
+	MatchStatus       sql.NullString `json:"match_status"` // Raw Goalserve status
+	Status            string         `json:"status"`       // Canonical status
+	MatchStartDate    sql.NullTime   `json:"match_start_date"`
+	MatchStartTime    sql.NullString `json:"match_start_time"`
+	KickoffAt         sql.NullTime   `json:"kickoff_at"`
+	PreviousKickoffAt sql.NullTime   `json:"previous_kickoff_at"` // Kickoff before it last moved
+	HTeamID           sql.NullInt64  `json:"h_team_id"`
+	ATeamID           sql.NullInt64  `json:"a_team_id"`
+	HTeamName         sql.NullString `json:"h_team_name"`
+	ATeamName         sql.NullString `json:"a_team_name"`
+	HTeamGoals        sql.NullInt32  `json:"h_team_goals"`
+	ATeamGoals        sql.NullInt32  `json:"a_team_goals"`
+	HTScore           sql.NullString `json:"ht_score"`
+	FTScore           sql.NullString `json:"ft_score"`
+	Events            sql.NullString `json:"events"` // JSON stored as string
+	MissedSyncs       int            `json:"missed_syncs"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
 }
 
 // BasketballMatch represents a basketball match record
 type BasketballMatch struct {
-	ID          int64          `json:"id"`
-	MatchID     sql.NullInt64  `json:"match_id"`
-	LeagueGID   sql.NullInt64  `json:"league_gid"`
-	LeagueID    sql.NullInt64  `json:"league_id"`
-	LeagueName  sql.NullString `json:"league_name"`
-	FileGroup   sql.NullString `json:"file_group"`
-	MatchStatus sql.NullString `json:"match_status"`
-	MatchDate   sql.NullTime   `json:"match_date"`
-	MatchTime   sql.NullString `json:"match_time"`
-	Timer       sql.NullString `json:"timer"`
-	HTeamID     sql.NullInt64  `json:"h_team_id"`
-	HTeamName   sql.NullString `json:"h_team_name"`
-	HTeamScore  sql.NullInt32  `json:"h_team_score"`
-	HTeamQ1     sql.NullInt32  `json:"h_team_q1"`
-	HTeamQ2     sql.NullInt32  `json:"h_team_q2"`
-	HTeamQ3     sql.NullInt32  `json:"h_team_q3"`
-	HTeamQ4     sql.NullInt32  `json:"h_team_q4"`
-	HTeamOt     sql.NullInt32  `json:"h_team_ot"`
-	ATeamID     sql.NullInt64  `json:"a_team_id"`
-	ATeamName   sql.NullString `json:"a_team_name"`
-	ATeamScore  sql.NullInt32  `json:"a_team_score"`
-	ATeamQ1     sql.NullInt32  `json:"a_team_q1"`
-	ATeamQ2     sql.NullInt32  `json:"a_team_q2"`
-	ATeamQ3     sql.NullInt32  `json:"a_team_q3"`
-	ATeamQ4     sql.NullInt32  `json:"a_team_q4"`
-	ATeamOt     sql.NullInt32  `json:"a_team_ot"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
+	ID                int64          `json:"id"`
+	MatchID           sql.NullInt64  `json:"match_id"`
+	LeagueGID         sql.NullInt64  `json:"league_gid"`
+	LeagueID          sql.NullInt64  `json:"league_id"`
+	LeagueName        sql.NullString `json:"league_name"`
+	FileGroup         sql.NullString `json:"file_group"`
+	MatchStatus       sql.NullString `json:"match_status"` // Raw Goalserve status
+	Status            string         `json:"status"`       // Canonical status
+	MatchDate         sql.NullTime   `json:"match_date"`
+	MatchTime         sql.NullString `json:"match_time"`
+	KickoffAt         sql.NullTime   `json:"kickoff_at"`
+	PreviousKickoffAt sql.NullTime   `json:"previous_kickoff_at"` // Kickoff before it last moved
+	Timer             sql.NullString `json:"timer"`
+	HTeamID           sql.NullInt64  `json:"h_team_id"`
+	HTeamName         sql.NullString `json:"h_team_name"`
+	HTeamScore        sql.NullInt32  `json:"h_team_score"`
+	HTeamQ1           sql.NullInt32  `json:"h_team_q1"`
+	HTeamQ2           sql.NullInt32  `json:"h_team_q2"`
+	HTeamQ3           sql.NullInt32  `json:"h_team_q3"`
+	HTeamQ4           sql.NullInt32  `json:"h_team_q4"`
+	HTeamOt           sql.NullInt32  `json:"h_team_ot"`
+	ATeamID           sql.NullInt64  `json:"a_team_id"`
+	ATeamName         sql.NullString `json:"a_team_name"`
+	ATeamScore        sql.NullInt32  `json:"a_team_score"`
+	ATeamQ1           sql.NullInt32  `json:"a_team_q1"`
+	ATeamQ2           sql.NullInt32  `json:"a_team_q2"`
+	ATeamQ3           sql.NullInt32  `json:"a_team_q3"`
+	ATeamQ4           sql.NullInt32  `json:"a_team_q4"`
+	ATeamOt           sql.NullInt32  `json:"a_team_ot"`
+	MissedSyncs       int            `json:"missed_syncs"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
 }
 
 // ApiKey represents an API key record for authentication
@@ -69,23 +115,356 @@ type ApiKey struct {
 	Sports     []string     `json:"sports"`     // Unmarshaled from JSON
 	RateLimit  int          `json:"rate_limit"` // Requests per minute
 	IsActive   bool         `json:"is_active"`
+	IsAdmin    bool         `json:"is_admin"` // Grants the admin endpoints
 	CreatedAt  time.Time    `json:"created_at"`
 	LastUsedAt sql.NullTime `json:"last_used_at"`
 	ExpiresAt  sql.NullTime `json:"expires_at"`
 }
 
+// MatchChange represents one field change recorded by sync in match_changes
+type MatchChange struct {
+	ID        int64          `json:"id"`
+	Sport     string         `json:"sport"`
+	MatchID   int64          `json:"match_id"`
+	Field     string         `json:"field"`
+	OldValue  sql.NullString `json:"old_value"`
+	NewValue  sql.NullString `json:"new_value"`
+	ChangedAt time.Time      `json:"changed_at"`
+	SyncRunID string         `json:"sync_run_id"`
+}
+
+// SoccerMatchEvent represents a goal, card or substitution stored in soccer_match_events
+type SoccerMatchEvent struct {
+	ID          int64          `json:"id"`
+	MatchID     int64          `json:"match_id"`
+	EventKey    string         `json:"event_key"`
+	Sequence    int            `json:"sequence"`
+	Type        string         `json:"type"`
+	TeamSide    sql.NullString `json:"team_side"`
+	Player      sql.NullString `json:"player"`
+	PlayerID    sql.NullInt64  `json:"player_id"`
+	Minute      sql.NullInt32  `json:"minute"`
+	ExtraMinute sql.NullInt32  `json:"extra_minute"`
+	Result      sql.NullString `json:"result"`
+}
+
+// SyncLeadership names the leadership held by the running sync worker
+const SyncLeadership = "sync"
+
+// SyncLeaderLease is how stale a leader's heartbeat may get before the leader
+// is presumed dead and a standby may evict it
+const SyncLeaderLease = 15 * time.Second
+
+// SyncLeader represents the instance currently holding a sync leadership lock
+type SyncLeader struct {
+	Name         string         `json:"name"`
+	InstanceID   string         `json:"instance_id"`
+	Hostname     sql.NullString `json:"hostname"`
+	PID          sql.NullInt32  `json:"pid"`
+	BackendPID   int            `json:"backend_pid"`
+	AcquiredAt   time.Time      `json:"acquired_at"`
+	HeartbeatAt  time.Time      `json:"heartbeat_at"`
+	HeartbeatAge time.Duration  `json:"heartbeat_age"` // Measured by the database clock
+}
+
+// Healthy reports whether the leader heartbeated within the lease
+func (l *SyncLeader) Healthy() bool {
+	return l.HeartbeatAge < SyncLeaderLease
+}
+
+// Sync run statuses stored in sync_runs.status
+const (
+	SyncRunRunning   = "running" // Still running, or the worker died before finishing
+	SyncRunSucceeded = "succeeded"
+	SyncRunFailed    = "failed"
+)
+
+// SyncRunStatuses lists every sync run status
+var SyncRunStatuses = []string{SyncRunRunning, SyncRunSucceeded, SyncRunFailed}
+
+// Feeds recorded in sync_runs.feed
+const (
+	SyncFeedToday     = "today"     // Today's feed, carries the live scores
+	SyncFeedFuture    = "future"    // Next 7 days of fixtures
+	SyncFeedRetry     = "retry"     // Dead letters reprocessed by sync retry-failed
+	SyncFeedReprocess = "reprocess" // Archived raw feeds replayed by the reprocess command
+	SyncFeedBackfill  = "backfill"  // Day feeds fetched by the backfill command
+)
+
+// SyncFeeds lists every recorded feed
+var SyncFeeds = []string{SyncFeedToday, SyncFeedFuture, SyncFeedRetry, SyncFeedReprocess, SyncFeedBackfill}
+
+// SyncRun represents one scheduled feed sync recorded in sync_runs
+type SyncRun struct {
+	ID         string           `json:"id"` // Also the sync_run_id of its match_changes
+	Sport      string           `json:"sport"`
+	Feed       string           `json:"feed"`
+	Status     string           `json:"status"`
+	StartedAt  time.Time        `json:"started_at"`
+	FinishedAt sql.NullTime     `json:"finished_at"`
+	HTTPStatus sql.NullInt32    `json:"http_status"` // Goalserve response status
+	Inserted   int              `json:"inserted"`
+	Updated    int              `json:"updated"`
+	Unchanged  int              `json:"unchanged"`
+	Failed     int              `json:"failed"`
+	Failures   []SyncRunFailure `json:"failures"` // Unmarshaled from JSON
+	Error      sql.NullString   `json:"error"`
+}
+
+// SyncRunFailure is one match of a sync run that could not be stored
+type SyncRunFailure struct {
+	MatchID string `json:"match_id"` // Raw feed ID, it may be the reason the match failed
+	Error   string `json:"error"`
+}
+
+// SyncDeadLetter represents a match a sync could not parse or store
+type SyncDeadLetter struct {
+	ID            int64           `json:"id"`
+	Sport         string          `json:"sport"`
+	MatchKey      string          `json:"match_key"`
+	Payload       json.RawMessage `json:"payload"` // Goalserve category holding just this match
+	Error         string          `json:"error"`
+	Attempts      int             `json:"attempts"`
+	SyncRunID     string          `json:"sync_run_id"`
+	FirstFailedAt time.Time       `json:"first_failed_at"`
+	LastFailedAt  time.Time       `json:"last_failed_at"`
+	ResolvedAt    sql.NullTime    `json:"resolved_at"`
+}
+
+// RawFeed is an archived Goalserve payload. Body holds the gzipped payload
+// and is only loaded by GetRawFeedBody.
+type RawFeed struct {
+	ID        int64     `json:"id"`
+	Sport     string    `json:"sport"`
+	Feed      string    `json:"feed"` // Feed path with its date query, e.g. soccernew/home
+	FetchedAt time.Time `json:"fetched_at"`
+	SHA256    string    `json:"sha256"`
+	Size      int       `json:"size"` // Uncompressed bytes
+	Body      []byte    `json:"-"`
+}
+
+// RawFeedParams holds the filters for listing archived feeds
+type RawFeedParams struct {
+	Sport string
+	Feed  string
+	Since time.Time
+	Until time.Time // Zero for no upper bound
+}
+
+// SyncRunParams holds the filters for listing sync runs
+type SyncRunParams struct {
+	Limit  int
+	Offset int
+	Sport  string
+	Feed   string
+	Status string
+}
+
+// Match scopes accepted in QueryParams.When
+const (
+	WhenUpcoming = "upcoming" // Scheduled or in play, soonest first
+	WhenPast     = "past"     // Finished, latest first
+)
+
 // QueryParams holds common query parameters for filtering
 type QueryParams struct {
 	Limit    int
 	Offset   int
-	Date     string
+	Date     string         // YYYY-MM-DD, a calendar day in Location
+	Location *time.Location // Timezone of Date, UTC when nil
 	Status   string
 	LeagueID *int64
+	TeamRef  *int64 // teams.id of the home or away team
+	When     string // WhenUpcoming, WhenPast, or empty for every match
+}
+
+// dayRange returns the start of Date in Location and the start of the next day
+func (p QueryParams) dayRange() (time.Time, time.Time, error) {
+	loc := p.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	day, err := time.ParseInLocation("2006-01-02", p.Date, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid date %q: %w", p.Date, err)
+	}
+	return day, day.AddDate(0, 0, 1), nil
+}
+
+// matchFilters returns the TeamRef and When conditions, which both match
+// tables share
+func (p QueryParams) matchFilters() sq.And {
+	filters := sq.And{}
+	if p.TeamRef != nil {
+		filters = append(filters, sq.Expr("(h_team_ref = ? OR a_team_ref = ?)", *p.TeamRef, *p.TeamRef))
+	}
+	switch p.When {
+	case WhenUpcoming:
+		// A scheduled match long past its kickoff has dropped out of the feed, not started late
+		filters = append(filters,
+			sq.Expr("status = ANY(?)", pq.Array(append([]string{StatusScheduled}, InPlayStatuses...))),
+			sq.Expr("kickoff_at > ?", time.Now().Add(-staleInPlayAfter)),
+		)
+	case WhenPast:
+		filters = append(filters, sq.Eq{"status": StatusFinished})
+	}
+	return filters
+}
+
+// matchOrder returns the ORDER BY of a match list: soonest first for upcoming
+// matches, latest first otherwise
+func (p QueryParams) matchOrder() []string {
+	if p.When == WhenUpcoming {
+		return []string{"kickoff_at ASC NULLS LAST", "id ASC"}
+	}
+	return []string{"kickoff_at DESC NULLS LAST", "id DESC"}
+}
+
+// League represents a league record kept up to date by sync
+type League struct {
+	ID        int64          `json:"id"`
+	Sport     string         `json:"sport"`
+	LeagueID  int64          `json:"league_id"` // Goalserve category ID, the league_id of matches
+	GID       sql.NullInt64  `json:"gid"`
+	Name      string         `json:"name"`
+	Country   sql.NullString `json:"country"` // Prefix of the category name, e.g. "England"
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// Season represents one season of a league, starting in its configured month
+// (July unless set), spanning the match days sync has seen in it
+type Season struct {
+	ID        int64     `json:"id"`
+	LeagueRef int64     `json:"league_ref"`
+	Name      string    `json:"name"` // e.g. "2026/2027", or "2026" for a season starting in January
+	StartsOn  time.Time `json:"starts_on"`
+	EndsOn    time.Time `json:"ends_on"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Team represents a team record kept up to date by sync
+type Team struct {
+	ID        int64     `json:"id"`
+	Sport     string    `json:"sport"`
+	TeamID    int64     `json:"team_id"` // Goalserve team ID, the h_team_id and a_team_id of matches
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// MatchResult is the final score of a finished match, the input of standings and team stats
+type MatchResult struct {
+	MatchID      int64     `json:"match_id"`
+	KickoffAt    time.Time `json:"kickoff_at"`
+	HomeTeamID   int64     `json:"home_team_id"` // Goalserve team IDs
+	HomeTeamName string    `json:"home_team_name"`
+	AwayTeamID   int64     `json:"away_team_id"`
+	AwayTeamName string    `json:"away_team_name"`
+	HomeScore    int       `json:"home_score"` // Goals, or points in basketball
+	AwayScore    int       `json:"away_score"`
+	LeagueID     int64     `json:"league_id,omitempty"` // Goalserve league ID, 0 when unknown
+	LeagueName   string    `json:"league_name,omitempty"`
+}
+
+// QuarterLine is the score by quarter of a finished basketball match
+type QuarterLine struct {
+	MatchID    int64
+	KickoffAt  time.Time
+	HomeTeamID int64 // Goalserve team IDs
+	AwayTeamID int64
+	Home       [4]int // Points in Q1 to Q4
+	Away       [4]int
+	HomeOT     int // Points in all overtime periods
+	AwayOT     int
+}
+
+// QuarterParams filters the matches read by GetBasketballQuarterLines
+type QuarterParams struct {
+	TeamRef  *int64 // teams row ID, home or away
+	LeagueID *int64 // Goalserve league ID
+	From     time.Time
+	To       time.Time // Exclusive; zero bounds are open
 }
 
-// LeagueInfo represents league information
-type LeagueInfo struct {
-	ID   int64  `json:"id"`
-	GID  int64  `json:"gid"`
-	Name string `json:"name"`
+// TeamRating is a team's Elo rating after one of its matches
+type TeamRating struct {
+	Sport        string    `json:"sport"`
+	TeamRef      int64     `json:"team_ref"` // teams row IDs
+	OpponentRef  int64     `json:"opponent_ref"`
+	MatchID      int64     `json:"match_id"`
+	KickoffAt    time.Time `json:"kickoff_at"`
+	Season       string    `json:"season"` // Season of the match, else the team's previous one; empty when unknown
+	Home         bool      `json:"home"`
+	RatingBefore float64   `json:"rating_before"` // After any off-season regression
+	Rating       float64   `json:"rating"`
+
+	// Read from teams by GetTeamRatingHistory
+	OpponentID   int64  `json:"opponent_id,omitempty"`
+	OpponentName string `json:"opponent_name,omitempty"`
+}
+
+// RatedTeam is a team's current rating, its latest TeamRating
+type RatedTeam struct {
+	Rank        int       `json:"rank"`
+	TeamID      int64     `json:"team_id"` // Goalserve team ID
+	Name        string    `json:"name"`
+	Rating      float64   `json:"rating"`
+	Played      int       `json:"played"` // Rated matches
+	LastMatchAt time.Time `json:"last_match_at"`
+}
+
+// RatingMatch is a finished match to rate, with its teams as teams row IDs
+type RatingMatch struct {
+	MatchID   int64
+	KickoffAt time.Time
+	Season    string
+	HomeRef   int64
+	AwayRef   int64
+	HomeScore int
+	AwayScore int
+}
+
+// RatingParams holds the filters for listing current ratings
+type RatingParams struct {
+	Limit    int
+	Offset   int
+	LeagueID *int64 // Only teams with matches in this Goalserve league
+}
+
+// Fixture is a scheduled match to predict
+type Fixture struct {
+	MatchID    int64
+	KickoffAt  time.Time
+	LeagueID   int64 // Goalserve IDs
+	HomeTeamID int64
+	AwayTeamID int64
+}
+
+// H2HCache holds the meetings of a team pair last fetched from Goalserve.
+// TeamA is the lower of the two Goalserve team IDs.
+type H2HCache struct {
+	Sport     string        `json:"sport"`
+	TeamA     int64         `json:"team_a"`
+	TeamB     int64         `json:"team_b"`
+	Meetings  []MatchResult `json:"meetings"`
+	FetchedAt time.Time     `json:"fetched_at"`
+}
+
+// H2HRequest is a team pair whose Goalserve h2h feed the API asked the sync
+// worker to fetch. TeamA is the lower of the two Goalserve team IDs.
+type H2HRequest struct {
+	Sport       string    `json:"sport"`
+	TeamA       int64     `json:"team_a"`
+	TeamB       int64     `json:"team_b"`
+	RequestedAt time.Time `json:"requested_at"`
+}
+
+// TeamParams holds the filters for listing teams
+type TeamParams struct {
+	Limit    int
+	Offset   int
+	Search   string // Case-insensitive substring of the name
+	LeagueID *int64 // Goalserve league ID the team played in
 }
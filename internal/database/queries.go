@@ -1,15 +1,76 @@
 package database
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
 )
 
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// soccerMatchColumns lists the soccer_matches columns read by scanSoccerMatch, in scan order
+var soccerMatchColumns = []string{
+	"id", "match_id", "league_gid", "league_id", "league_name",
+	"match_status", "status", "match_start_date", "match_start_time", "kickoff_at", "previous_kickoff_at",
+	"h_team_id", "a_team_id", "h_team_name", "a_team_name",
+	"h_team_goals", "a_team_goals", "ht_score", "ft_score",
+	"events", "missed_syncs", "created_at", "updated_at",
+}
+
+// scanSoccerMatch reads one row selected with soccerMatchColumns
+func scanSoccerMatch(row rowScanner) (SoccerMatch, error) {
+	var m SoccerMatch
+	err := row.Scan(
+		&m.ID, &m.MatchID, &m.LeagueGID, &m.LeagueID, &m.LeagueName,
+		&m.MatchStatus, &m.Status, &m.MatchStartDate, &m.MatchStartTime, &m.KickoffAt, &m.PreviousKickoffAt,
+		&m.HTeamID, &m.ATeamID, &m.HTeamName, &m.ATeamName,
+		&m.HTeamGoals, &m.ATeamGoals, &m.HTScore, &m.FTScore,
+		&m.Events, &m.MissedSyncs, &m.CreatedAt, &m.UpdatedAt,
+	)
+	return m, err
+}
+
+// basketballMatchColumns lists the basketball_matches columns read by scanBasketballMatch, in scan order
+var basketballMatchColumns = []string{
+	"id", "match_id", "league_gid", "league_id", "league_name", "file_group",
+	"match_status", "status", "match_date", "match_time", "kickoff_at", "previous_kickoff_at", "timer",
+	"h_team_id", "h_team_name", "h_team_score",
+	"h_team_q1", "h_team_q2", "h_team_q3", "h_team_q4", "h_team_ot",
+	"a_team_id", "a_team_name", "a_team_score",
+	"a_team_q1", "a_team_q2", "a_team_q3", "a_team_q4", "a_team_ot",
+	"missed_syncs", "created_at", "updated_at",
+}
+
+// scanBasketballMatch reads one row selected with basketballMatchColumns
+func scanBasketballMatch(row rowScanner) (BasketballMatch, error) {
+	var m BasketballMatch
+	err := row.Scan(
+		&m.ID, &m.MatchID, &m.LeagueGID, &m.LeagueID, &m.LeagueName, &m.FileGroup,
+		&m.MatchStatus, &m.Status, &m.MatchDate, &m.MatchTime, &m.KickoffAt, &m.PreviousKickoffAt, &m.Timer,
+		&m.HTeamID, &m.HTeamName, &m.HTeamScore,
+		&m.HTeamQ1, &m.HTeamQ2, &m.HTeamQ3, &m.HTeamQ4, &m.HTeamOt,
+		&m.ATeamID, &m.ATeamName, &m.ATeamScore,
+		&m.ATeamQ1, &m.ATeamQ2, &m.ATeamQ3, &m.ATeamQ4, &m.ATeamOt,
+		&m.MissedSyncs, &m.CreatedAt, &m.UpdatedAt,
+	)
+	return m, err
+}
+
 // Example: Query soccer matches
 func (db *DB) GetSoccerMatches() ([]SoccerMatch, error) {
 	query := db.Builder.
-		Select("*").
+		Select(soccerMatchColumns...).
 		From("soccer_matches").
 		OrderBy("match_start_date DESC").
 		Limit(10)
@@ -27,14 +88,7 @@ func (db *DB) GetSoccerMatches() ([]SoccerMatch, error) {
 
 	var matches []SoccerMatch
 	for rows.Next() {
-		var m SoccerMatch
-		err := rows.Scan(
-			&m.ID, &m.MatchID, &m.LeagueGID, &m.LeagueID, &m.LeagueName,
-			&m.MatchStatus, &m.MatchStartDate, &m.MatchStartTime,
-			&m.HTeamID, &m.ATeamID, &m.HTeamName, &m.ATeamName,
-			&m.HTeamGoals, &m.ATeamGoals, &m.HTScore, &m.FTScore,
-			&m.Events, &m.CreatedAt, &m.UpdatedAt,
-		)
+		m, err := scanSoccerMatch(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
@@ -47,7 +101,7 @@ func (db *DB) GetSoccerMatches() ([]SoccerMatch, error) {
 // Example: Get match by ID
 func (db *DB) GetMatchByID(matchID int64) (*SoccerMatch, error) {
 	query := db.Builder.
-		Select("*").
+		Select(soccerMatchColumns...).
 		From("soccer_matches").
 		Where("match_id = ?", matchID)
 
@@ -56,14 +110,7 @@ func (db *DB) GetMatchByID(matchID int64) (*SoccerMatch, error) {
 		return nil, fmt.Errorf("failed to build query: %w", err)
 	}
 
-	var m SoccerMatch
-	err = db.Conn.QueryRow(sql, args...).Scan(
-		&m.ID, &m.MatchID, &m.LeagueGID, &m.LeagueID, &m.LeagueName,
-		&m.MatchStatus, &m.MatchStartDate, &m.MatchStartTime,
-		&m.HTeamID, &m.ATeamID, &m.HTeamName, &m.ATeamName,
-		&m.HTeamGoals, &m.ATeamGoals, &m.HTScore, &m.FTScore,
-		&m.Events, &m.CreatedAt, &m.UpdatedAt,
-	)
+	m, err := scanSoccerMatch(db.Conn.QueryRow(sql, args...))
 	if err != nil {
 		return nil, fmt.Errorf("failed to query match: %w", err)
 	}
@@ -128,7 +175,7 @@ func (db *DB) UpdateMatchScore(matchID int64, hGoals, aGoals int, htScore, ftSco
 // GetBasketballMatches returns recent basketball matches
 func (db *DB) GetBasketballMatches() ([]BasketballMatch, error) {
 	query := db.Builder.
-		Select("*").
+		Select(basketballMatchColumns...).
 		From("basketball_matches").
 		OrderBy("match_date DESC").
 		Limit(10)
@@ -146,16 +193,7 @@ func (db *DB) GetBasketballMatches() ([]BasketballMatch, error) {
 
 	var matches []BasketballMatch
 	for rows.Next() {
-		var m BasketballMatch
-		err := rows.Scan(
-			&m.ID, &m.MatchID, &m.LeagueGID, &m.LeagueID, &m.LeagueName, &m.FileGroup,
-			&m.MatchStatus, &m.MatchDate, &m.MatchTime, &m.Timer,
-			&m.HTeamID, &m.HTeamName, &m.HTeamScore,
-			&m.HTeamQ1, &m.HTeamQ2, &m.HTeamQ3, &m.HTeamQ4, &m.HTeamOt,
-			&m.ATeamID, &m.ATeamName, &m.ATeamScore,
-			&m.ATeamQ1, &m.ATeamQ2, &m.ATeamQ3, &m.ATeamQ4, &m.ATeamOt,
-			&m.CreatedAt, &m.UpdatedAt,
-		)
+		m, err := scanBasketballMatch(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
@@ -168,7 +206,7 @@ func (db *DB) GetBasketballMatches() ([]BasketballMatch, error) {
 // GetBasketballMatchByID returns a basketball match by its match ID
 func (db *DB) GetBasketballMatchByID(matchID int64) (*BasketballMatch, error) {
 	query := db.Builder.
-		Select("*").
+		Select(basketballMatchColumns...).
 		From("basketball_matches").
 		Where("match_id = ?", matchID)
 
@@ -177,16 +215,7 @@ func (db *DB) GetBasketballMatchByID(matchID int64) (*BasketballMatch, error) {
 		return nil, fmt.Errorf("failed to build query: %w", err)
 	}
 
-	var m BasketballMatch
-	err = db.Conn.QueryRow(sql, args...).Scan(
-		&m.ID, &m.MatchID, &m.LeagueGID, &m.LeagueID, &m.LeagueName, &m.FileGroup,
-		&m.MatchStatus, &m.MatchDate, &m.MatchTime, &m.Timer,
-		&m.HTeamID, &m.HTeamName, &m.HTeamScore,
-		&m.HTeamQ1, &m.HTeamQ2, &m.HTeamQ3, &m.HTeamQ4, &m.HTeamOt,
-		&m.ATeamID, &m.ATeamName, &m.ATeamScore,
-		&m.ATeamQ1, &m.ATeamQ2, &m.ATeamQ3, &m.ATeamQ4, &m.ATeamOt,
-		&m.CreatedAt, &m.UpdatedAt,
-	)
+	m, err := scanBasketballMatch(db.Conn.QueryRow(sql, args...))
 	if err != nil {
 		return nil, fmt.Errorf("failed to query basketball match: %w", err)
 	}
@@ -199,7 +228,7 @@ func (db *DB) GetBasketballMatchByID(matchID int64) (*BasketballMatch, error) {
 // ============================================================================
 
 // CreateApiKey creates a new API key record
-func (db *DB) CreateApiKey(keyHash, keyPrefix, name string, sports []string, rateLimit int) (*ApiKey, error) {
+func (db *DB) CreateApiKey(keyHash, keyPrefix, name string, sports []string, rateLimit int, isAdmin bool) (*ApiKey, error) {
 	sportsJSON, err := json.Marshal(sports)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal sports: %w", err)
@@ -207,9 +236,9 @@ func (db *DB) CreateApiKey(keyHash, keyPrefix, name string, sports []string, rat
 
 	query := db.Builder.
 		Insert("api_keys").
-		Columns("key_hash", "key_prefix", "name", "sports", "rate_limit").
-		Values(keyHash, keyPrefix, name, string(sportsJSON), rateLimit).
-		Suffix("RETURNING id, key_hash, key_prefix, name, sports, rate_limit, is_active, created_at, last_used_at, expires_at")
+		Columns("key_hash", "key_prefix", "name", "sports", "rate_limit", "is_admin").
+		Values(keyHash, keyPrefix, name, string(sportsJSON), rateLimit, isAdmin).
+		Suffix("RETURNING id, key_hash, key_prefix, name, sports, rate_limit, is_active, is_admin, created_at, last_used_at, expires_at")
 
 	sql, args, err := query.ToSql()
 	if err != nil {
@@ -220,7 +249,7 @@ func (db *DB) CreateApiKey(keyHash, keyPrefix, name string, sports []string, rat
 	var sportsStr string
 	err = db.Conn.QueryRow(sql, args...).Scan(
 		&apiKey.ID, &apiKey.KeyHash, &apiKey.KeyPrefix, &apiKey.Name,
-		&sportsStr, &apiKey.RateLimit, &apiKey.IsActive,
+		&sportsStr, &apiKey.RateLimit, &apiKey.IsActive, &apiKey.IsAdmin,
 		&apiKey.CreatedAt, &apiKey.LastUsedAt, &apiKey.ExpiresAt,
 	)
 	if err != nil {
@@ -238,7 +267,7 @@ func (db *DB) CreateApiKey(keyHash, keyPrefix, name string, sports []string, rat
 // GetApiKeyByHash retrieves an API key by its hash
 func (db *DB) GetApiKeyByHash(keyHash string) (*ApiKey, error) {
 	query := db.Builder.
-		Select("id", "key_hash", "key_prefix", "name", "sports", "rate_limit", "is_active", "created_at", "last_used_at", "expires_at").
+		Select("id", "key_hash", "key_prefix", "name", "sports", "rate_limit", "is_active", "is_admin", "created_at", "last_used_at", "expires_at").
 		From("api_keys").
 		Where("key_hash = ?", keyHash)
 
@@ -251,7 +280,7 @@ func (db *DB) GetApiKeyByHash(keyHash string) (*ApiKey, error) {
 	var sportsStr string
 	err = db.Conn.QueryRow(sql, args...).Scan(
 		&apiKey.ID, &apiKey.KeyHash, &apiKey.KeyPrefix, &apiKey.Name,
-		&sportsStr, &apiKey.RateLimit, &apiKey.IsActive,
+		&sportsStr, &apiKey.RateLimit, &apiKey.IsActive, &apiKey.IsAdmin,
 		&apiKey.CreatedAt, &apiKey.LastUsedAt, &apiKey.ExpiresAt,
 	)
 	if err != nil {
@@ -269,7 +298,7 @@ func (db *DB) GetApiKeyByHash(keyHash string) (*ApiKey, error) {
 // GetAllApiKeys retrieves all API keys
 func (db *DB) GetAllApiKeys() ([]ApiKey, error) {
 	query := db.Builder.
-		Select("id", "key_hash", "key_prefix", "name", "sports", "rate_limit", "is_active", "created_at", "last_used_at", "expires_at").
+		Select("id", "key_hash", "key_prefix", "name", "sports", "rate_limit", "is_active", "is_admin", "created_at", "last_used_at", "expires_at").
 		From("api_keys").
 		OrderBy("created_at DESC")
 
@@ -290,7 +319,7 @@ func (db *DB) GetAllApiKeys() ([]ApiKey, error) {
 		var sportsStr string
 		err := rows.Scan(
 			&apiKey.ID, &apiKey.KeyHash, &apiKey.KeyPrefix, &apiKey.Name,
-			&sportsStr, &apiKey.RateLimit, &apiKey.IsActive,
+			&sportsStr, &apiKey.RateLimit, &apiKey.IsActive, &apiKey.IsAdmin,
 			&apiKey.CreatedAt, &apiKey.LastUsedAt, &apiKey.ExpiresAt,
 		)
 		if err != nil {
@@ -357,7 +386,7 @@ func (db *DB) UpdateApiKeyLastUsed(id int64) error {
 func (db *DB) GetSoccerMatchesFiltered(params QueryParams) ([]SoccerMatch, int, error) {
 	// Build base query
 	baseQuery := db.Builder.
-		Select("*").
+		Select(soccerMatchColumns...).
 		From("soccer_matches")
 
 	countQuery := db.Builder.
@@ -366,17 +395,26 @@ func (db *DB) GetSoccerMatchesFiltered(params QueryParams) ([]SoccerMatch, int,
 
 	// Apply filters
 	if params.Date != "" {
-		baseQuery = baseQuery.Where("match_start_date = ?", params.Date)
-		countQuery = countQuery.Where("match_start_date = ?", params.Date)
+		// The day is taken in the caller's timezone, so match on the kickoff instant
+		from, to, err := params.dayRange()
+		if err != nil {
+			return nil, 0, err
+		}
+		baseQuery = baseQuery.Where("kickoff_at >= ? AND kickoff_at < ?", from, to)
+		countQuery = countQuery.Where("kickoff_at >= ? AND kickoff_at < ?", from, to)
 	}
 	if params.Status != "" {
-		baseQuery = baseQuery.Where("match_status = ?", params.Status)
-		countQuery = countQuery.Where("match_status = ?", params.Status)
+		baseQuery = baseQuery.Where("status = ?", params.Status)
+		countQuery = countQuery.Where("status = ?", params.Status)
 	}
 	if params.LeagueID != nil {
 		baseQuery = baseQuery.Where("league_id = ?", *params.LeagueID)
 		countQuery = countQuery.Where("league_id = ?", *params.LeagueID)
 	}
+	if filters := params.matchFilters(); len(filters) > 0 {
+		baseQuery = baseQuery.Where(filters)
+		countQuery = countQuery.Where(filters)
+	}
 
 	// Get total count
 	countSQL, countArgs, err := countQuery.ToSql()
@@ -391,7 +429,7 @@ func (db *DB) GetSoccerMatchesFiltered(params QueryParams) ([]SoccerMatch, int,
 
 	// Apply pagination and ordering
 	baseQuery = baseQuery.
-		OrderBy("match_start_date DESC", "match_start_time DESC").
+		OrderBy(params.matchOrder()...).
 		Limit(uint64(params.Limit)).
 		Offset(uint64(params.Offset))
 
@@ -408,14 +446,7 @@ func (db *DB) GetSoccerMatchesFiltered(params QueryParams) ([]SoccerMatch, int,
 
 	var matches []SoccerMatch
 	for rows.Next() {
-		var m SoccerMatch
-		err := rows.Scan(
-			&m.ID, &m.MatchID, &m.LeagueGID, &m.LeagueID, &m.LeagueName,
-			&m.MatchStatus, &m.MatchStartDate, &m.MatchStartTime,
-			&m.HTeamID, &m.ATeamID, &m.HTeamName, &m.ATeamName,
-			&m.HTeamGoals, &m.ATeamGoals, &m.HTScore, &m.FTScore,
-			&m.Events, &m.CreatedAt, &m.UpdatedAt,
-		)
+		m, err := scanSoccerMatch(rows)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan row: %w", err)
 		}
@@ -427,14 +458,11 @@ func (db *DB) GetSoccerMatchesFiltered(params QueryParams) ([]SoccerMatch, int,
 
 // GetLiveSoccerMatches returns currently live soccer matches
 func (db *DB) GetLiveSoccerMatches() ([]SoccerMatch, error) {
-	// Common statuses for live matches
-	liveStatuses := []string{"1H", "HT", "2H", "ET", "P", "Live", "In Play"}
-
 	query := db.Builder.
-		Select("*").
+		Select(soccerMatchColumns...).
 		From("soccer_matches").
-		Where("match_status IN (?, ?, ?, ?, ?, ?, ?)", liveStatuses[0], liveStatuses[1], liveStatuses[2], liveStatuses[3], liveStatuses[4], liveStatuses[5], liveStatuses[6]).
-		OrderBy("match_start_time ASC")
+		Where("status = ANY(?)", pq.Array(InPlayStatuses)).
+		OrderBy("kickoff_at ASC")
 
 	sql, args, err := query.ToSql()
 	if err != nil {
@@ -449,14 +477,7 @@ func (db *DB) GetLiveSoccerMatches() ([]SoccerMatch, error) {
 
 	var matches []SoccerMatch
 	for rows.Next() {
-		var m SoccerMatch
-		err := rows.Scan(
-			&m.ID, &m.MatchID, &m.LeagueGID, &m.LeagueID, &m.LeagueName,
-			&m.MatchStatus, &m.MatchStartDate, &m.MatchStartTime,
-			&m.HTeamID, &m.ATeamID, &m.HTeamName, &m.ATeamName,
-			&m.HTeamGoals, &m.ATeamGoals, &m.HTScore, &m.FTScore,
-			&m.Events, &m.CreatedAt, &m.UpdatedAt,
-		)
+		m, err := scanSoccerMatch(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
@@ -466,49 +487,6 @@ func (db *DB) GetLiveSoccerMatches() ([]SoccerMatch, error) {
 	return matches, nil
 }
 
-// GetSoccerLeagues returns distinct leagues from soccer matches
-func (db *DB) GetSoccerLeagues() ([]LeagueInfo, error) {
-	query := db.Builder.
-		Select("DISTINCT league_id", "league_gid", "league_name").
-		From("soccer_matches").
-		Where("league_id IS NOT NULL").
-		OrderBy("league_name ASC")
-
-	sql, args, err := query.ToSql()
-	if err != nil {
-		return nil, fmt.Errorf("failed to build query: %w", err)
-	}
-
-	rows, err := db.Conn.Query(sql, args...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute query: %w", err)
-	}
-	defer rows.Close()
-
-	var leagues []LeagueInfo
-	for rows.Next() {
-		var l LeagueInfo
-		var gid, id *int64
-		var name *string
-		err := rows.Scan(&id, &gid, &name)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
-		}
-		if id != nil {
-			l.ID = *id
-		}
-		if gid != nil {
-			l.GID = *gid
-		}
-		if name != nil {
-			l.Name = *name
-		}
-		leagues = append(leagues, l)
-	}
-
-	return leagues, nil
-}
-
 // ============================================================================
 // Basketball Filtered Queries (for API)
 // ============================================================================
@@ -517,7 +495,7 @@ func (db *DB) GetSoccerLeagues() ([]LeagueInfo, error) {
 func (db *DB) GetBasketballMatchesFiltered(params QueryParams) ([]BasketballMatch, int, error) {
 	// Build base query
 	baseQuery := db.Builder.
-		Select("*").
+		Select(basketballMatchColumns...).
 		From("basketball_matches")
 
 	countQuery := db.Builder.
@@ -526,17 +504,26 @@ func (db *DB) GetBasketballMatchesFiltered(params QueryParams) ([]BasketballMatc
 
 	// Apply filters
 	if params.Date != "" {
-		baseQuery = baseQuery.Where("match_date = ?", params.Date)
-		countQuery = countQuery.Where("match_date = ?", params.Date)
+		// The day is taken in the caller's timezone, so match on the kickoff instant
+		from, to, err := params.dayRange()
+		if err != nil {
+			return nil, 0, err
+		}
+		baseQuery = baseQuery.Where("kickoff_at >= ? AND kickoff_at < ?", from, to)
+		countQuery = countQuery.Where("kickoff_at >= ? AND kickoff_at < ?", from, to)
 	}
 	if params.Status != "" {
-		baseQuery = baseQuery.Where("match_status = ?", params.Status)
-		countQuery = countQuery.Where("match_status = ?", params.Status)
+		baseQuery = baseQuery.Where("status = ?", params.Status)
+		countQuery = countQuery.Where("status = ?", params.Status)
 	}
 	if params.LeagueID != nil {
 		baseQuery = baseQuery.Where("league_id = ?", *params.LeagueID)
 		countQuery = countQuery.Where("league_id = ?", *params.LeagueID)
 	}
+	if filters := params.matchFilters(); len(filters) > 0 {
+		baseQuery = baseQuery.Where(filters)
+		countQuery = countQuery.Where(filters)
+	}
 
 	// Get total count
 	countSQL, countArgs, err := countQuery.ToSql()
@@ -551,7 +538,7 @@ func (db *DB) GetBasketballMatchesFiltered(params QueryParams) ([]BasketballMatc
 
 	// Apply pagination and ordering
 	baseQuery = baseQuery.
-		OrderBy("match_date DESC", "match_time DESC").
+		OrderBy(params.matchOrder()...).
 		Limit(uint64(params.Limit)).
 		Offset(uint64(params.Offset))
 
@@ -568,16 +555,7 @@ func (db *DB) GetBasketballMatchesFiltered(params QueryParams) ([]BasketballMatc
 
 	var matches []BasketballMatch
 	for rows.Next() {
-		var m BasketballMatch
-		err := rows.Scan(
-			&m.ID, &m.MatchID, &m.LeagueGID, &m.LeagueID, &m.LeagueName, &m.FileGroup,
-			&m.MatchStatus, &m.MatchDate, &m.MatchTime, &m.Timer,
-			&m.HTeamID, &m.HTeamName, &m.HTeamScore,
-			&m.HTeamQ1, &m.HTeamQ2, &m.HTeamQ3, &m.HTeamQ4, &m.HTeamOt,
-			&m.ATeamID, &m.ATeamName, &m.ATeamScore,
-			&m.ATeamQ1, &m.ATeamQ2, &m.ATeamQ3, &m.ATeamQ4, &m.ATeamOt,
-			&m.CreatedAt, &m.UpdatedAt,
-		)
+		m, err := scanBasketballMatch(rows)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan row: %w", err)
 		}
@@ -589,14 +567,11 @@ func (db *DB) GetBasketballMatchesFiltered(params QueryParams) ([]BasketballMatc
 
 // GetLiveBasketballMatches returns currently live basketball matches
 func (db *DB) GetLiveBasketballMatches() ([]BasketballMatch, error) {
-	// Common statuses for live basketball matches
-	liveStatuses := []string{"Q1", "Q2", "Q3", "Q4", "OT", "HT", "Live", "In Play"}
-
 	query := db.Builder.
-		Select("*").
+		Select(basketballMatchColumns...).
 		From("basketball_matches").
-		Where("match_status IN (?, ?, ?, ?, ?, ?, ?, ?)", liveStatuses[0], liveStatuses[1], liveStatuses[2], liveStatuses[3], liveStatuses[4], liveStatuses[5], liveStatuses[6], liveStatuses[7]).
-		OrderBy("match_time ASC")
+		Where("status = ANY(?)", pq.Array(InPlayStatuses)).
+		OrderBy("kickoff_at ASC")
 
 	sql, args, err := query.ToSql()
 	if err != nil {
@@ -611,16 +586,7 @@ func (db *DB) GetLiveBasketballMatches() ([]BasketballMatch, error) {
 
 	var matches []BasketballMatch
 	for rows.Next() {
-		var m BasketballMatch
-		err := rows.Scan(
-			&m.ID, &m.MatchID, &m.LeagueGID, &m.LeagueID, &m.LeagueName, &m.FileGroup,
-			&m.MatchStatus, &m.MatchDate, &m.MatchTime, &m.Timer,
-			&m.HTeamID, &m.HTeamName, &m.HTeamScore,
-			&m.HTeamQ1, &m.HTeamQ2, &m.HTeamQ3, &m.HTeamQ4, &m.HTeamOt,
-			&m.ATeamID, &m.ATeamName, &m.ATeamScore,
-			&m.ATeamQ1, &m.ATeamQ2, &m.ATeamQ3, &m.ATeamQ4, &m.ATeamOt,
-			&m.CreatedAt, &m.UpdatedAt,
-		)
+		m, err := scanBasketballMatch(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
@@ -630,45 +596,1515 @@ func (db *DB) GetLiveBasketballMatches() ([]BasketballMatch, error) {
 	return matches, nil
 }
 
-// GetBasketballLeagues returns distinct leagues from basketball matches
-func (db *DB) GetBasketballLeagues() ([]LeagueInfo, error) {
+// ============================================================================
+// League and Team Queries (for API)
+// ============================================================================
+
+// leagueColumns lists the leagues columns read by scanLeague, in scan order
+var leagueColumns = []string{"id", "sport", "league_id", "gid", "name", "country", "created_at", "updated_at"}
+
+// scanLeague reads one row selected with leagueColumns
+func scanLeague(row rowScanner) (League, error) {
+	var l League
+	err := row.Scan(&l.ID, &l.Sport, &l.LeagueID, &l.GID, &l.Name, &l.Country, &l.CreatedAt, &l.UpdatedAt)
+	return l, err
+}
+
+// teamColumns lists the teams columns read by scanTeam, in scan order
+var teamColumns = []string{"id", "sport", "team_id", "name", "created_at", "updated_at"}
+
+// scanTeam reads one row selected with teamColumns
+func scanTeam(row rowScanner) (Team, error) {
+	var t Team
+	err := row.Scan(&t.ID, &t.Sport, &t.TeamID, &t.Name, &t.CreatedAt, &t.UpdatedAt)
+	return t, err
+}
+
+// prefixColumns qualifies columns with a table alias
+func prefixColumns(alias string, columns []string) []string {
+	prefixed := make([]string, len(columns))
+	for i, col := range columns {
+		prefixed[i] = alias + "." + col
+	}
+	return prefixed
+}
+
+// likeEscaper escapes the LIKE wildcards in user input
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// GetLeagues returns every league of a sport, ordered by name
+func (db *DB) GetLeagues(sport string) ([]League, error) {
 	query := db.Builder.
-		Select("DISTINCT league_id", "league_gid", "league_name").
-		From("basketball_matches").
-		Where("league_id IS NOT NULL").
-		OrderBy("league_name ASC")
+		Select(leagueColumns...).
+		From("leagues").
+		Where("sport = ?", sport).
+		OrderBy("name ASC", "league_id ASC")
 
-	sql, args, err := query.ToSql()
+	sqlStr, args, err := query.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build query: %w", err)
 	}
 
-	rows, err := db.Conn.Query(sql, args...)
+	rows, err := db.Conn.Query(sqlStr, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
 	defer rows.Close()
 
-	var leagues []LeagueInfo
+	var leagues []League
 	for rows.Next() {
-		var l LeagueInfo
-		var gid, id *int64
-		var name *string
-		err := rows.Scan(&id, &gid, &name)
+		l, err := scanLeague(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
-		if id != nil {
-			l.ID = *id
+		leagues = append(leagues, l)
+	}
+
+	return leagues, rows.Err()
+}
+
+// GetLeague returns a league by its Goalserve ID, or nil when it is unknown
+func (db *DB) GetLeague(sport string, leagueID int64) (*League, error) {
+	query := db.Builder.
+		Select(leagueColumns...).
+		From("leagues").
+		Where("sport = ? AND league_id = ?", sport, leagueID)
+
+	sqlStr, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	l, err := scanLeague(db.Conn.QueryRow(sqlStr, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league: %w", err)
+	}
+	return &l, nil
+}
+
+// GetSeasons returns the seasons of a league, latest first
+func (db *DB) GetSeasons(leagueRef int64) ([]Season, error) {
+	query := db.Builder.
+		Select("id", "league_ref", "name", "starts_on", "ends_on", "created_at", "updated_at").
+		From("seasons").
+		Where("league_ref = ?", leagueRef).
+		OrderBy("starts_on DESC")
+
+	sqlStr, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.Conn.Query(sqlStr, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var seasons []Season
+	for rows.Next() {
+		var s Season
+		if err := rows.Scan(&s.ID, &s.LeagueRef, &s.Name, &s.StartsOn, &s.EndsOn, &s.CreatedAt, &s.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		seasons = append(seasons, s)
+	}
+
+	return seasons, rows.Err()
+}
+
+// seasonNameExpr renders in SQL the name of the season starting in month start
+// that the match day column falls in, as the sync names it
+func seasonNameExpr(column string, start time.Month) sq.Sqlizer {
+	if start == time.January {
+		return sq.Expr(fmt.Sprintf("EXTRACT(YEAR FROM %s)::int::text", column))
+	}
+	year := fmt.Sprintf("EXTRACT(YEAR FROM %s - make_interval(months => ?))::int", column)
+	return sq.Expr(year+" || '/' || ("+year+" + 1)", int(start-1), int(start-1))
+}
+
+// RegroupSeasons renames the seasons of a league to ones starting in month
+// start: it creates the seasons its match days fall in, points the matches at
+// them and deletes the seasons left without matches, in one transaction. It
+// returns how many matches moved season.
+func (db *DB) RegroupSeasons(ctx context.Context, sport string, leagueRef int64, start time.Month) (int64, error) {
+	table, ok := matchTables[sport]
+	if !ok {
+		return 0, fmt.Errorf("unsupported sport: %s", sport)
+	}
+	day := "m." + dateColumns[sport]
+	name, nameArgs, err := seasonNameExpr(day, start).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var moved int64
+	err = db.WithTx(ctx, func(tx *sql.Tx) error {
+		insert := db.Builder.
+			Insert("seasons").
+			Columns("league_ref", "name", "starts_on", "ends_on").
+			Select(sq.Select("m.league_ref").
+				Column(seasonNameExpr(day, start)).
+				Columns("MIN("+day+")", "MAX("+day+")").
+				From(table+" m").
+				Where("m.league_ref = ? AND "+day+" IS NOT NULL", leagueRef).
+				GroupBy("1", "2")).
+			Suffix(`ON CONFLICT (league_ref, name) DO UPDATE SET
+				starts_on = EXCLUDED.starts_on,
+				ends_on = EXCLUDED.ends_on,
+				updated_at = now()
+				WHERE (seasons.starts_on, seasons.ends_on) IS DISTINCT FROM (EXCLUDED.starts_on, EXCLUDED.ends_on)`)
+		sqlStr, args, err := insert.ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build query: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, sqlStr, args...); err != nil {
+			return fmt.Errorf("failed to insert seasons: %w", err)
+		}
+
+		sqlStr, args, err = db.Builder.
+			Update(table+" m").
+			Set("season_ref", sq.Expr("s.id")).
+			From("seasons s").
+			Where("s.league_ref = m.league_ref AND m.league_ref = ?", leagueRef).
+			Where(sq.Expr("s.name = "+name, nameArgs...)).
+			Where("m.season_ref IS DISTINCT FROM s.id").
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build query: %w", err)
+		}
+		result, err := tx.ExecContext(ctx, sqlStr, args...)
+		if err != nil {
+			return fmt.Errorf("failed to move matches to seasons: %w", err)
+		}
+		if moved, err = result.RowsAffected(); err != nil {
+			return fmt.Errorf("failed to count moved matches: %w", err)
+		}
+
+		sqlStr, args, err = db.Builder.
+			Delete("seasons s").
+			Where("s.league_ref = ?", leagueRef).
+			Where("NOT EXISTS (SELECT 1 FROM " + table + " m WHERE m.season_ref = s.id)").
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build query: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, sqlStr, args...); err != nil {
+			return fmt.Errorf("failed to delete empty seasons: %w", err)
 		}
-		if gid != nil {
-			l.GID = *gid
+		return nil
+	})
+	return moved, err
+}
+
+// GetTeams returns the teams of a sport with filtering and pagination, ordered by name
+func (db *DB) GetTeams(sport string, params TeamParams) ([]Team, int, error) {
+	table, ok := matchTables[sport]
+	if !ok {
+		return nil, 0, fmt.Errorf("unsupported sport: %s", sport)
+	}
+
+	filters := sq.And{sq.Expr("t.sport = ?", sport)}
+	if params.Search != "" {
+		filters = append(filters, sq.Expr("t.name ILIKE ?", "%"+likeEscaper.Replace(params.Search)+"%"))
+	}
+	if params.LeagueID != nil {
+		filters = append(filters, sq.Expr(fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM %s m JOIN leagues l ON l.id = m.league_ref
+			WHERE l.league_id = ? AND t.id IN (m.h_team_ref, m.a_team_ref))`, table), *params.LeagueID))
+	}
+
+	countQuery := db.Builder.
+		Select("COUNT(*)").
+		From("teams t").
+		Where(filters)
+
+	countSQL, countArgs, err := countQuery.ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to build count query: %w", err)
+	}
+
+	var total int
+	if err := db.Conn.QueryRow(countSQL, countArgs...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count teams: %w", err)
+	}
+
+	query := db.Builder.
+		Select(prefixColumns("t", teamColumns)...).
+		From("teams t").
+		Where(filters).
+		OrderBy("t.name ASC", "t.team_id ASC").
+		Limit(uint64(params.Limit)).
+		Offset(uint64(params.Offset))
+
+	sqlStr, args, err := query.ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.Conn.Query(sqlStr, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var teams []Team
+	for rows.Next() {
+		t, err := scanTeam(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan row: %w", err)
 		}
-		if name != nil {
-			l.Name = *name
+		teams = append(teams, t)
+	}
+
+	return teams, total, rows.Err()
+}
+
+// GetTeam returns a team by its Goalserve ID, or nil when it is unknown
+func (db *DB) GetTeam(sport string, teamID int64) (*Team, error) {
+	query := db.Builder.
+		Select(teamColumns...).
+		From("teams").
+		Where("sport = ? AND team_id = ?", sport, teamID)
+
+	sqlStr, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	t, err := scanTeam(db.Conn.QueryRow(sqlStr, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+	return &t, nil
+}
+
+// GetTeamLeagues returns the leagues a team has matches in, ordered by name
+func (db *DB) GetTeamLeagues(sport string, teamRef int64) ([]League, error) {
+	table, ok := matchTables[sport]
+	if !ok {
+		return nil, fmt.Errorf("unsupported sport: %s", sport)
+	}
+
+	query := db.Builder.
+		Select(prefixColumns("l", leagueColumns)...).
+		From("leagues l").
+		Where(fmt.Sprintf("EXISTS (SELECT 1 FROM %s m WHERE m.league_ref = l.id AND ? IN (m.h_team_ref, m.a_team_ref))", table), teamRef).
+		OrderBy("l.name ASC", "l.league_id ASC")
+
+	sqlStr, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.Conn.Query(sqlStr, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var leagues []League
+	for rows.Next() {
+		l, err := scanLeague(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
 		leagues = append(leagues, l)
 	}
 
-	return leagues, nil
+	return leagues, rows.Err()
+}
+
+// ============================================================================
+// Result Queries (for API)
+// ============================================================================
+
+// dateColumns names the match day column of each sport, the feed day seasons are named from
+var dateColumns = map[string]string{
+	"soccer":     "match_start_date",
+	"basketball": "match_date",
+}
+
+// scoreColumns names the home and away final score columns of each sport
+var scoreColumns = map[string][2]string{
+	"soccer":     {"h_team_goals", "a_team_goals"},
+	"basketball": {"h_team_score", "a_team_score"},
+}
+
+// resultColumns lists the MatchResult columns of sport's match table, in scanResult order
+func resultColumns(sport string) []string {
+	scores := scoreColumns[sport]
+	return []string{
+		"match_id", "kickoff_at",
+		"h_team_id", "COALESCE(h_team_name, '')", "a_team_id", "COALESCE(a_team_name, '')",
+		scores[0], scores[1],
+		"COALESCE(league_id, 0)", "COALESCE(league_name, '')",
+	}
+}
+
+func scanResult(row rowScanner) (MatchResult, error) {
+	var m MatchResult
+	err := row.Scan(&m.MatchID, &m.KickoffAt,
+		&m.HomeTeamID, &m.HomeTeamName, &m.AwayTeamID, &m.AwayTeamName,
+		&m.HomeScore, &m.AwayScore,
+		&m.LeagueID, &m.LeagueName)
+	return m, err
+}
+
+// GetSeasonResults returns the finished matches of a season that kicked off
+// before before (zero for no bound), oldest first
+func (db *DB) GetSeasonResults(sport string, seasonRef int64, before time.Time) ([]MatchResult, error) {
+	table, ok := matchTables[sport]
+	if !ok {
+		return nil, fmt.Errorf("unsupported sport: %s", sport)
+	}
+	scores := scoreColumns[sport]
+
+	query := db.Builder.
+		Select(resultColumns(sport)...).
+		From(table).
+		Where("season_ref = ? AND status = ?", seasonRef, StatusFinished).
+		Where(sq.NotEq{"h_team_id": nil, "a_team_id": nil, scores[0]: nil, scores[1]: nil, "kickoff_at": nil}).
+		OrderBy("kickoff_at ASC", "match_id ASC")
+	if !before.IsZero() {
+		query = query.Where("kickoff_at < ?", before)
+	}
+
+	return db.queryResults(query)
+}
+
+// GetMeetings returns the finished matches between two teams, given by
+// Goalserve team ID, with either side at home, latest first
+func (db *DB) GetMeetings(sport string, teamA, teamB int64) ([]MatchResult, error) {
+	table, ok := matchTables[sport]
+	if !ok {
+		return nil, fmt.Errorf("unsupported sport: %s", sport)
+	}
+	scores := scoreColumns[sport]
+
+	query := db.Builder.
+		Select(resultColumns(sport)...).
+		From(table).
+		Where("status = ?", StatusFinished).
+		Where(sq.Or{
+			sq.Eq{"h_team_id": teamA, "a_team_id": teamB},
+			sq.Eq{"h_team_id": teamB, "a_team_id": teamA},
+		}).
+		Where(sq.NotEq{scores[0]: nil, scores[1]: nil, "kickoff_at": nil}).
+		OrderBy("kickoff_at DESC", "match_id DESC")
+
+	return db.queryResults(query)
+}
+
+// GetTeamResults returns up to limit finished matches of a team, given by its
+// teams row ID, latest first. leagueID, when set, keeps one Goalserve league.
+func (db *DB) GetTeamResults(sport string, teamRef int64, leagueID *int64, limit int) ([]MatchResult, error) {
+	table, ok := matchTables[sport]
+	if !ok {
+		return nil, fmt.Errorf("unsupported sport: %s", sport)
+	}
+	scores := scoreColumns[sport]
+
+	query := db.Builder.
+		Select(resultColumns(sport)...).
+		From(table).
+		Where("status = ?", StatusFinished).
+		Where("(h_team_ref = ? OR a_team_ref = ?)", teamRef, teamRef).
+		Where(sq.NotEq{"h_team_id": nil, "a_team_id": nil, scores[0]: nil, scores[1]: nil, "kickoff_at": nil}).
+		OrderBy("kickoff_at DESC", "match_id DESC").
+		Limit(uint64(limit))
+	if leagueID != nil {
+		query = query.Where("league_id = ?", *leagueID)
+	}
+
+	return db.queryResults(query)
+}
+
+// queryResults runs a select of resultColumns and scans every row
+func (db *DB) queryResults(query sq.SelectBuilder) ([]MatchResult, error) {
+	sqlStr, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.Conn.Query(sqlStr, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var results []MatchResult
+	for rows.Next() {
+		m, err := scanResult(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		results = append(results, m)
+	}
+
+	return results, rows.Err()
+}
+
+// GetBasketballQuarterLines returns the finished basketball matches with
+// every quarter scored for both teams, oldest first
+func (db *DB) GetBasketballQuarterLines(params QuarterParams) ([]QuarterLine, error) {
+	query := db.Builder.
+		Select("match_id", "kickoff_at", "h_team_id", "a_team_id",
+			"h_team_q1", "h_team_q2", "h_team_q3", "h_team_q4", "COALESCE(h_team_ot, 0)",
+			"a_team_q1", "a_team_q2", "a_team_q3", "a_team_q4", "COALESCE(a_team_ot, 0)").
+		From("basketball_matches").
+		Where("status = ?", StatusFinished).
+		Where(sq.NotEq{
+			"kickoff_at": nil, "h_team_id": nil, "a_team_id": nil,
+			"h_team_q1": nil, "h_team_q2": nil, "h_team_q3": nil, "h_team_q4": nil,
+			"a_team_q1": nil, "a_team_q2": nil, "a_team_q3": nil, "a_team_q4": nil,
+		}).
+		OrderBy("kickoff_at ASC", "match_id ASC")
+	if params.TeamRef != nil {
+		query = query.Where("(h_team_ref = ? OR a_team_ref = ?)", *params.TeamRef, *params.TeamRef)
+	}
+	if params.LeagueID != nil {
+		query = query.Where("league_id = ?", *params.LeagueID)
+	}
+	if !params.From.IsZero() {
+		query = query.Where("kickoff_at >= ?", params.From)
+	}
+	if !params.To.IsZero() {
+		query = query.Where("kickoff_at < ?", params.To)
+	}
+
+	sqlStr, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.Conn.Query(sqlStr, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []QuarterLine
+	for rows.Next() {
+		var l QuarterLine
+		err := rows.Scan(&l.MatchID, &l.KickoffAt, &l.HomeTeamID, &l.AwayTeamID,
+			&l.Home[0], &l.Home[1], &l.Home[2], &l.Home[3], &l.HomeOT,
+			&l.Away[0], &l.Away[1], &l.Away[2], &l.Away[3], &l.AwayOT)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		lines = append(lines, l)
+	}
+
+	return lines, rows.Err()
+}
+
+// ============================================================================
+// Head-to-Head Cache Queries
+// ============================================================================
+
+// h2hKey orders a team pair the way h2h_cache stores it, lower ID first
+func h2hKey(teamA, teamB int64) (int64, int64) {
+	return min(teamA, teamB), max(teamA, teamB)
+}
+
+// GetH2HCache returns the cached Goalserve meetings of a team pair in either
+// order, or nil when none are cached
+func (db *DB) GetH2HCache(sport string, teamA, teamB int64) (*H2HCache, error) {
+	a, b := h2hKey(teamA, teamB)
+	query := db.Builder.
+		Select("sport", "team_a", "team_b", "meetings", "fetched_at").
+		From("h2h_cache").
+		Where("sport = ? AND team_a = ? AND team_b = ?", sport, a, b)
+
+	sqlStr, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var c H2HCache
+	var meetings []byte
+	err = db.Conn.QueryRow(sqlStr, args...).Scan(&c.Sport, &c.TeamA, &c.TeamB, &meetings, &c.FetchedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get h2h cache: %w", err)
+	}
+	if err := json.Unmarshal(meetings, &c.Meetings); err != nil {
+		return nil, fmt.Errorf("failed to decode cached meetings: %w", err)
+	}
+
+	return &c, nil
+}
+
+// SaveH2HCache stores the Goalserve meetings of a team pair, replacing any
+// earlier entry
+func (db *DB) SaveH2HCache(sport string, teamA, teamB int64, meetings []MatchResult) error {
+	if meetings == nil {
+		meetings = []MatchResult{}
+	}
+	payload, err := json.Marshal(meetings)
+	if err != nil {
+		return fmt.Errorf("failed to encode meetings: %w", err)
+	}
+
+	a, b := h2hKey(teamA, teamB)
+	query := db.Builder.
+		Insert("h2h_cache").
+		Columns("sport", "team_a", "team_b", "meetings").
+		Values(sport, a, b, payload).
+		Suffix(`ON CONFLICT (sport, team_a, team_b) DO UPDATE SET
+			meetings = EXCLUDED.meetings,
+			fetched_at = now()`)
+
+	sqlStr, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	if _, err := db.Conn.Exec(sqlStr, args...); err != nil {
+		return fmt.Errorf("failed to save h2h cache: %w", err)
+	}
+	return nil
+}
+
+// RequestH2H queues a team pair for the sync worker to fetch. A pair already
+// queued keeps its place.
+func (db *DB) RequestH2H(sport string, teamA, teamB int64) error {
+	a, b := h2hKey(teamA, teamB)
+	query := db.Builder.
+		Insert("h2h_requests").
+		Columns("sport", "team_a", "team_b").
+		Values(sport, a, b).
+		Suffix("ON CONFLICT (sport, team_a, team_b) DO NOTHING")
+
+	sqlStr, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	if _, err := db.Conn.Exec(sqlStr, args...); err != nil {
+		return fmt.Errorf("failed to request h2h: %w", err)
+	}
+	return nil
+}
+
+// GetH2HRequests returns up to limit queued team pairs, oldest first
+func (db *DB) GetH2HRequests(limit int) ([]H2HRequest, error) {
+	query := db.Builder.
+		Select("sport", "team_a", "team_b", "requested_at").
+		From("h2h_requests").
+		OrderBy("requested_at ASC", "id ASC").
+		Limit(uint64(limit))
+
+	sqlStr, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.Conn.Query(sqlStr, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var requests []H2HRequest
+	for rows.Next() {
+		var r H2HRequest
+		if err := rows.Scan(&r.Sport, &r.TeamA, &r.TeamB, &r.RequestedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		requests = append(requests, r)
+	}
+
+	return requests, rows.Err()
+}
+
+// DeleteH2HRequest removes a team pair from the queue
+func (db *DB) DeleteH2HRequest(sport string, teamA, teamB int64) error {
+	a, b := h2hKey(teamA, teamB)
+	query := db.Builder.
+		Delete("h2h_requests").
+		Where("sport = ? AND team_a = ? AND team_b = ?", sport, a, b)
+
+	sqlStr, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	if _, err := db.Conn.Exec(sqlStr, args...); err != nil {
+		return fmt.Errorf("failed to delete h2h request: %w", err)
+	}
+	return nil
+}
+
+// ============================================================================
+// Rating Queries
+// ============================================================================
+
+// ratableMatch keeps the matches of sport's table, aliased m, that can be
+// rated: finished, with a kickoff, a final score and two different teams
+func ratableMatch(sport string) sq.And {
+	scores := scoreColumns[sport]
+	return sq.And{
+		sq.Eq{"m.status": StatusFinished},
+		sq.NotEq{"m.kickoff_at": nil, "m.h_team_ref": nil, "m.a_team_ref": nil, "m." + scores[0]: nil, "m." + scores[1]: nil},
+		sq.Expr("m.h_team_ref <> m.a_team_ref"),
+	}
+}
+
+// earliestRatableKickoff returns the kickoff of the earliest ratable match of
+// sport that also matches cond, or nil when there is none
+func (db *DB) earliestRatableKickoff(sport string, cond sq.Sqlizer) (*time.Time, error) {
+	table, ok := matchTables[sport]
+	if !ok {
+		return nil, fmt.Errorf("unsupported sport: %s", sport)
+	}
+
+	query := db.Builder.
+		Select("MIN(m.kickoff_at)").
+		From(table + " m").
+		Where(ratableMatch(sport)).
+		Where(cond)
+
+	sqlStr, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var kickoff sql.NullTime
+	if err := db.Conn.QueryRow(sqlStr, args...).Scan(&kickoff); err != nil {
+		return nil, fmt.Errorf("failed to find matches to rate: %w", err)
+	}
+	if !kickoff.Valid {
+		return nil, nil
+	}
+	return &kickoff.Time, nil
+}
+
+// GetEarliestUnratedKickoff returns the kickoff of the earliest finished match
+// of sport that can be rated but has no team_ratings rows, or nil when every
+// such match is rated
+func (db *DB) GetEarliestUnratedKickoff(sport string) (*time.Time, error) {
+	return db.earliestRatableKickoff(sport, sq.Expr(`NOT EXISTS (SELECT 1 FROM team_ratings r
+		WHERE r.sport = ? AND r.team_ref = m.h_team_ref AND r.match_id = m.match_id)`, sport))
+}
+
+// GetEarliestRescoredKickoff returns the kickoff of the earliest rated match of
+// sport whose final score changed in match_changes after it was rated, or nil
+// when no rated score changed
+func (db *DB) GetEarliestRescoredKickoff(sport string) (*time.Time, error) {
+	scores := scoreColumns[sport]
+	return db.earliestRatableKickoff(sport, sq.Expr(`EXISTS (SELECT 1 FROM team_ratings r
+		JOIN match_changes c ON c.sport = r.sport AND c.match_id = r.match_id
+		WHERE r.sport = ? AND r.team_ref = m.h_team_ref AND r.match_id = m.match_id
+			AND c.field = ANY(?) AND c.changed_at > r.created_at)`, sport, pq.Array(scores[:])))
+}
+
+// GetRatingStates returns every team's latest rating of sport from matches
+// kicking off before before, keyed by teams row ID
+func (db *DB) GetRatingStates(sport string, before time.Time) (map[int64]TeamRating, error) {
+	query := db.Builder.
+		Select("sport", "team_ref", "opponent_ref", "match_id", "kickoff_at", "COALESCE(season, '')",
+			"home", "rating_before", "rating").
+		Options("DISTINCT ON (team_ref)").
+		From("team_ratings").
+		Where("sport = ? AND kickoff_at < ?", sport, before).
+		OrderBy("team_ref", "kickoff_at DESC", "match_id DESC")
+
+	sqlStr, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.Conn.Query(sqlStr, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	states := make(map[int64]TeamRating)
+	for rows.Next() {
+		var r TeamRating
+		err := rows.Scan(&r.Sport, &r.TeamRef, &r.OpponentRef, &r.MatchID, &r.KickoffAt, &r.Season,
+			&r.Home, &r.RatingBefore, &r.Rating)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		states[r.TeamRef] = r
+	}
+
+	return states, rows.Err()
+}
+
+// GetRatingMatches returns the finished matches of sport that can be rated,
+// kicking off at or after from, oldest first
+func (db *DB) GetRatingMatches(sport string, from time.Time) ([]RatingMatch, error) {
+	table, ok := matchTables[sport]
+	if !ok {
+		return nil, fmt.Errorf("unsupported sport: %s", sport)
+	}
+	scores := scoreColumns[sport]
+
+	query := db.Builder.
+		Select("m.match_id", "m.kickoff_at", "COALESCE(s.name, '')",
+			"m.h_team_ref", "m.a_team_ref", "m."+scores[0], "m."+scores[1]).
+		From(table+" m").
+		LeftJoin("seasons s ON s.id = m.season_ref").
+		Where(ratableMatch(sport)).
+		Where("m.kickoff_at >= ?", from).
+		OrderBy("m.kickoff_at ASC", "m.match_id ASC")
+
+	sqlStr, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.Conn.Query(sqlStr, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var matches []RatingMatch
+	for rows.Next() {
+		var m RatingMatch
+		err := rows.Scan(&m.MatchID, &m.KickoffAt, &m.Season, &m.HomeRef, &m.AwayRef, &m.HomeScore, &m.AwayScore)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		matches = append(matches, m)
+	}
+
+	return matches, rows.Err()
+}
+
+// ratingInsertBatch keeps each team_ratings insert well under Postgres' 65535 parameter limit
+const ratingInsertBatch = 1000
+
+// ReplaceRatings deletes the ratings of sport from matches kicking off at or
+// after from and inserts ratings in their place, in one transaction. Callers
+// rating the same sport at once are serialized.
+func (db *DB) ReplaceRatings(ctx context.Context, sport string, from time.Time, ratings []TeamRating) error {
+	return db.WithTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", "team_ratings/"+sport); err != nil {
+			return fmt.Errorf("failed to lock ratings: %w", err)
+		}
+
+		sqlStr, args, err := db.Builder.
+			Delete("team_ratings").
+			Where("sport = ? AND kickoff_at >= ?", sport, from).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build query: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, sqlStr, args...); err != nil {
+			return fmt.Errorf("failed to delete ratings: %w", err)
+		}
+
+		for batch := range slices.Chunk(ratings, ratingInsertBatch) {
+			query := db.Builder.
+				Insert("team_ratings").
+				Columns("sport", "team_ref", "opponent_ref", "match_id", "kickoff_at", "season",
+					"home", "rating_before", "rating")
+			for _, r := range batch {
+				query = query.Values(r.Sport, r.TeamRef, r.OpponentRef, r.MatchID, r.KickoffAt,
+					sql.NullString{String: r.Season, Valid: r.Season != ""}, r.Home, r.RatingBefore, r.Rating)
+			}
+
+			sqlStr, args, err := query.ToSql()
+			if err != nil {
+				return fmt.Errorf("failed to build query: %w", err)
+			}
+			if _, err := tx.ExecContext(ctx, sqlStr, args...); err != nil {
+				return fmt.Errorf("failed to insert ratings: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// currentRatings selects every rated team's latest rating of sport with the
+// number of matches rated. Its placeholders are left as ? for embedding.
+func currentRatings(sport string) sq.SelectBuilder {
+	return sq.Select("team_ref", "rating", "kickoff_at",
+		"COUNT(*) OVER (PARTITION BY team_ref) AS played").
+		Options("DISTINCT ON (team_ref)").
+		From("team_ratings").
+		Where("sport = ?", sport).
+		OrderBy("team_ref", "kickoff_at DESC", "match_id DESC")
+}
+
+// GetRatings returns the current ratings of sport, best first, ranked among
+// the teams the filters keep
+func (db *DB) GetRatings(sport string, params RatingParams) ([]RatedTeam, int, error) {
+	table, ok := matchTables[sport]
+	if !ok {
+		return nil, 0, fmt.Errorf("unsupported sport: %s", sport)
+	}
+
+	var filters sq.And
+	if params.LeagueID != nil {
+		filters = append(filters, sq.Expr(fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM %s m JOIN leagues l ON l.id = m.league_ref
+			WHERE l.league_id = ? AND t.id IN (m.h_team_ref, m.a_team_ref))`, table), *params.LeagueID))
+	}
+
+	countQuery := db.Builder.
+		Select("COUNT(*)").
+		FromSelect(currentRatings(sport), "r").
+		Join("teams t ON t.id = r.team_ref").
+		Where(filters)
+
+	countSQL, countArgs, err := countQuery.ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to build count query: %w", err)
+	}
+
+	var total int
+	if err := db.Conn.QueryRow(countSQL, countArgs...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count ratings: %w", err)
+	}
+
+	query := db.Builder.
+		Select("RANK() OVER (ORDER BY r.rating DESC)", "t.team_id", "t.name", "r.rating", "r.played", "r.kickoff_at").
+		FromSelect(currentRatings(sport), "r").
+		Join("teams t ON t.id = r.team_ref").
+		Where(filters).
+		OrderBy("r.rating DESC", "t.team_id ASC").
+		Limit(uint64(params.Limit)).
+		Offset(uint64(params.Offset))
+
+	sqlStr, args, err := query.ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.Conn.Query(sqlStr, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var ratings []RatedTeam
+	for rows.Next() {
+		var r RatedTeam
+		if err := rows.Scan(&r.Rank, &r.TeamID, &r.Name, &r.Rating, &r.Played, &r.LastMatchAt); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan row: %w", err)
+		}
+		ratings = append(ratings, r)
+	}
+
+	return ratings, total, rows.Err()
+}
+
+// GetTeamRatingHistory returns a team's ratings, given by its teams row ID,
+// latest first, with the total number rated
+func (db *DB) GetTeamRatingHistory(sport string, teamRef int64, limit, offset int) ([]TeamRating, int, error) {
+	countSQL, countArgs, err := db.Builder.
+		Select("COUNT(*)").
+		From("team_ratings").
+		Where("sport = ? AND team_ref = ?", sport, teamRef).
+		ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to build count query: %w", err)
+	}
+
+	var total int
+	if err := db.Conn.QueryRow(countSQL, countArgs...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count ratings: %w", err)
+	}
+
+	query := db.Builder.
+		Select("r.sport", "r.team_ref", "r.opponent_ref", "r.match_id", "r.kickoff_at", "COALESCE(r.season, '')",
+			"r.home", "r.rating_before", "r.rating", "o.team_id", "o.name").
+		From("team_ratings r").
+		Join("teams o ON o.id = r.opponent_ref").
+		Where("r.sport = ? AND r.team_ref = ?", sport, teamRef).
+		OrderBy("r.kickoff_at DESC", "r.match_id DESC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset))
+
+	sqlStr, args, err := query.ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.Conn.Query(sqlStr, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var history []TeamRating
+	for rows.Next() {
+		var r TeamRating
+		err := rows.Scan(&r.Sport, &r.TeamRef, &r.OpponentRef, &r.MatchID, &r.KickoffAt, &r.Season,
+			&r.Home, &r.RatingBefore, &r.Rating, &r.OpponentID, &r.OpponentName)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan row: %w", err)
+		}
+		history = append(history, r)
+	}
+
+	return history, total, rows.Err()
+}
+
+// ============================================================================
+// Prediction Queries
+// ============================================================================
+
+// GetLeagueResults returns the finished matches kicking off in [from, before)
+// in the given Goalserve leagues, or in any league when leagueIDs is empty,
+// oldest first
+func (db *DB) GetLeagueResults(sport string, leagueIDs []int64, from, before time.Time) ([]MatchResult, error) {
+	table, ok := matchTables[sport]
+	if !ok {
+		return nil, fmt.Errorf("unsupported sport: %s", sport)
+	}
+	scores := scoreColumns[sport]
+
+	query := db.Builder.
+		Select(resultColumns(sport)...).
+		From(table).
+		Where("status = ? AND kickoff_at >= ? AND kickoff_at < ?", StatusFinished, from, before).
+		Where(sq.NotEq{"league_id": nil, "h_team_id": nil, "a_team_id": nil, scores[0]: nil, scores[1]: nil}).
+		Where("h_team_id <> a_team_id").
+		OrderBy("kickoff_at ASC", "match_id ASC")
+	if len(leagueIDs) > 0 {
+		query = query.Where("league_id = ANY(?)", pq.Array(leagueIDs))
+	}
+
+	return db.queryResults(query)
+}
+
+// GetScheduledFixtures returns the scheduled matches among matchIDs with both
+// teams, league and kickoff known
+func (db *DB) GetScheduledFixtures(sport string, matchIDs []int64) ([]Fixture, error) {
+	table, ok := matchTables[sport]
+	if !ok {
+		return nil, fmt.Errorf("unsupported sport: %s", sport)
+	}
+	if len(matchIDs) == 0 {
+		return nil, nil
+	}
+
+	query := db.Builder.
+		Select("m.match_id", "m.kickoff_at", "m.league_id", "m.h_team_id", "m.a_team_id").
+		From(table+" m").
+		Where("m.match_id = ANY(?) AND m.status = ?", pq.Array(matchIDs), StatusScheduled).
+		Where(sq.NotEq{"m.kickoff_at": nil, "m.league_id": nil, "m.h_team_id": nil, "m.a_team_id": nil})
+
+	sqlStr, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.Conn.Query(sqlStr, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var fixtures []Fixture
+	for rows.Next() {
+		var f Fixture
+		if err := rows.Scan(&f.MatchID, &f.KickoffAt, &f.LeagueID, &f.HomeTeamID, &f.AwayTeamID); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		fixtures = append(fixtures, f)
+	}
+
+	return fixtures, rows.Err()
+}
+
+// GetLatestRatings returns the latest rating of each of teamIDs that has one,
+// keyed by Goalserve team ID
+func (db *DB) GetLatestRatings(sport string, teamIDs []int64) (map[int64]TeamRating, error) {
+	latest := make(map[int64]TeamRating)
+	if len(teamIDs) == 0 {
+		return latest, nil
+	}
+
+	query := db.Builder.
+		Select("t.team_id", "r.sport", "r.team_ref", "r.opponent_ref", "r.match_id", "r.kickoff_at",
+			"COALESCE(r.season, '')", "r.home", "r.rating_before", "r.rating").
+		Options("DISTINCT ON (r.team_ref)").
+		From("team_ratings r").
+		Join("teams t ON t.id = r.team_ref").
+		Where("r.sport = ? AND t.team_id = ANY(?)", sport, pq.Array(teamIDs)).
+		OrderBy("r.team_ref", "r.kickoff_at DESC", "r.match_id DESC")
+
+	sqlStr, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.Conn.Query(sqlStr, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var teamID int64
+		var r TeamRating
+		err := rows.Scan(&teamID, &r.Sport, &r.TeamRef, &r.OpponentRef, &r.MatchID, &r.KickoffAt, &r.Season,
+			&r.Home, &r.RatingBefore, &r.Rating)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		latest[teamID] = r
+	}
+
+	return latest, rows.Err()
+}
+
+// ============================================================================
+// Match Change History Queries (for API)
+// ============================================================================
+
+// GetMatchChanges returns the recorded field changes of a match, newest first
+func (db *DB) GetMatchChanges(sport string, matchID int64, params QueryParams) ([]MatchChange, int, error) {
+	countQuery := db.Builder.
+		Select("COUNT(*)").
+		From("match_changes").
+		Where("sport = ? AND match_id = ?", sport, matchID)
+
+	countSQL, countArgs, err := countQuery.ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to build count query: %w", err)
+	}
+
+	var total int
+	if err := db.Conn.QueryRow(countSQL, countArgs...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count match changes: %w", err)
+	}
+
+	query := db.Builder.
+		Select("id", "sport", "match_id", "field", "old_value", "new_value", "changed_at", "sync_run_id").
+		From("match_changes").
+		Where("sport = ? AND match_id = ?", sport, matchID).
+		OrderBy("changed_at DESC", "id DESC").
+		Limit(uint64(params.Limit)).
+		Offset(uint64(params.Offset))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.Conn.Query(sql, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []MatchChange
+	for rows.Next() {
+		var c MatchChange
+		err := rows.Scan(
+			&c.ID, &c.Sport, &c.MatchID, &c.Field,
+			&c.OldValue, &c.NewValue, &c.ChangedAt, &c.SyncRunID,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan row: %w", err)
+		}
+		changes = append(changes, c)
+	}
+
+	return changes, total, nil
+}
+
+// ============================================================================
+// Soccer Match Event Queries (for API)
+// ============================================================================
+
+// GetSoccerMatchEvents returns the events of the given matches keyed by match ID,
+// each list in match order
+func (db *DB) GetSoccerMatchEvents(matchIDs []int64) (map[int64][]SoccerMatchEvent, error) {
+	events := make(map[int64][]SoccerMatchEvent)
+	if len(matchIDs) == 0 {
+		return events, nil
+	}
+
+	query := db.Builder.
+		Select("id", "match_id", "event_key", "sequence", "type", "team_side",
+			"player", "player_id", "minute", "extra_minute", "result").
+		From("soccer_match_events").
+		Where("match_id = ANY(?)", pq.Array(matchIDs)).
+		OrderBy("match_id", "sequence", "id")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.Conn.Query(sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e SoccerMatchEvent
+		err := rows.Scan(
+			&e.ID, &e.MatchID, &e.EventKey, &e.Sequence, &e.Type, &e.TeamSide,
+			&e.Player, &e.PlayerID, &e.Minute, &e.ExtraMinute, &e.Result,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		events[e.MatchID] = append(events[e.MatchID], e)
+	}
+
+	return events, rows.Err()
+}
+
+// ============================================================================
+// Sync Scheduling Queries
+// ============================================================================
+
+// matchTables maps a sport to its match table
+var matchTables = map[string]string{
+	"soccer":     "soccer_matches",
+	"basketball": "basketball_matches",
+}
+
+// staleInPlayAfter bounds how long after kickoff an in-play row still counts as
+// active, so a match that dropped out of the feed mid-game doesn't keep sync hot
+const staleInPlayAfter = 6 * time.Hour
+
+// CountActiveMatches returns how many matches of a sport are in play, or still
+// scheduled with a kickoff within window of now (about to start, or late to start)
+func (db *DB) CountActiveMatches(sport string, window time.Duration) (int, error) {
+	table, ok := matchTables[sport]
+	if !ok {
+		return 0, fmt.Errorf("unsupported sport: %s", sport)
+	}
+
+	now := time.Now()
+	query := db.Builder.
+		Select("COUNT(*)").
+		From(table).
+		Where(sq.Or{
+			sq.And{
+				sq.Expr("status = ANY(?)", pq.Array(InPlayStatuses)),
+				sq.Expr("kickoff_at > ?", now.Add(-staleInPlayAfter)),
+			},
+			sq.And{
+				sq.Eq{"status": StatusScheduled},
+				sq.Expr("kickoff_at BETWEEN ? AND ?", now.Add(-window), now.Add(window)),
+			},
+		})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var count int
+	if err := db.Conn.QueryRow(sql, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count active matches: %w", err)
+	}
+	return count, nil
+}
+
+// GetSyncLeader returns the current holder of a leadership lock, or nil when none is recorded
+func (db *DB) GetSyncLeader(name string) (*SyncLeader, error) {
+	query := db.Builder.
+		Select("name", "instance_id", "hostname", "pid", "backend_pid", "acquired_at", "heartbeat_at",
+			"EXTRACT(EPOCH FROM (now()::timestamp - heartbeat_at))").
+		From("sync_leader").
+		Where("name = ?", name)
+
+	sqlStr, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var l SyncLeader
+	var ageSeconds float64
+	err = db.Conn.QueryRow(sqlStr, args...).Scan(
+		&l.Name, &l.InstanceID, &l.Hostname, &l.PID, &l.BackendPID,
+		&l.AcquiredAt, &l.HeartbeatAt, &ageSeconds,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sync leader: %w", err)
+	}
+	l.HeartbeatAge = time.Duration(ageSeconds * float64(time.Second))
+
+	return &l, nil
+}
+
+// ============================================================================
+// Sync Run Queries
+// ============================================================================
+
+// syncRunColumns lists the sync_runs columns in scanSyncRun order
+var syncRunColumns = []string{
+	"id", "sport", "feed", "status", "started_at", "finished_at", "http_status",
+	"inserted", "updated", "unchanged", "failed", "failures", "error",
+}
+
+// scanSyncRun scans one row selected with syncRunColumns
+func scanSyncRun(row rowScanner) (SyncRun, error) {
+	var r SyncRun
+	var failures []byte
+	err := row.Scan(
+		&r.ID, &r.Sport, &r.Feed, &r.Status, &r.StartedAt, &r.FinishedAt, &r.HTTPStatus,
+		&r.Inserted, &r.Updated, &r.Unchanged, &r.Failed, &failures, &r.Error,
+	)
+	if err != nil {
+		return r, err
+	}
+
+	if err := json.Unmarshal(failures, &r.Failures); err != nil {
+		return r, fmt.Errorf("failed to unmarshal failures: %w", err)
+	}
+	return r, nil
+}
+
+// GetSyncRuns retrieves sync runs with filtering, newest first
+func (db *DB) GetSyncRuns(params SyncRunParams) ([]SyncRun, int, error) {
+	baseQuery := db.Builder.
+		Select(syncRunColumns...).
+		From("sync_runs")
+
+	countQuery := db.Builder.
+		Select("COUNT(*)").
+		From("sync_runs")
+
+	// Apply filters
+	filters := sq.Eq{}
+	if params.Sport != "" {
+		filters["sport"] = params.Sport
+	}
+	if params.Feed != "" {
+		filters["feed"] = params.Feed
+	}
+	if params.Status != "" {
+		filters["status"] = params.Status
+	}
+	baseQuery = baseQuery.Where(filters)
+	countQuery = countQuery.Where(filters)
+
+	// Get total count
+	countSQL, countArgs, err := countQuery.ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to build count query: %w", err)
+	}
+
+	var total int
+	if err := db.Conn.QueryRow(countSQL, countArgs...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count sync runs: %w", err)
+	}
+
+	baseQuery = baseQuery.
+		OrderBy("started_at DESC").
+		Limit(uint64(params.Limit)).
+		Offset(uint64(params.Offset))
+
+	sqlStr, args, err := baseQuery.ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.Conn.Query(sqlStr, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []SyncRun
+	for rows.Next() {
+		r, err := scanSyncRun(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan row: %w", err)
+		}
+		runs = append(runs, r)
+	}
+
+	return runs, total, rows.Err()
+}
+
+// GetLatestSyncRuns returns the most recent run of every sport and feed,
+// only considering runs with the given status unless it is empty
+func (db *DB) GetLatestSyncRuns(status string) ([]SyncRun, error) {
+	query := db.Builder.
+		Select(syncRunColumns...).
+		Options("DISTINCT ON (sport, feed)").
+		From("sync_runs").
+		OrderBy("sport", "feed", "started_at DESC")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	sqlStr, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.Conn.Query(sqlStr, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []SyncRun
+	for rows.Next() {
+		r, err := scanSyncRun(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		runs = append(runs, r)
+	}
+
+	return runs, rows.Err()
+}
+
+// ============================================================================
+// Sync Dead Letter Queries
+// ============================================================================
+
+// GetDeadLetters retrieves the unresolved dead letters of a sport, oldest first
+func (db *DB) GetDeadLetters(sport string, limit int) ([]SyncDeadLetter, error) {
+	query := db.Builder.
+		Select("id", "sport", "match_key", "payload", "error", "attempts", "sync_run_id",
+			"first_failed_at", "last_failed_at", "resolved_at").
+		From("sync_dead_letters").
+		Where("sport = ? AND resolved_at IS NULL", sport).
+		OrderBy("first_failed_at", "id").
+		Limit(uint64(limit))
+
+	sqlStr, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.Conn.Query(sqlStr, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var letters []SyncDeadLetter
+	for rows.Next() {
+		var l SyncDeadLetter
+		err := rows.Scan(
+			&l.ID, &l.Sport, &l.MatchKey, &l.Payload, &l.Error, &l.Attempts, &l.SyncRunID,
+			&l.FirstFailedAt, &l.LastFailedAt, &l.ResolvedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		letters = append(letters, l)
+	}
+
+	return letters, rows.Err()
+}
+
+// CountDeadLetters returns the number of unresolved dead letters per sport
+func (db *DB) CountDeadLetters() (map[string]int, error) {
+	query := db.Builder.
+		Select("sport", "COUNT(*)").
+		From("sync_dead_letters").
+		Where("resolved_at IS NULL").
+		GroupBy("sport")
+
+	sqlStr, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.Conn.Query(sqlStr, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var sport string
+		var count int
+		if err := rows.Scan(&sport, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		counts[sport] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// ============================================================================
+// Raw Feed Queries
+// ============================================================================
+
+// GetRawFeeds lists the archived feeds matching params, oldest first, without their bodies
+func (db *DB) GetRawFeeds(params RawFeedParams) ([]RawFeed, error) {
+	query := db.Builder.
+		Select("id", "sport", "feed", "fetched_at", "sha256", "size").
+		From("raw_feeds").
+		Where("fetched_at >= ?", params.Since).
+		OrderBy("fetched_at", "id")
+
+	if params.Sport != "" {
+		query = query.Where(sq.Eq{"sport": params.Sport})
+	}
+	if params.Feed != "" {
+		query = query.Where(sq.Eq{"feed": params.Feed})
+	}
+	if !params.Until.IsZero() {
+		query = query.Where("fetched_at < ?", params.Until)
+	}
+
+	sqlStr, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.Conn.Query(sqlStr, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var feeds []RawFeed
+	for rows.Next() {
+		var f RawFeed
+		if err := rows.Scan(&f.ID, &f.Sport, &f.Feed, &f.FetchedAt, &f.SHA256, &f.Size); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		feeds = append(feeds, f)
+	}
+
+	return feeds, rows.Err()
+}
+
+// GetRawFeedBody retrieves the gzipped payload of an archived feed
+func (db *DB) GetRawFeedBody(id int64) ([]byte, error) {
+	sqlStr, args, err := db.Builder.
+		Select("body").
+		From("raw_feeds").
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var body []byte
+	if err := db.Conn.QueryRow(sqlStr, args...).Scan(&body); err != nil {
+		return nil, fmt.Errorf("failed to get raw feed %d: %w", id, err)
+	}
+	return body, nil
 }
@@ -44,6 +44,11 @@ func (m *GoalServeBasketballMatchData) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalJSON writes the matches as the array Goalserve sends, so the output decodes again
+func (m GoalServeBasketballMatchData) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Matches)
+}
+
 // GoalServeBasketballMatch represents a basketball match from GoalServe
 type GoalServeBasketballMatch struct {
 	ID        string                  `json:"id"`
@@ -1,70 +1,117 @@
 package goalserve
 
 import (
-	"encoding/json"
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"math/rand/v2"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 )
 
 // Client represents the Goalserve API client
 type Client struct {
-	BaseURL     string
-	APIKey      string
-	HTTPClient  *http.Client
-	rateLimiter *time.Ticker
+	BaseURL    string
+	APIKey     string
+	HTTPClient *http.Client
+
+	// Retry policy for idempotent GETs
+	MaxRetries     int           // Retries after the first attempt
+	RetryBaseDelay time.Duration // Backoff before the first retry, doubled on each attempt
+	RetryMaxDelay  time.Duration // Upper bound for a single backoff or Retry-After wait
+
+	// Archiver, when set, receives every payload that decoded successfully
+	Archiver FeedArchiver
+
+	scheduler *Scheduler
+}
+
+// FeedArchiver stores raw feed payloads. feed is the RecordingKey of the
+// request and body the payload exactly as received, possibly gzipped.
+type FeedArchiver interface {
+	ArchiveFeed(ctx context.Context, feed string, fetchedAt time.Time, body []byte) error
 }
 
 // NewClient creates a new Goalserve API client
 func NewClient() *Client {
+	maxRetries, err := strconv.Atoi(getEnv("GOALSERVE_MAX_RETRIES", "3"))
+	if err != nil || maxRetries < 0 {
+		maxRetries = 3
+	}
+
 	return &Client{
 		BaseURL: getEnv("GOALSERVE_URL", "https://www.goalserve.com"),
 		APIKey:  getEnv("GOALSERVE_API_KEY", ""),
 		HTTPClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: transportFromEnv(),
 		},
-		rateLimiter: time.NewTicker(1 * time.Second), // 1 request per second
+		MaxRetries:     maxRetries,
+		RetryBaseDelay: 1 * time.Second,
+		RetryMaxDelay:  30 * time.Second,
+		scheduler:      SharedScheduler(), // One rate budget for every sport
 	}
 }
 
-// Close closes the client and releases resources
-func (c *Client) Close() {
-	if c.rateLimiter != nil {
-		c.rateLimiter.Stop()
+// transportFromEnv returns the HTTP transport selected by the environment:
+// GOALSERVE_REPLAY_DIR serves feeds from recordings without network access,
+// GOALSERVE_RECORD_DIR saves every live response for later replay
+func transportFromEnv() http.RoundTripper {
+	if dir := getEnv("GOALSERVE_REPLAY_DIR", ""); dir != "" {
+		mode := ReplayMode(getEnv("GOALSERVE_REPLAY_MODE", string(ReplayLatest)))
+		log.Printf("Replaying GoalServe feeds from %s (%s)", dir, mode)
+		return NewReplayTransport(dir, mode)
+	}
+	if dir := getEnv("GOALSERVE_RECORD_DIR", ""); dir != "" {
+		log.Printf("Recording GoalServe feeds to %s", dir)
+		return NewRecordingTransport(dir, http.DefaultTransport)
 	}
+	return http.DefaultTransport
+}
+
+// Close releases client resources. The shared scheduler outlives individual
+// clients and is intentionally left running.
+func (c *Client) Close() {
+	c.HTTPClient.CloseIdleConnections()
 }
 
 // FetchSoccerTodayMatches fetches today's soccer matches from Goalserve API
-func (c *Client) FetchSoccerTodayMatches() (*GoalServeSoccerScores, error) {
-	// Wait for rate limiter
-	<-c.rateLimiter.C
+func (c *Client) FetchSoccerTodayMatches(ctx context.Context) (*GoalServeSoccerScores, error) {
+	ctx = withDefaultPriority(ctx, PriorityHigh)
 
 	url := fmt.Sprintf("%s/getfeed/%s/soccernew/home?json=1", c.BaseURL, c.APIKey)
 
 	log.Printf("Fetching matches from GoalServe: %s", url)
 
-	return c.fetchSoccerMatchesFromURL(url)
+	return c.fetchSoccerMatchesFromURL(ctx, url)
 }
 
 // FetchSoccerMatchesPast7Days fetches soccer matches for the past 7 days
-func (c *Client) FetchSoccerMatchesPast7Days() (*GoalServeSoccerScores, error) {
+func (c *Client) FetchSoccerMatchesPast7Days(ctx context.Context) (*GoalServeSoccerScores, error) {
+	ctx = withDefaultPriority(ctx, PriorityLow)
+
 	var allScores GoalServeSoccerScores
 	allScores.Categories = make([]GoalServeSoccerCategory, 0)
 
 	// Fetch matches for past 7 days (d-1 to d-7)
 	for day := 1; day <= 7; day++ {
-		// Wait for rate limiter
-		<-c.rateLimiter.C
-
 		url := fmt.Sprintf("%s/getfeed/%s/soccernew/d-%d?json=1", c.BaseURL, c.APIKey, day)
 
 		log.Printf("Fetching past matches from GoalServe (day %d): %s", day, url)
 
-		scores, err := c.fetchSoccerMatchesFromURL(url)
+		scores, err := c.fetchSoccerMatchesFromURL(ctx, url)
 		if err != nil {
+			if IsFatal(err) {
+				return nil, err
+			}
 			log.Printf("Failed to fetch matches for past day %d: %v", day, err)
 			continue // Continue with other days even if one fails
 		}
@@ -84,21 +131,23 @@ func (c *Client) FetchSoccerMatchesPast7Days() (*GoalServeSoccerScores, error) {
 }
 
 // FetchSoccerMatchesFuture7Days fetches soccer matches for the next 7 days
-func (c *Client) FetchSoccerMatchesFuture7Days() (*GoalServeSoccerScores, error) {
+func (c *Client) FetchSoccerMatchesFuture7Days(ctx context.Context) (*GoalServeSoccerScores, error) {
+	ctx = withDefaultPriority(ctx, PriorityLow)
+
 	var allScores GoalServeSoccerScores
 	allScores.Categories = make([]GoalServeSoccerCategory, 0)
 
 	// Fetch matches for next 7 days (d1 to d7)
 	for day := 1; day <= 7; day++ {
-		// Wait for rate limiter
-		<-c.rateLimiter.C
-
 		url := fmt.Sprintf("%s/getfeed/%s/soccernew/d%d?json=1", c.BaseURL, c.APIKey, day)
 
 		log.Printf("Fetching future matches from GoalServe (day %d): %s", day, url)
 
-		scores, err := c.fetchSoccerMatchesFromURL(url)
+		scores, err := c.fetchSoccerMatchesFromURL(ctx, url)
 		if err != nil {
+			if IsFatal(err) {
+				return nil, err
+			}
 			log.Printf("Failed to fetch matches for future day %d: %v", day, err)
 			continue // Continue with other days even if one fails
 		}
@@ -117,46 +166,28 @@ func (c *Client) FetchSoccerMatchesFuture7Days() (*GoalServeSoccerScores, error)
 	return &allScores, nil
 }
 
-// fetchSoccerMatchesFromURL is a helper function to fetch soccer matches from a specific URL
-func (c *Client) fetchSoccerMatchesFromURL(url string) (*GoalServeSoccerScores, error) {
-	resp, err := c.HTTPClient.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch matches: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
-	}
+// FetchSoccerMatchesForDate fetches soccer matches for a single feed day from its
+// d-N/dN feed, or the ?date= historical feed for a day before DayFeedWindow.
+// Later days fail with ErrNoDayFeed.
+func (c *Client) FetchSoccerMatchesForDate(ctx context.Context, date time.Time) (*GoalServeSoccerScores, error) {
+	ctx = withDefaultPriority(ctx, PriorityLow)
 
-	body, err := io.ReadAll(resp.Body)
+	feed, err := dayFeed(date, time.Now())
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
 	}
+	url := fmt.Sprintf("%s/getfeed/%s/soccernew/%s", c.BaseURL, c.APIKey, feed)
 
-	// Parse JSON response - the API response structure has scores at root level
-	var jsonResponse map[string]interface{}
-	if err := json.Unmarshal(body, &jsonResponse); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON response: %w", err)
-	}
-
-	// Extract the scores object from the response
-	scoresData, ok := jsonResponse["scores"]
-	if !ok {
-		return nil, fmt.Errorf("no scores field found in response")
-	}
+	log.Printf("Fetching soccer matches for %s from GoalServe: %s", date.Format("2006-01-02"), url)
 
-	// Convert scores data back to JSON for proper unmarshaling
-	scoresJSON, err := json.Marshal(scoresData)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal scores data: %w", err)
-	}
+	return c.fetchSoccerMatchesFromURL(ctx, url)
+}
 
-	// Unmarshal directly into GoalServeSoccerScores
+// fetchSoccerMatchesFromURL is a helper function to fetch soccer matches from a specific URL
+func (c *Client) fetchSoccerMatchesFromURL(ctx context.Context, url string) (*GoalServeSoccerScores, error) {
 	var scores GoalServeSoccerScores
-	if err := json.Unmarshal(scoresJSON, &scores); err != nil {
-		return nil, fmt.Errorf("failed to parse scores JSON: %w", err)
+	if err := c.fetchFeed(ctx, url, "scores", &scores); err != nil {
+		return nil, err
 	}
 
 	// Count total matches for logging
@@ -170,33 +201,34 @@ func (c *Client) fetchSoccerMatchesFromURL(url string) (*GoalServeSoccerScores,
 }
 
 // FetchBasketballTodayMatches fetches today's basketball matches from Goalserve API
-func (c *Client) FetchBasketballTodayMatches() (*GoalServeBasketballScores, error) {
-	// Wait for rate limiter
-	<-c.rateLimiter.C
+func (c *Client) FetchBasketballTodayMatches(ctx context.Context) (*GoalServeBasketballScores, error) {
+	ctx = withDefaultPriority(ctx, PriorityHigh)
 
 	url := fmt.Sprintf("%s/getfeed/%s/bsktbl/home?json=1", c.BaseURL, c.APIKey)
 
 	log.Printf("Fetching basketball matches from GoalServe: %s", url)
 
-	return c.fetchBasketballMatchesFromURL(url)
+	return c.fetchBasketballMatchesFromURL(ctx, url)
 }
 
 // FetchBasketballMatchesPast7Days fetches basketball matches for the past 7 days
-func (c *Client) FetchBasketballMatchesPast7Days() (*GoalServeBasketballScores, error) {
+func (c *Client) FetchBasketballMatchesPast7Days(ctx context.Context) (*GoalServeBasketballScores, error) {
+	ctx = withDefaultPriority(ctx, PriorityLow)
+
 	var allScores GoalServeBasketballScores
 	allScores.Categories = make([]GoalServeBasketballCategory, 0)
 
 	// Fetch matches for past 7 days (d-1 to d-7)
 	for day := 1; day <= 7; day++ {
-		// Wait for rate limiter
-		<-c.rateLimiter.C
-
 		url := fmt.Sprintf("%s/getfeed/%s/bsktbl/d-%d?json=1", c.BaseURL, c.APIKey, day)
 
 		log.Printf("Fetching past basketball matches from GoalServe (day %d): %s", day, url)
 
-		scores, err := c.fetchBasketballMatchesFromURL(url)
+		scores, err := c.fetchBasketballMatchesFromURL(ctx, url)
 		if err != nil {
+			if IsFatal(err) {
+				return nil, err
+			}
 			log.Printf("Failed to fetch basketball matches for past day %d: %v", day, err)
 			continue // Continue with other days even if one fails
 		}
@@ -216,21 +248,23 @@ func (c *Client) FetchBasketballMatchesPast7Days() (*GoalServeBasketballScores,
 }
 
 // FetchBasketballMatchesFuture7Days fetches basketball matches for the next 7 days
-func (c *Client) FetchBasketballMatchesFuture7Days() (*GoalServeBasketballScores, error) {
+func (c *Client) FetchBasketballMatchesFuture7Days(ctx context.Context) (*GoalServeBasketballScores, error) {
+	ctx = withDefaultPriority(ctx, PriorityLow)
+
 	var allScores GoalServeBasketballScores
 	allScores.Categories = make([]GoalServeBasketballCategory, 0)
 
 	// Fetch matches for next 7 days (d1 to d7)
 	for day := 1; day <= 7; day++ {
-		// Wait for rate limiter
-		<-c.rateLimiter.C
-
 		url := fmt.Sprintf("%s/getfeed/%s/bsktbl/d%d?json=1", c.BaseURL, c.APIKey, day)
 
 		log.Printf("Fetching future basketball matches from GoalServe (day %d): %s", day, url)
 
-		scores, err := c.fetchBasketballMatchesFromURL(url)
+		scores, err := c.fetchBasketballMatchesFromURL(ctx, url)
 		if err != nil {
+			if IsFatal(err) {
+				return nil, err
+			}
 			log.Printf("Failed to fetch basketball matches for future day %d: %v", day, err)
 			continue // Continue with other days even if one fails
 		}
@@ -249,56 +283,329 @@ func (c *Client) FetchBasketballMatchesFuture7Days() (*GoalServeBasketballScores
 	return &allScores, nil
 }
 
+// FetchBasketballMatchesForDate fetches basketball matches for a single feed day from
+// its d-N/dN feed, or the ?date= historical feed for a day before DayFeedWindow.
+// Later days fail with ErrNoDayFeed.
+func (c *Client) FetchBasketballMatchesForDate(ctx context.Context, date time.Time) (*GoalServeBasketballScores, error) {
+	ctx = withDefaultPriority(ctx, PriorityLow)
+
+	feed, err := dayFeed(date, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf("%s/getfeed/%s/bsktbl/%s", c.BaseURL, c.APIKey, feed)
+
+	log.Printf("Fetching basketball matches for %s from GoalServe: %s", date.Format("2006-01-02"), url)
+
+	return c.fetchBasketballMatchesFromURL(ctx, url)
+}
+
 // fetchBasketballMatchesFromURL is a helper function to fetch basketball matches from a specific URL
-func (c *Client) fetchBasketballMatchesFromURL(url string) (*GoalServeBasketballScores, error) {
-	resp, err := c.HTTPClient.Get(url)
+func (c *Client) fetchBasketballMatchesFromURL(ctx context.Context, url string) (*GoalServeBasketballScores, error) {
+	var scores GoalServeBasketballScores
+	if err := c.fetchFeed(ctx, url, "scores", &scores); err != nil {
+		return nil, err
+	}
+
+	// Count total matches for logging
+	var totalMatches int
+	for _, category := range scores.Categories {
+		totalMatches += len(category.Match.Matches)
+	}
+
+	log.Printf("Successfully fetched basketball matches: %d total", totalMatches)
+	return &scores, nil
+}
+
+// FetchSoccerH2H fetches the latest meetings between two soccer teams by Goalserve team ID
+func (c *Client) FetchSoccerH2H(ctx context.Context, team1, team2 int64) (*GoalServeH2H, error) {
+	ctx = withDefaultPriority(ctx, PriorityLow)
+
+	url := fmt.Sprintf("%s/getfeed/%s/h2h/%d/%d?json=1", c.BaseURL, c.APIKey, team1, team2)
+
+	log.Printf("Fetching soccer h2h from GoalServe: %s", url)
+
+	return c.fetchH2HFromURL(ctx, url)
+}
+
+// FetchBasketballH2H fetches the latest meetings between two basketball teams by Goalserve team ID
+func (c *Client) FetchBasketballH2H(ctx context.Context, team1, team2 int64) (*GoalServeH2H, error) {
+	ctx = withDefaultPriority(ctx, PriorityLow)
+
+	url := fmt.Sprintf("%s/getfeed/%s/bsktbl/h2h_%d-%d?json=1", c.BaseURL, c.APIKey, team1, team2)
+
+	log.Printf("Fetching basketball h2h from GoalServe: %s", url)
+
+	return c.fetchH2HFromURL(ctx, url)
+}
+
+// fetchH2HFromURL is a helper function to fetch an h2h feed from a specific URL
+func (c *Client) fetchH2HFromURL(ctx context.Context, url string) (*GoalServeH2H, error) {
+	var h2h GoalServeH2H
+	if err := c.fetchFeed(ctx, url, "h2h", &h2h); err != nil {
+		return nil, err
+	}
+
+	var totalMatches int
+	for _, category := range h2h.Top50.Categories {
+		totalMatches += len(category.Matches)
+	}
+
+	log.Printf("Successfully fetched h2h: %d meetings", totalMatches)
+	return &h2h, nil
+}
+
+// dayFeedReach is how many days before and after today the d-N/dN feeds
+// serve. Older days come from the ?date= historical feed; later days have none.
+const dayFeedReach = 7
+
+// DayFeedWindow returns the first and last feed day (see FeedDay) the day
+// feeds serve at now
+func DayFeedWindow(now time.Time) (first, last time.Time) {
+	today := FeedDay(now)
+	return today.AddDate(0, 0, -dayFeedReach), today.AddDate(0, 0, dayFeedReach)
+}
+
+// dayFeed returns the feed name and query for the calendar day of date, read
+// from its year, month and day, relative to the feed day of now
+func dayFeed(date, now time.Time) (string, error) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	today := FeedDay(now)
+	offset := int(day.Sub(today) / (24 * time.Hour))
+
+	switch {
+	case offset == 0:
+		return "home?json=1", nil
+	case offset >= -dayFeedReach && offset <= dayFeedReach:
+		return fmt.Sprintf("d%d?json=1", offset), nil // d-7 ... d7
+	case offset < -dayFeedReach:
+		return fmt.Sprintf("home?date=%s&json=1", day.Format("02.01.2006")), nil
+	}
+
+	_, last := DayFeedWindow(now)
+	return "", fmt.Errorf("%w for %s, the last day served is %s", ErrNoDayFeed,
+		day.Format("2006-01-02"), last.Format("2006-01-02"))
+}
+
+// fetchFeed downloads a feed and streams its root object named root into v
+func (c *Client) fetchFeed(ctx context.Context, url, root string, v any) error {
+	resp, err := c.get(ctx, url)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch basketball matches: %w", err)
+		return err
 	}
 	defer resp.Body.Close()
+	fetchedAt := time.Now()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+	body := io.Reader(resp.Body)
+	var raw *bytes.Buffer
+	if c.Archiver != nil {
+		raw = new(bytes.Buffer)
+		body = io.TeeReader(resp.Body, raw)
+	}
+
+	if err := decodeFeed(body, root, v); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// A connection dropped mid-body is an outage, not a bad payload
+		var netErr net.Error
+		if errors.As(err, &netErr) {
+			return &APIError{Kind: ErrUpstreamDown, Feed: c.feedPath(url), StatusCode: resp.StatusCode, Err: err}
+		}
+		return c.malformed(url, err)
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	if raw != nil {
+		c.archive(ctx, url, fetchedAt, body, raw)
+	}
+
+	return nil
+}
+
+// archive hands a decoded payload to the Archiver. The decoder stops after the
+// root object, so the rest of the body is read first to keep gzip streams
+// whole. Archiving is best effort and never fails the fetch.
+func (c *Client) archive(ctx context.Context, rawURL string, fetchedAt time.Time, body io.Reader, raw *bytes.Buffer) {
+	if _, err := io.Copy(io.Discard, body); err != nil {
+		log.Printf("Warning: not archiving GoalServe feed %s, failed to read the rest of the body: %v", c.feedPath(rawURL), err)
+		return
+	}
+
+	u, err := url.Parse(rawURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		log.Printf("Warning: not archiving GoalServe feed %s: %v", c.feedPath(rawURL), err)
+		return
 	}
 
-	// Parse JSON response - the API response structure has scores at root level
-	var jsonResponse map[string]interface{}
-	if err := json.Unmarshal(body, &jsonResponse); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON response: %w", err)
+	feed := RecordingKey(u)
+	if err := c.Archiver.ArchiveFeed(ctx, feed, fetchedAt, raw.Bytes()); err != nil {
+		log.Printf("Warning: failed to archive GoalServe feed %s: %v", feed, err)
 	}
+}
+
+// get performs a rate-limited GET with retries and returns a 200 response.
+// The caller must close the response body.
+// 5xx responses, timeouts and network errors are retried with jittered exponential
+// backoff; 429/503 responses honour the Retry-After header.
+func (c *Client) get(ctx context.Context, url string) (*http.Response, error) {
+	feed := c.feedPath(url)
+
+	var lastErr *APIError
+	for attempt := 0; attempt <= c.MaxRetries; attempt++ {
+		if attempt > 0 {
+			delay := c.backoff(attempt, lastErr.RetryAfter)
+			log.Printf("Retrying GoalServe feed %s in %s (attempt %d/%d): %v", feed, delay, attempt+1, c.MaxRetries+1, lastErr)
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		// Wait for the shared rate budget - every attempt counts against the quota
+		if err := c.scheduler.Wait(ctx, priorityFrom(ctx, PriorityNormal)); err != nil {
+			return nil, err
+		}
+
+		resp, err := c.doGet(ctx, url, feed)
+		if err == nil {
+			return resp, nil
+		}
 
-	// Extract the scores object from the response
-	scoresData, ok := jsonResponse["scores"]
-	if !ok {
-		return nil, fmt.Errorf("no scores field found in response")
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			return nil, err // Context cancellation or a non-retryable setup error
+		}
+		apiErr.Attempts = attempt + 1
+		lastErr = apiErr
+
+		if !apiErr.IsRetryable() {
+			return nil, apiErr
+		}
+		// Don't sleep through a Retry-After longer than we are willing to wait
+		if apiErr.RetryAfter > c.RetryMaxDelay {
+			return nil, apiErr
+		}
 	}
 
-	// Convert scores data back to JSON for proper unmarshaling
-	scoresJSON, err := json.Marshal(scoresData)
+	return nil, lastErr
+}
+
+// doGet performs a single GET attempt and classifies any failure
+func (c *Client) doGet(ctx context.Context, url, feed string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal scores data: %w", err)
+		return nil, fmt.Errorf("failed to build request: %w", err)
 	}
+	// Ask for gzip explicitly; decodeFeed inflates it while streaming
+	req.Header.Set("Accept-Encoding", "gzip")
 
-	// Unmarshal directly into GoalServeBasketballScores
-	var scores GoalServeBasketballScores
-	if err := json.Unmarshal(scoresJSON, &scores); err != nil {
-		return nil, fmt.Errorf("failed to parse basketball scores JSON: %w", err)
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		// Timeouts and connection failures are treated as upstream outages
+		return nil, &APIError{Kind: ErrUpstreamDown, Feed: feed, Err: err}
 	}
 
-	// Count total matches for logging
-	var totalMatches int
-	for _, category := range scores.Categories {
-		totalMatches += len(category.Match.Matches)
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, &APIError{
+			Kind:       classifyStatus(resp.StatusCode),
+			Feed:       feed,
+			StatusCode: resp.StatusCode,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+			Body:       strings.TrimSpace(string(body)),
+		}
 	}
 
-	log.Printf("Successfully fetched basketball matches: %d total", totalMatches)
-	return &scores, nil
+	return resp, nil
+}
+
+// backoff returns the delay before the given retry attempt. A server supplied
+// Retry-After wins; otherwise full jitter over an exponentially growing window.
+func (c *Client) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+
+	window := c.RetryBaseDelay << (attempt - 1)
+	if window <= 0 || window > c.RetryMaxDelay {
+		window = c.RetryMaxDelay
+	}
+	if window <= 0 {
+		return 0
+	}
+
+	return window/2 + rand.N(window/2+1)
+}
+
+// malformed wraps a decode failure as an ErrMalformedPayload API error
+func (c *Client) malformed(url string, err error) error {
+	return &APIError{Kind: ErrMalformedPayload, Feed: c.feedPath(url), StatusCode: http.StatusOK, Err: err}
+}
+
+// feedPath strips the base URL, API key and query string from a feed URL so it
+// can be logged or stored safely
+func (c *Client) feedPath(url string) string {
+	path := strings.TrimPrefix(url, c.BaseURL)
+	path = strings.TrimPrefix(path, "/getfeed/")
+	if c.APIKey != "" {
+		path = strings.TrimPrefix(path, c.APIKey)
+	}
+	path = strings.TrimPrefix(path, "/")
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	return path
+}
+
+// FeedSport returns the sport served by a feed path such as "soccernew/home",
+// or "" for an unknown feed
+func FeedSport(feed string) string {
+	switch {
+	case strings.HasPrefix(feed, "soccernew/"):
+		return "soccer"
+	case strings.HasPrefix(feed, "bsktbl/"):
+		return "basketball"
+	default:
+		return ""
+	}
+}
+
+// classifyStatus maps an HTTP status code to one of the sentinel errors
+func classifyStatus(status int) error {
+	switch {
+	case status == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return ErrAuthRejected
+	case status >= 500:
+		return ErrUpstreamDown
+	default:
+		return nil
+	}
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
 }
 
 // getEnv gets an environment variable with a fallback default value
@@ -0,0 +1,43 @@
+package goalserve
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDayFeed(t *testing.T) {
+	t.Setenv("GOALSERVE_TIMEZONE", "UTC")
+	now := time.Date(2026, time.December, 28, 12, 0, 0, 0, time.UTC)
+	day := func(offset int) time.Time {
+		return now.AddDate(0, 0, offset)
+	}
+
+	tests := []struct {
+		name    string
+		date    time.Time
+		want    string
+		wantErr error
+	}{
+		{"today", day(0), "home?json=1", nil},
+		{"today at midnight", time.Date(2026, time.December, 28, 0, 0, 0, 0, time.UTC), "home?json=1", nil},
+		{"yesterday", day(-1), "d-1?json=1", nil},
+		{"tomorrow", day(1), "d1?json=1", nil},
+		{"first day feed", day(-7), "d-7?json=1", nil},
+		{"last day feed, in the new year", day(7), "d7?json=1", nil},
+		{"before the day feeds", day(-8), "home?date=20.12.2026&json=1", nil},
+		{"a season ago", time.Date(2025, time.August, 9, 0, 0, 0, 0, time.UTC), "home?date=09.08.2025&json=1", nil},
+		{"after the day feeds", day(8), "", ErrNoDayFeed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := dayFeed(tt.date, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("dayFeed error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("dayFeed = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
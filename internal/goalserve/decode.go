@@ -0,0 +1,96 @@
+package goalserve
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// DecodeSoccerScores streams a raw soccer feed payload (plain or gzipped JSON)
+// into GoalServeSoccerScores
+func DecodeSoccerScores(r io.Reader) (*GoalServeSoccerScores, error) {
+	var scores GoalServeSoccerScores
+	if err := decodeFeed(r, "scores", &scores); err != nil {
+		return nil, err
+	}
+	return &scores, nil
+}
+
+// DecodeBasketballScores streams a raw basketball feed payload (plain or gzipped JSON)
+// into GoalServeBasketballScores
+func DecodeBasketballScores(r io.Reader) (*GoalServeBasketballScores, error) {
+	var scores GoalServeBasketballScores
+	if err := decodeFeed(r, "scores", &scores); err != nil {
+		return nil, err
+	}
+	return &scores, nil
+}
+
+// decodeFeed inflates gzip payloads if needed and decodes the root object
+// named root, e.g. "scores", into v
+func decodeFeed(r io.Reader, root string, v any) error {
+	body, err := maybeGunzip(r)
+	if err != nil {
+		return err
+	}
+	return decodeRoot(body, root, v)
+}
+
+// maybeGunzip returns a reader that inflates r when it starts with the gzip
+// magic bytes. Goalserve serves some feeds gzipped without Content-Encoding.
+func maybeGunzip(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReaderSize(r, 32*1024)
+
+	magic, err := br.Peek(2)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open gzip body: %w", err)
+		}
+		return gz, nil
+	}
+
+	return br, nil
+}
+
+// decodeRoot walks the root object token by token and decodes only the root
+// value into v, so the payload is parsed in a single pass
+func decodeRoot(r io.Reader, root string, v any) error {
+	dec := json.NewDecoder(r)
+
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("failed to parse JSON response: %w", err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("failed to parse JSON response: expected object, got %v", tok)
+	}
+
+	for dec.More() {
+		keyTok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("failed to parse JSON response: %w", err)
+		}
+
+		if key, _ := keyTok.(string); key == root {
+			if err := dec.Decode(v); err != nil {
+				return fmt.Errorf("failed to parse %s JSON: %w", root, err)
+			}
+			return nil
+		}
+
+		// Skip values we don't care about (e.g. "?xml" prologue)
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return fmt.Errorf("failed to parse JSON response: %w", err)
+		}
+	}
+
+	return fmt.Errorf("no %s field found in response", root)
+}
@@ -0,0 +1,82 @@
+package goalserve
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+)
+
+// testdata/soccernew_home.json is a soccernew/home payload of 203 matches
+// rendered by mock.NewServer with seed 42 and 40 matches per league: all 8
+// generated leagues, three of them on a single-match day. The mock-goalserve
+// command caps --matches at 10, so the fixture was built through the package.
+
+func loadSoccerFixture(b *testing.B) []byte {
+	b.Helper()
+	payload, err := os.ReadFile("testdata/soccernew_home.json")
+	if err != nil {
+		b.Fatalf("failed to read fixture: %v", err)
+	}
+	return payload
+}
+
+// BenchmarkDecodeSoccerScores measures the streaming decode the client uses
+func BenchmarkDecodeSoccerScores(b *testing.B) {
+	payload := loadSoccerFixture(b)
+	b.SetBytes(int64(len(payload)))
+	b.ReportAllocs()
+
+	for b.Loop() {
+		scores, err := DecodeSoccerScores(bytes.NewReader(payload))
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(scores.Categories) == 0 {
+			b.Fatal("no categories decoded")
+		}
+	}
+}
+
+// BenchmarkDecodeSoccerScoresMapBaseline measures the decode the client did
+// before streaming: the whole payload into a map, the scores value marshalled
+// back to JSON and decoded again into the typed struct
+func BenchmarkDecodeSoccerScoresMapBaseline(b *testing.B) {
+	payload := loadSoccerFixture(b)
+	b.SetBytes(int64(len(payload)))
+	b.ReportAllocs()
+
+	for b.Loop() {
+		scores, err := decodeSoccerScoresViaMap(payload)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(scores.Categories) == 0 {
+			b.Fatal("no categories decoded")
+		}
+	}
+}
+
+func decodeSoccerScoresViaMap(body []byte) (*GoalServeSoccerScores, error) {
+	var jsonResponse map[string]interface{}
+	if err := json.Unmarshal(body, &jsonResponse); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON response: %w", err)
+	}
+
+	scoresData, ok := jsonResponse["scores"]
+	if !ok {
+		return nil, fmt.Errorf("no scores field found in response")
+	}
+
+	scoresJSON, err := json.Marshal(scoresData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal scores data: %w", err)
+	}
+
+	var scores GoalServeSoccerScores
+	if err := json.Unmarshal(scoresJSON, &scores); err != nil {
+		return nil, fmt.Errorf("failed to parse scores JSON: %w", err)
+	}
+	return &scores, nil
+}
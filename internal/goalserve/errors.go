@@ -0,0 +1,81 @@
+package goalserve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Sentinel errors describing why a Goalserve request failed. Callers should
+// branch on them with errors.Is instead of inspecting error strings.
+var (
+	// ErrRateLimited is returned when Goalserve answers with 429 Too Many Requests
+	ErrRateLimited = errors.New("goalserve: rate limited")
+	// ErrAuthRejected is returned when Goalserve rejects the API key (401/403)
+	ErrAuthRejected = errors.New("goalserve: authentication rejected")
+	// ErrUpstreamDown is returned for 5xx responses, timeouts and network failures
+	ErrUpstreamDown = errors.New("goalserve: upstream unavailable")
+	// ErrMalformedPayload is returned when a response body cannot be decoded
+	ErrMalformedPayload = errors.New("goalserve: malformed payload")
+	// ErrNoDayFeed is returned for a day after the d-N/dN day feeds
+	ErrNoDayFeed = errors.New("goalserve: no day feed")
+)
+
+// APIError carries the details of a failed Goalserve request
+type APIError struct {
+	Kind       error         // One of the sentinel errors above, nil for other 4xx responses
+	Feed       string        // Feed path without the API key, e.g. "soccernew/home"
+	StatusCode int           // HTTP status code, 0 when no response was received
+	RetryAfter time.Duration // Server requested delay, 0 when not provided
+	Attempts   int           // Number of attempts made before giving up
+	Body       string        // Truncated response body for diagnostics
+	Err        error         // Underlying transport or decode error, if any
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("goalserve %s", e.Feed)
+	if e.StatusCode != 0 {
+		msg += fmt.Sprintf(": status %d", e.StatusCode)
+	}
+	if e.Kind != nil {
+		msg += ": " + e.Kind.Error()
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	if e.Body != "" {
+		msg += ": " + e.Body
+	}
+	if e.Attempts > 1 {
+		msg += fmt.Sprintf(" (after %d attempts)", e.Attempts)
+	}
+	return msg
+}
+
+// Unwrap exposes both the sentinel kind and the underlying error to errors.Is/As
+func (e *APIError) Unwrap() []error {
+	var errs []error
+	if e.Kind != nil {
+		errs = append(errs, e.Kind)
+	}
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	return errs
+}
+
+// IsRetryable reports whether the failed request may succeed if repeated later
+func (e *APIError) IsRetryable() bool {
+	return errors.Is(e.Kind, ErrUpstreamDown) || errors.Is(e.Kind, ErrRateLimited)
+}
+
+// IsFatal reports whether err means further requests in the same run are
+// pointless (bad credentials, exhausted quota or a cancelled context)
+func IsFatal(err error) bool {
+	return errors.Is(err, ErrAuthRejected) ||
+		errors.Is(err, ErrRateLimited) ||
+		errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
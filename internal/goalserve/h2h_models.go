@@ -0,0 +1,82 @@
+package goalserve
+
+import (
+	"encoding/json"
+)
+
+// GoalServeH2H represents the root h2h structure of the soccer h2h/{team1}/{team2}
+// and basketball bsktbl/h2h_{team1}-{team2} feeds
+type GoalServeH2H struct {
+	Team1   string            `json:"@team1"`
+	Team1ID string            `json:"@id1"`
+	Team2   string            `json:"@team2"`
+	Team2ID string            `json:"@id2"`
+	Top50   GoalServeH2HTop50 `json:"top50"`
+}
+
+// GoalServeH2HTop50 wraps the latest meetings, grouped by competition
+type GoalServeH2HTop50 struct {
+	Categories []GoalServeH2HCategory
+}
+
+// UnmarshalJSON handles a missing list and both a single category object and an array of categories
+func (t *GoalServeH2HTop50) UnmarshalJSON(data []byte) error {
+	var temp struct {
+		Category json.RawMessage `json:"category"`
+	}
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+	return unmarshalOneOrMany(temp.Category, &t.Categories)
+}
+
+// GoalServeH2HCategory represents a competition the two teams met in
+type GoalServeH2HCategory struct {
+	ID      string              `json:"@id"`
+	Name    string              `json:"@name"`
+	Matches []GoalServeH2HMatch `json:"-"`
+}
+
+// UnmarshalJSON handles both a single match object and an array of matches
+func (c *GoalServeH2HCategory) UnmarshalJSON(data []byte) error {
+	var temp struct {
+		ID    string          `json:"@id"`
+		Name  string          `json:"@name"`
+		Match json.RawMessage `json:"match"`
+	}
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+	c.ID, c.Name = temp.ID, temp.Name
+	return unmarshalOneOrMany(temp.Match, &c.Matches)
+}
+
+// GoalServeH2HMatch represents one past meeting; Team1 played at home
+type GoalServeH2HMatch struct {
+	ID         string `json:"@id"`
+	Date       string `json:"@date"` // dd.MM.yyyy
+	Team1      string `json:"@team1"`
+	Team1ID    string `json:"@id1"`
+	Team1Score string `json:"@team1_score"`
+	Team2      string `json:"@team2"`
+	Team2ID    string `json:"@id2"`
+	Team2Score string `json:"@team2_score"`
+}
+
+// unmarshalOneOrMany decodes a list Goalserve sends as an array, a single
+// object or nothing at all into v
+func unmarshalOneOrMany[T any](data json.RawMessage, v *[]T) error {
+	switch {
+	case len(data) > 0 && data[0] == '[':
+		return json.Unmarshal(data, v)
+	case len(data) > 0 && data[0] == '{':
+		var single T
+		if err := json.Unmarshal(data, &single); err != nil {
+			return err
+		}
+		*v = []T{single}
+		return nil
+	}
+	*v = []T{}
+	return nil
+}
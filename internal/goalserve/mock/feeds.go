@@ -0,0 +1,409 @@
+package mock
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Wall-clock timelines of mock matches, relative to kickoff
+const (
+	soccerFirstHalfEnd = 50 * time.Minute  // 45 minutes plus up to 5 added
+	soccerSecondHalf   = 65 * time.Minute  // 15 minute half-time break
+	soccerFullTime     = 115 * time.Minute // 45 minutes plus up to 5 added
+	basketballQuarter  = 12 * time.Minute
+	basketballShortGap = 2 * time.Minute
+	basketballHalfTime = 15 * time.Minute
+	basketballOvertime = 5 * time.Minute
+)
+
+// Date and time layouts used by both feeds
+const (
+	feedDateFormat = "02.01.2006"
+	feedShortDate  = "Jan 2"
+	feedTimeFormat = "15:04"
+)
+
+// soccerScores is the "scores" object of a soccernew payload
+type soccerScores struct {
+	Sport    string           `json:"@sport"`
+	Category []soccerCategory `json:"category"`
+}
+
+type soccerCategory struct {
+	Name    string        `json:"@name"`
+	GID     string        `json:"@gid"`
+	ID      string        `json:"@id"`
+	File    string        `json:"@file_group"`
+	Matches soccerMatches `json:"matches"`
+}
+
+type soccerMatches struct {
+	Date          string `json:"@date"`
+	FormattedDate string `json:"@formatted_date"`
+	Match         any    `json:"match"` // Single object when there is one match, array otherwise
+}
+
+type soccerMatch struct {
+	Status        string       `json:"@status"`
+	Timer         string       `json:"@timer"`
+	Date          string       `json:"@date"`
+	FormattedDate string       `json:"@formatted_date"`
+	Time          string       `json:"@time"`
+	ID            string       `json:"@id"`
+	StaticID      string       `json:"@static_id"`
+	LocalTeam     soccerTeam   `json:"localteam"`
+	VisitorTeam   soccerTeam   `json:"visitorteam"`
+	Events        any          `json:"events"` // null, {"event": {...}} or {"event": [...]}
+	HT            soccerResult `json:"ht"`
+	FT            soccerResult `json:"ft"`
+}
+
+type soccerTeam struct {
+	Name  string `json:"@name"`
+	Goals string `json:"@goals"`
+	ID    string `json:"@id"`
+}
+
+type soccerResult struct {
+	Score string `json:"@score"`
+}
+
+type soccerEvent struct {
+	Type     string `json:"@type"`
+	Team     string `json:"@team"`
+	Player   string `json:"@player"`
+	Result   string `json:"@result"`
+	PlayerID string `json:"@playerId"`
+	Minute   string `json:"@minute"`
+	ExtraMin string `json:"@extra_min"`
+	EventID  string `json:"@eventid"`
+}
+
+// basketballScores is the "scores" object of a bsktbl payload
+type basketballScores struct {
+	Sport    string               `json:"sport"`
+	Category []basketballCategory `json:"category"`
+}
+
+type basketballCategory struct {
+	Name      string `json:"name"`
+	GID       string `json:"gid"`
+	ID        string `json:"id"`
+	FileGroup string `json:"file_group"`
+	Match     any    `json:"match"` // Single object when there is one match, array otherwise
+}
+
+type basketballMatch struct {
+	ID        string         `json:"id"`
+	Date      string         `json:"date"`
+	Time      string         `json:"time"`
+	Status    string         `json:"status"`
+	Timer     string         `json:"timer"`
+	LocalTeam basketballTeam `json:"localteam"`
+	AwayTeam  basketballTeam `json:"awayteam"`
+}
+
+type basketballTeam struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	TotalScore string `json:"totalscore"`
+	Q1         string `json:"q1"`
+	Q2         string `json:"q2"`
+	Q3         string `json:"q3"`
+	Q4         string `json:"q4"`
+	Ot         string `json:"ot"`
+}
+
+// soccerFeedFor renders the soccernew feed for one day as seen at now
+func (g *generator) soccerFeedFor(day, now time.Time) soccerScores {
+	feed := soccerScores{Sport: "soccer", Category: []soccerCategory{}}
+
+	for _, l := range g.soccer {
+		fixtures := g.fixtures(l, day, true)
+		matches := make([]soccerMatch, len(fixtures))
+		for i, f := range fixtures {
+			matches[i] = renderSoccerMatch(f, now)
+		}
+
+		feed.Category = append(feed.Category, soccerCategory{
+			Name: fmt.Sprintf("%s: %s", l.Country, l.Name),
+			GID:  strconv.Itoa(l.GID),
+			ID:   strconv.Itoa(l.ID),
+			File: l.Country,
+			Matches: soccerMatches{
+				Date:          day.Format(feedShortDate),
+				FormattedDate: day.Format(feedDateFormat),
+				Match:         oneOrMany(matches),
+			},
+		})
+	}
+
+	return feed
+}
+
+// renderSoccerMatch derives the live state of a fixture at now
+func renderSoccerMatch(f fixture, now time.Time) soccerMatch {
+	m := soccerMatch{
+		Date:          f.Kickoff.Format(feedShortDate),
+		FormattedDate: f.Kickoff.Format(feedDateFormat),
+		Time:          f.Kickoff.Format(feedTimeFormat),
+		ID:            strconv.Itoa(f.ID),
+		StaticID:      strconv.Itoa(f.ID + 500_000_000),
+		LocalTeam:     soccerTeam{Name: f.Home.Name, ID: strconv.Itoa(f.Home.ID), Goals: "?"},
+		VisitorTeam:   soccerTeam{Name: f.Away.Name, ID: strconv.Itoa(f.Away.ID), Goals: "?"},
+	}
+
+	switch f.Outcome {
+	case "postponed":
+		m.Status = "Postp."
+		return m
+	case "cancelled":
+		m.Status = "Canc."
+		return m
+	}
+
+	elapsed := now.Sub(f.Kickoff)
+	var minute int // Absolute match minute including added time
+	switch {
+	case elapsed < 0:
+		m.Status = m.Time
+		return m
+	case elapsed < soccerFirstHalfEnd:
+		minute = int(elapsed/time.Minute) + 1
+		m.Status = strconv.Itoa(min(minute, 45))
+	case elapsed < soccerSecondHalf:
+		minute = 50
+		m.Status = "HT"
+	case elapsed < soccerFullTime:
+		minute = 46 + int((elapsed-soccerSecondHalf)/time.Minute)
+		m.Status = strconv.Itoa(min(minute, 90))
+	default:
+		minute = 100
+		m.Status = "FT"
+	}
+	if m.Status != "HT" && m.Status != "FT" {
+		m.Timer = m.Status
+	}
+
+	happened := func(e goal) bool {
+		if e.Minute <= 45 {
+			// First half events are all in once the half is over
+			return elapsed >= soccerFirstHalfEnd || e.Minute+e.Extra <= minute
+		}
+		return elapsed >= soccerSecondHalf && e.Minute+e.Extra <= minute
+	}
+
+	var home, away, htHome, htAway int
+	var events []soccerEvent
+	for i, e := range f.Goals {
+		if !happened(e) {
+			continue
+		}
+		if e.Home {
+			home++
+		} else {
+			away++
+		}
+		if e.Minute <= 45 {
+			htHome, htAway = home, away
+		}
+		events = append(events, newSoccerEvent(f, "goal", e, i, fmt.Sprintf("[%d-%d]", home, away)))
+	}
+	for i, e := range f.Cards {
+		if happened(e) {
+			events = append(events, newSoccerEvent(f, "yellowcard", e, len(f.Goals)+i, ""))
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		a, _ := strconv.Atoi(events[i].Minute)
+		b, _ := strconv.Atoi(events[j].Minute)
+		return a < b
+	})
+
+	m.LocalTeam.Goals = strconv.Itoa(home)
+	m.VisitorTeam.Goals = strconv.Itoa(away)
+	if elapsed >= soccerFirstHalfEnd {
+		m.HT.Score = fmt.Sprintf("[%d-%d]", htHome, htAway)
+	}
+	if elapsed >= soccerFullTime {
+		m.FT.Score = fmt.Sprintf("[%d-%d]", home, away)
+	}
+
+	// Goalserve sends a single event as an object rather than a one-element array
+	switch len(events) {
+	case 0:
+		m.Events = nil
+	case 1:
+		m.Events = map[string]any{"event": events[0]}
+	default:
+		m.Events = map[string]any{"event": events}
+	}
+
+	return m
+}
+
+// newSoccerEvent renders one goal or card
+func newSoccerEvent(f fixture, eventType string, e goal, seq int, result string) soccerEvent {
+	side := "visitorteam"
+	if e.Home {
+		side = "localteam"
+	}
+	extra := ""
+	if e.Extra > 0 {
+		extra = strconv.Itoa(e.Extra)
+	}
+	return soccerEvent{
+		Type:     eventType,
+		Team:     side,
+		Player:   e.Player,
+		Result:   result,
+		PlayerID: strconv.Itoa(len(e.Player)*1000 + e.Minute),
+		Minute:   strconv.Itoa(e.Minute),
+		ExtraMin: extra,
+		EventID:  strconv.Itoa(f.ID*100 + seq),
+	}
+}
+
+// basketballFeedFor renders the bsktbl feed for one day as seen at now
+func (g *generator) basketballFeedFor(day, now time.Time) basketballScores {
+	feed := basketballScores{Sport: "basketball", Category: []basketballCategory{}}
+
+	for _, l := range g.basketball {
+		fixtures := g.fixtures(l, day, false)
+		matches := make([]basketballMatch, len(fixtures))
+		for i, f := range fixtures {
+			matches[i] = renderBasketballMatch(f, now)
+		}
+
+		feed.Category = append(feed.Category, basketballCategory{
+			Name:      fmt.Sprintf("%s: %s", l.Country, l.Name),
+			GID:       strconv.Itoa(l.GID),
+			ID:        strconv.Itoa(l.ID),
+			FileGroup: l.Country,
+			Match:     oneOrMany(matches),
+		})
+	}
+
+	return feed
+}
+
+// basketballPeriod is one segment of the wall-clock basketball timeline
+type basketballPeriod struct {
+	status  string
+	length  time.Duration
+	quarter int // Index into fixture.Quarters, -1 for breaks
+}
+
+// renderBasketballMatch derives the live state of a fixture at now
+func renderBasketballMatch(f fixture, now time.Time) basketballMatch {
+	m := basketballMatch{
+		ID:        strconv.Itoa(f.ID),
+		Date:      f.Kickoff.Format(feedDateFormat),
+		Time:      f.Kickoff.Format(feedTimeFormat),
+		LocalTeam: basketballTeam{ID: strconv.Itoa(f.Home.ID), Name: f.Home.Name},
+		AwayTeam:  basketballTeam{ID: strconv.Itoa(f.Away.ID), Name: f.Away.Name},
+	}
+
+	switch f.Outcome {
+	case "postponed":
+		m.Status = "Postponed"
+		return m
+	case "cancelled":
+		m.Status = "Cancelled"
+		return m
+	}
+
+	elapsed := now.Sub(f.Kickoff)
+	if elapsed < 0 {
+		m.Status = "Not Started"
+		return m
+	}
+
+	timeline := []basketballPeriod{
+		{"1st Quarter", basketballQuarter, 0},
+		{"Break Time", basketballShortGap, -1},
+		{"2nd Quarter", basketballQuarter, 1},
+		{"Halftime", basketballHalfTime, -1},
+		{"3rd Quarter", basketballQuarter, 2},
+		{"Break Time", basketballShortGap, -1},
+		{"4th Quarter", basketballQuarter, 3},
+	}
+	if f.Overtime {
+		timeline = append(timeline,
+			basketballPeriod{"Break Time", basketballShortGap, -1},
+			basketballPeriod{"Overtime", basketballOvertime, 4},
+		)
+	}
+
+	var points [5][2]int
+	m.Status = "Finished"
+	if f.Overtime {
+		m.Status = "After Over Time"
+	}
+	played := make([]bool, 5)
+
+	remaining := elapsed
+	for _, p := range timeline {
+		if remaining >= p.length {
+			remaining -= p.length
+			if p.quarter >= 0 {
+				points[p.quarter] = f.Quarters[p.quarter]
+				played[p.quarter] = true
+			}
+			continue
+		}
+
+		// Currently inside this period
+		m.Status = p.status
+		if p.quarter >= 0 {
+			progress := float64(remaining) / float64(p.length)
+			points[p.quarter] = [2]int{
+				int(float64(f.Quarters[p.quarter][0]) * progress),
+				int(float64(f.Quarters[p.quarter][1]) * progress),
+			}
+			played[p.quarter] = true
+			m.Timer = fmt.Sprintf("%02d", int((p.length-remaining)/time.Minute))
+		}
+		break
+	}
+
+	var home, away int
+	for q := 0; q < 5; q++ {
+		if !played[q] {
+			continue
+		}
+		home += points[q][0]
+		away += points[q][1]
+	}
+	m.LocalTeam.TotalScore = strconv.Itoa(home)
+	m.AwayTeam.TotalScore = strconv.Itoa(away)
+
+	quarterFields := func(t *basketballTeam, side int) {
+		fields := []*string{&t.Q1, &t.Q2, &t.Q3, &t.Q4, &t.Ot}
+		for q, field := range fields {
+			if played[q] {
+				*field = strconv.Itoa(points[q][side])
+			}
+		}
+	}
+	quarterFields(&m.LocalTeam, 0)
+	quarterFields(&m.AwayTeam, 1)
+
+	return m
+}
+
+// oneOrMany mirrors Goalserve's XML-to-JSON quirk: one element is sent as an
+// object, several as an array, none as null
+func oneOrMany[T any](items []T) any {
+	switch len(items) {
+	case 0:
+		return nil
+	case 1:
+		return items[0]
+	default:
+		return items
+	}
+}
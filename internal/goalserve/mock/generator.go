@@ -0,0 +1,235 @@
+package mock
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"sort"
+	"time"
+)
+
+// league is a generated competition with a fixed set of teams
+type league struct {
+	ID      int
+	GID     int
+	Name    string
+	Country string
+	Teams   []team
+}
+
+// team is a generated club
+type team struct {
+	ID   int
+	Name string
+}
+
+// fixture is a generated match with its full, pre-decided outcome. What the
+// feed shows is derived from the fixture and the current wall clock.
+type fixture struct {
+	ID       int
+	League   *league
+	Home     team
+	Away     team
+	Kickoff  time.Time
+	Outcome  string    // "", "postponed" or "cancelled"
+	Goals    []goal    // Soccer only
+	Cards    []goal    // Soccer only: yellow cards, reusing the goal shape
+	Quarters [5][2]int // Basketball only: Q1-Q4 and OT points (home, away)
+	Overtime bool      // Basketball only
+}
+
+// goal is a soccer event at a given minute for one side
+type goal struct {
+	Minute int
+	Extra  int
+	Home   bool
+	Player string
+}
+
+var soccerLeagueNames = []struct{ country, name string }{
+	{"England", "Premier League"},
+	{"Spain", "La Liga"},
+	{"Italy", "Serie A"},
+	{"Germany", "Bundesliga"},
+	{"France", "Ligue 1"},
+	{"Serbia", "Super Liga"},
+	{"Netherlands", "Eredivisie"},
+	{"Portugal", "Primeira Liga"},
+}
+
+var basketballLeagueNames = []struct{ country, name string }{
+	{"USA", "NBA"},
+	{"Europe", "Euroleague"},
+	{"Spain", "ACB"},
+	{"Serbia", "KLS"},
+	{"Turkey", "BSL"},
+	{"Greece", "Basket League"},
+}
+
+var cityNames = []string{
+	"Northbridge", "Eastfield", "Westport", "Southvale", "Riverton", "Lakeside", "Hillcrest",
+	"Kingsford", "Ashford", "Brookhaven", "Fairview", "Oakridge", "Stonebury", "Millbrook",
+	"Redcliff", "Bayside", "Greenwich", "Highmoor", "Clearwater", "Ironbridge",
+}
+
+var playerNames = []string{
+	"J. Silva", "M. Novak", "A. Rossi", "L. Muller", "K. Petrovic", "D. Jones", "R. Garcia",
+	"T. Dubois", "S. Jansen", "P. Costa", "N. Ivanov", "E. Smith", "F. Moreno", "G. Bianchi",
+}
+
+// generator builds deterministic leagues and fixtures from a seed
+type generator struct {
+	seed             uint64
+	matchesPerLeague int
+	soccer           []*league
+	basketball       []*league
+}
+
+// newGenerator creates the league and team catalogue for both sports
+func newGenerator(seed uint64, leagues, matchesPerLeague int) *generator {
+	g := &generator{seed: seed, matchesPerLeague: matchesPerLeague}
+	g.soccer = g.buildLeagues(soccerLeagueNames, leagues, 1000, "FC")
+	g.basketball = g.buildLeagues(basketballLeagueNames, leagues, 3000, "BC")
+	return g
+}
+
+// buildLeagues creates up to n leagues, each with enough teams for a full matchday
+func (g *generator) buildLeagues(names []struct{ country, name string }, n, idBase int, suffix string) []*league {
+	if n > len(names) {
+		n = len(names)
+	}
+
+	rng := g.rand("leagues", idBase)
+	leagues := make([]*league, n)
+	for i := 0; i < n; i++ {
+		l := &league{
+			ID:      idBase + i + 1,
+			GID:     idBase*10 + i + 1,
+			Name:    names[i].name,
+			Country: names[i].country,
+		}
+		// Two teams per match plus a spare so the single-match day can happen
+		teamCount := 2*g.matchesPerLeague + 2
+		perm := rng.Perm(len(cityNames))
+		for t := 0; t < teamCount; t++ {
+			city := cityNames[perm[t%len(perm)]]
+			if t >= len(perm) {
+				city += " United"
+			}
+			l.Teams = append(l.Teams, team{
+				ID:   (idBase+i+1)*100 + t + 1,
+				Name: fmt.Sprintf("%s %s", city, suffix),
+			})
+		}
+		leagues[i] = l
+	}
+	return leagues
+}
+
+// fixtures returns the matches of one league on the given UTC day
+func (g *generator) fixtures(l *league, day time.Time, soccer bool) []fixture {
+	dayIndex := int(day.Unix() / 86400)
+	rng := g.rand("day", l.ID, dayIndex)
+
+	// Every third day a league plays a single match, which Goalserve
+	// serialises as an object instead of an array
+	count := g.matchesPerLeague
+	if (dayIndex+l.ID)%3 == 0 {
+		count = 1
+	}
+
+	perm := rng.Perm(len(l.Teams))
+	fixtures := make([]fixture, 0, count)
+	for i := 0; i < count; i++ {
+		// Spread kickoffs over the whole day so something is always live
+		offset := time.Duration((i*24*60/count+(l.ID%7)*17)%(24*60)) * time.Minute
+		offset = offset.Truncate(5 * time.Minute)
+
+		f := fixture{
+			ID:      l.ID*1_000_000 + (dayIndex%10_000)*100 + i,
+			League:  l,
+			Home:    l.Teams[perm[2*i]],
+			Away:    l.Teams[perm[2*i+1]],
+			Kickoff: day.Add(offset),
+		}
+
+		switch roll := rng.IntN(100); {
+		case roll < 2:
+			f.Outcome = "postponed"
+		case roll < 3:
+			f.Outcome = "cancelled"
+		}
+
+		if soccer {
+			g.soccerScript(&f, rng)
+		} else {
+			g.basketballScript(&f, rng)
+		}
+		fixtures = append(fixtures, f)
+	}
+
+	return fixtures
+}
+
+// soccerScript decides goals and cards for a soccer fixture
+func (g *generator) soccerScript(f *fixture, rng *rand.Rand) {
+	for n := rng.IntN(6); n > 0; n-- {
+		minute := 1 + rng.IntN(90)
+		extra := 0
+		if minute == 45 || minute == 90 {
+			extra = rng.IntN(5)
+		}
+		f.Goals = append(f.Goals, goal{
+			Minute: minute,
+			Extra:  extra,
+			Home:   rng.IntN(100) < 55,
+			Player: playerNames[rng.IntN(len(playerNames))],
+		})
+	}
+	for n := rng.IntN(4); n > 0; n-- {
+		f.Cards = append(f.Cards, goal{
+			Minute: 1 + rng.IntN(90),
+			Home:   rng.IntN(2) == 0,
+			Player: playerNames[rng.IntN(len(playerNames))],
+		})
+	}
+	sortGoals(f.Goals)
+	sortGoals(f.Cards)
+}
+
+// basketballScript decides quarter scores for a basketball fixture
+func (g *generator) basketballScript(f *fixture, rng *rand.Rand) {
+	var home, away int
+	for q := 0; q < 4; q++ {
+		f.Quarters[q] = [2]int{16 + rng.IntN(17), 16 + rng.IntN(17)}
+		home += f.Quarters[q][0]
+		away += f.Quarters[q][1]
+	}
+	if home == away {
+		f.Overtime = true
+		f.Quarters[4] = [2]int{5 + rng.IntN(10), 5 + rng.IntN(10)}
+		if f.Quarters[4][0] == f.Quarters[4][1] {
+			f.Quarters[4][0]++
+		}
+	}
+}
+
+// rand returns a generator seeded from the global seed and the given parts
+func (g *generator) rand(parts ...any) *rand.Rand {
+	h := fnv.New64a()
+	fmt.Fprint(h, g.seed)
+	for _, p := range parts {
+		fmt.Fprint(h, "/", p)
+	}
+	return rand.New(rand.NewPCG(g.seed, h.Sum64()))
+}
+
+// sortGoals orders events by match minute
+func sortGoals(goals []goal) {
+	sort.SliceStable(goals, func(i, j int) bool {
+		if goals[i].Minute != goals[j].Minute {
+			return goals[i].Minute < goals[j].Minute
+		}
+		return goals[i].Extra < goals[j].Extra
+	})
+}
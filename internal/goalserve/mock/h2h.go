@@ -0,0 +1,133 @@
+package mock
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	h2hLookbackDays = 730 // How far back past meetings are looked for
+	h2hMaxMeetings  = 50  // Meetings in top50
+)
+
+// h2hFeed is the "h2h" object of a soccer h2h or bsktbl h2h_ payload
+type h2hFeed struct {
+	Team1   string   `json:"@team1"`
+	Team1ID string   `json:"@id1"`
+	Team2   string   `json:"@team2"`
+	Team2ID string   `json:"@id2"`
+	Top50   h2hTop50 `json:"top50"`
+}
+
+type h2hTop50 struct {
+	Category any `json:"category"` // Single object for one competition, null for none
+}
+
+type h2hCategory struct {
+	ID    string `json:"@id"`
+	Name  string `json:"@name"`
+	Match any    `json:"match"` // Single object when there is one meeting, array otherwise
+}
+
+type h2hMatch struct {
+	ID         string `json:"@id"`
+	Date       string `json:"@date"`
+	Team1      string `json:"@team1"` // Home side of the meeting
+	Team1ID    string `json:"@id1"`
+	Team1Score string `json:"@team1_score"`
+	Team2      string `json:"@team2"`
+	Team2ID    string `json:"@id2"`
+	Team2Score string `json:"@team2_score"`
+}
+
+// h2hFeedFor renders the finished meetings of two teams as seen at now,
+// latest first. The meetings are the generated fixtures of their league, so
+// they agree with the day feeds. It returns false for an unknown team.
+func (g *generator) h2hFeedFor(team1, team2 int, soccer bool, now time.Time) (h2hFeed, bool) {
+	leagues := g.basketball
+	if soccer {
+		leagues = g.soccer
+	}
+	l1, t1 := findTeam(leagues, team1)
+	l2, t2 := findTeam(leagues, team2)
+	if l1 == nil || l2 == nil {
+		return h2hFeed{}, false
+	}
+
+	feed := h2hFeed{
+		Team1:   t1.Name,
+		Team1ID: strconv.Itoa(t1.ID),
+		Team2:   t2.Name,
+		Team2ID: strconv.Itoa(t2.ID),
+	}
+	if l1 != l2 || team1 == team2 {
+		return feed, true // Teams of different leagues never meet
+	}
+
+	var matches []h2hMatch
+	today := now.Truncate(24 * time.Hour)
+	for d := 0; d < h2hLookbackDays && len(matches) < h2hMaxMeetings; d++ {
+		day := today.AddDate(0, 0, -d)
+		fixtures := g.fixtures(l1, day, soccer)
+		for i := len(fixtures) - 1; i >= 0; i-- {
+			f := fixtures[i]
+			if (f.Home.ID != team1 || f.Away.ID != team2) && (f.Home.ID != team2 || f.Away.ID != team1) {
+				continue
+			}
+			if m, ok := renderH2HMatch(f, soccer, now); ok && len(matches) < h2hMaxMeetings {
+				matches = append(matches, m)
+			}
+		}
+	}
+
+	if len(matches) > 0 {
+		feed.Top50.Category = h2hCategory{
+			ID:    strconv.Itoa(l1.ID),
+			Name:  fmt.Sprintf("%s: %s", l1.Country, l1.Name),
+			Match: oneOrMany(matches),
+		}
+	}
+	return feed, true
+}
+
+// renderH2HMatch renders a fixture as a past meeting, and false while it is
+// not finished
+func renderH2HMatch(f fixture, soccer bool, now time.Time) (h2hMatch, bool) {
+	m := h2hMatch{
+		ID:      strconv.Itoa(f.ID),
+		Date:    f.Kickoff.Format(feedDateFormat),
+		Team1:   f.Home.Name,
+		Team1ID: strconv.Itoa(f.Home.ID),
+		Team2:   f.Away.Name,
+		Team2ID: strconv.Itoa(f.Away.ID),
+	}
+
+	if soccer {
+		rendered := renderSoccerMatch(f, now)
+		if rendered.Status != "FT" {
+			return h2hMatch{}, false
+		}
+		m.Team1Score, m.Team2Score = rendered.LocalTeam.Goals, rendered.VisitorTeam.Goals
+		return m, true
+	}
+
+	rendered := renderBasketballMatch(f, now)
+	if rendered.Status != "Finished" && rendered.Status != "After Over Time" {
+		return h2hMatch{}, false
+	}
+	m.Team1Score, m.Team2Score = rendered.LocalTeam.TotalScore, rendered.AwayTeam.TotalScore
+	return m, true
+}
+
+// findTeam returns the league and team with the given ID, or a nil league
+func findTeam(leagues []*league, id int) (*league, team) {
+	for _, l := range leagues {
+		for _, t := range l.Teams {
+			if t.ID == id {
+				return l, t
+			}
+		}
+	}
+	return nil, team{}
+}
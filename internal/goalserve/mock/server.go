@@ -0,0 +1,243 @@
+// Package mock implements a local stand-in for the Goalserve feed API. It
+// generates deterministic leagues and fixtures, advances matches through
+// their statuses in real time and can inject upstream faults.
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// Config controls the generated data and the injected faults
+type Config struct {
+	APIKey           string        // Required key path segment, empty accepts any key
+	Seed             uint64        // Same seed, same leagues, teams and fixtures
+	Leagues          int           // Leagues per sport
+	MatchesPerLeague int           // Matches per league per day
+	Latency          time.Duration // Delay added to slow responses
+	SlowRate         float64       // Fraction of responses delayed by Latency
+	ErrorRate        float64       // Fraction of responses replaced by a 500
+	TruncateRate     float64       // Fraction of responses cut off mid-JSON
+}
+
+// Server serves generated Goalserve feeds
+type Server struct {
+	config    Config
+	generator *generator
+	now       func() time.Time
+}
+
+// NewServer creates a mock server from config
+func NewServer(config Config) *Server {
+	if config.Leagues < 1 {
+		config.Leagues = 1
+	}
+	if config.MatchesPerLeague < 1 {
+		config.MatchesPerLeague = 1
+	}
+
+	return &Server{
+		config:    config,
+		generator: newGenerator(config.Seed, config.Leagues, config.MatchesPerLeague),
+		now:       time.Now,
+	}
+}
+
+// Handler returns the HTTP handler serving the feed routes
+func (s *Server) Handler() http.Handler {
+	r := chi.NewRouter()
+
+	r.Use(chiMiddleware.Logger)
+	r.Use(chiMiddleware.Recoverer)
+
+	r.Route("/getfeed/{key}", func(r chi.Router) {
+		r.Use(s.authenticate)
+		r.Use(s.injectFaults)
+
+		r.Get("/soccernew/{feed}", s.handleSoccer)
+		r.Get("/bsktbl/{feed}", s.handleBasketball)
+		r.Get("/h2h/{team1}/{team2}", s.handleSoccerH2H)
+		r.Get("/bsktbl/h2h_{team1}-{team2}", s.handleBasketballH2H)
+	})
+
+	return r
+}
+
+// authenticate rejects requests whose key segment does not match the configured key
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.config.APIKey != "" && chi.URLParam(r, "key") != s.config.APIKey {
+			http.Error(w, "Invalid API key", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// injectFaults delays, fails or truncates a share of responses
+func (s *Server) injectFaults(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.config.Latency > 0 && rand.Float64() < s.config.SlowRate {
+			select {
+			case <-time.After(s.config.Latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		if rand.Float64() < s.config.ErrorRate {
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		if rand.Float64() < s.config.TruncateRate {
+			next.ServeHTTP(&truncatingWriter{ResponseWriter: w}, r)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handleSoccer serves soccernew/home, soccernew/d-N and soccernew/dN
+func (s *Server) handleSoccer(w http.ResponseWriter, r *http.Request) {
+	day, err := s.feedDay(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	writeFeed(w, s.generator.soccerFeedFor(day, s.now().UTC()))
+}
+
+// handleBasketball serves bsktbl/home, bsktbl/d-N and bsktbl/dN
+func (s *Server) handleBasketball(w http.ResponseWriter, r *http.Request) {
+	day, err := s.feedDay(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	writeFeed(w, s.generator.basketballFeedFor(day, s.now().UTC()))
+}
+
+// handleSoccerH2H serves h2h/{team1}/{team2}
+func (s *Server) handleSoccerH2H(w http.ResponseWriter, r *http.Request) {
+	s.serveH2H(w, r, true)
+}
+
+// handleBasketballH2H serves bsktbl/h2h_{team1}-{team2}
+func (s *Server) handleBasketballH2H(w http.ResponseWriter, r *http.Request) {
+	s.serveH2H(w, r, false)
+}
+
+// serveH2H renders the meetings of the two teams in the request path
+func (s *Server) serveH2H(w http.ResponseWriter, r *http.Request, soccer bool) {
+	team1, err1 := strconv.Atoi(chi.URLParam(r, "team1"))
+	team2, err2 := strconv.Atoi(chi.URLParam(r, "team2"))
+	if err1 != nil || err2 != nil {
+		http.Error(w, "invalid team IDs", http.StatusNotFound)
+		return
+	}
+
+	feed, ok := s.generator.h2hFeedFor(team1, team2, soccer, s.now().UTC())
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown team %d or %d", team1, team2), http.StatusNotFound)
+		return
+	}
+	writeJSON(w, h2hEnvelope{XML: xmlPrologue, H2H: feed})
+}
+
+// feedDay resolves the UTC day a request is asking for. An explicit
+// ?date=dd.MM.yyyy wins over the feed's day offset.
+func (s *Server) feedDay(r *http.Request) (time.Time, error) {
+	if date := r.URL.Query().Get("date"); date != "" {
+		day, err := time.Parse(feedDateFormat, date)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date %q", date)
+		}
+		return day, nil
+	}
+
+	offset, err := parseFeedOffset(chi.URLParam(r, "feed"))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	today := s.now().UTC().Truncate(24 * time.Hour)
+	return today.AddDate(0, 0, offset), nil
+}
+
+// parseFeedOffset maps "home" to 0, "d-N" to -N and "dN" to +N days
+func parseFeedOffset(feed string) (int, error) {
+	if feed == "home" {
+		return 0, nil
+	}
+
+	digits, ok := strings.CutPrefix(feed, "d")
+	if !ok {
+		return 0, fmt.Errorf("unknown feed %q", feed)
+	}
+
+	offset, err := strconv.Atoi(digits)
+	if err != nil || offset < -30 || offset > 30 {
+		return 0, fmt.Errorf("unknown feed %q", feed)
+	}
+	return offset, nil
+}
+
+// xmlPrologue is the key Goalserve leaves in its JSON output from the XML prologue
+var xmlPrologue = map[string]string{"@version": "1.0", "@encoding": "utf-8"}
+
+// feedEnvelope wraps a scores object the way Goalserve does
+type feedEnvelope struct {
+	XML    map[string]string `json:"?xml"`
+	Scores any               `json:"scores"`
+}
+
+// h2hEnvelope wraps an h2h object the way Goalserve does
+type h2hEnvelope struct {
+	XML map[string]string `json:"?xml"`
+	H2H h2hFeed           `json:"h2h"`
+}
+
+// writeFeed encodes a scores object in Goalserve's JSON envelope
+func writeFeed(w http.ResponseWriter, scores any) {
+	writeJSON(w, feedEnvelope{XML: xmlPrologue, Scores: scores})
+}
+
+// writeJSON encodes a response envelope
+func writeJSON(w http.ResponseWriter, envelope any) {
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		log.Printf("Failed to encode mock feed: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write(body)
+}
+
+// truncatingWriter passes through roughly the first half of the body and
+// drops the rest, leaving the client with invalid JSON
+type truncatingWriter struct {
+	http.ResponseWriter
+}
+
+// Write implements io.Writer
+func (t *truncatingWriter) Write(p []byte) (int, error) {
+	if _, err := t.ResponseWriter.Write(p[:len(p)/2]); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
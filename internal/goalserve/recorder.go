@@ -0,0 +1,247 @@
+package goalserve
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// recordingTimeFormat names recording files so they sort chronologically
+const recordingTimeFormat = "20060102T150405.000000000Z"
+
+// Recording describes one raw feed response saved by RecordingTransport
+type Recording struct {
+	Feed            string    `json:"feed"` // Feed key, e.g. "soccernew/home" or "bsktbl/d-1/date=01.09.2026"
+	URL             string    `json:"url"`  // Request URL with the API key removed
+	FetchedAt       time.Time `json:"fetched_at"`
+	StatusCode      int       `json:"status_code"`
+	ContentType     string    `json:"content_type,omitempty"`
+	ContentEncoding string    `json:"content_encoding,omitempty"`
+	BodyFile        string    `json:"body_file"` // Raw body, stored next to the metadata file
+}
+
+// RecordingTransport saves every response that passes through it to Dir,
+// one metadata file plus one raw body file per response
+type RecordingTransport struct {
+	Dir  string
+	Next http.RoundTripper
+}
+
+// NewRecordingTransport wraps next (or http.DefaultTransport) and records into dir
+func NewRecordingTransport(dir string, next http.RoundTripper) *RecordingTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &RecordingTransport{Dir: dir, Next: next}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.Next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err := t.save(req.URL, resp, body); err != nil {
+		// Recording is a debugging aid; never fail the sync because of it
+		log.Printf("Warning: failed to record GoalServe response: %v", err)
+	}
+
+	return resp, nil
+}
+
+// save writes the metadata and body files for one response
+func (t *RecordingTransport) save(u *url.URL, resp *http.Response, body []byte) error {
+	feed := RecordingKey(u)
+	dir := filepath.Join(t.Dir, filepath.FromSlash(feed))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	name := now.Format(recordingTimeFormat)
+
+	rec := Recording{
+		Feed:            feed,
+		URL:             redactURL(u),
+		FetchedAt:       now,
+		StatusCode:      resp.StatusCode,
+		ContentType:     resp.Header.Get("Content-Type"),
+		ContentEncoding: resp.Header.Get("Content-Encoding"),
+		BodyFile:        name + ".body",
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, rec.BodyFile), body, 0o644); err != nil {
+		return err
+	}
+
+	meta, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, name+".json"), meta, 0o644)
+}
+
+// ReplayMode selects which recording ReplayTransport serves for a feed
+type ReplayMode string
+
+const (
+	// ReplayLatest always serves the newest recording of a feed
+	ReplayLatest ReplayMode = "latest"
+	// ReplaySequence serves recordings oldest first, one per request, then repeats the last
+	ReplaySequence ReplayMode = "sequence"
+)
+
+// ReplayTransport serves responses from a directory written by RecordingTransport
+// without touching the network
+type ReplayTransport struct {
+	Dir  string
+	Mode ReplayMode
+
+	mu       sync.Mutex
+	position map[string]int
+}
+
+// NewReplayTransport creates a replay transport reading recordings from dir
+func NewReplayTransport(dir string, mode ReplayMode) *ReplayTransport {
+	if mode != ReplaySequence {
+		mode = ReplayLatest
+	}
+	return &ReplayTransport{Dir: dir, Mode: mode, position: make(map[string]int)}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	feed := RecordingKey(req.URL)
+
+	recordings, err := LoadRecordings(filepath.Join(t.Dir, filepath.FromSlash(feed)))
+	if err != nil {
+		return nil, err
+	}
+	if len(recordings) == 0 {
+		return replayResponse(req, http.StatusNotFound, nil, fmt.Appendf(nil, "no recording for feed %s", feed)), nil
+	}
+
+	rec := recordings[len(recordings)-1]
+	if t.Mode == ReplaySequence {
+		t.mu.Lock()
+		i := t.position[feed]
+		if i < len(recordings)-1 {
+			t.position[feed] = i + 1
+		}
+		t.mu.Unlock()
+		rec = recordings[i]
+	}
+
+	body, err := os.ReadFile(filepath.Join(t.Dir, filepath.FromSlash(feed), rec.BodyFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recorded body: %w", err)
+	}
+
+	header := make(http.Header)
+	if rec.ContentType != "" {
+		header.Set("Content-Type", rec.ContentType)
+	}
+	if rec.ContentEncoding != "" {
+		header.Set("Content-Encoding", rec.ContentEncoding)
+	}
+
+	return replayResponse(req, rec.StatusCode, header, body), nil
+}
+
+// LoadRecordings returns the recordings in dir ordered oldest first
+func LoadRecordings(dir string) ([]Recording, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recordings: %w", err)
+	}
+
+	var recordings []Recording
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read recording: %w", err)
+		}
+
+		var rec Recording
+		if err := json.Unmarshal(data, &rec); err != nil {
+			return nil, fmt.Errorf("failed to parse recording %s: %w", entry.Name(), err)
+		}
+		recordings = append(recordings, rec)
+	}
+
+	sort.Slice(recordings, func(i, j int) bool {
+		return recordings[i].FetchedAt.Before(recordings[j].FetchedAt)
+	})
+
+	return recordings, nil
+}
+
+// RecordingKey derives the storage key for a feed URL: the path after the API
+// key plus any query parameters other than json=1
+func RecordingKey(u *url.URL) string {
+	path := strings.TrimPrefix(u.Path, "/getfeed/")
+	if _, rest, ok := strings.Cut(path, "/"); ok {
+		path = rest // Drop the API key segment
+	}
+
+	query := u.Query()
+	query.Del("json")
+	if len(query) > 0 {
+		path += "/" + strings.ReplaceAll(query.Encode(), "&", "_")
+	}
+
+	return path
+}
+
+// redactURL returns u as a string with the API key segment replaced
+func redactURL(u *url.URL) string {
+	redacted := *u
+	path := strings.TrimPrefix(u.Path, "/getfeed/")
+	if _, rest, ok := strings.Cut(path, "/"); ok {
+		redacted.Path = "/getfeed/KEY/" + rest
+	}
+	return redacted.String()
+}
+
+// replayResponse builds a synthetic response for a replayed request
+func replayResponse(req *http.Request, status int, header http.Header, body []byte) *http.Response {
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,178 @@
+package goalserve
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// Priority orders Goalserve requests competing for the shared rate budget
+type Priority int
+
+const (
+	// PriorityLow is used for static fixture pulls (future and past days) and h2h history
+	PriorityLow Priority = iota
+	// PriorityNormal is used for requests without an explicit priority
+	PriorityNormal
+	// PriorityHigh is used for live score polling and pre-empts everything else
+	PriorityHigh
+
+	numPriorities = int(PriorityHigh) + 1
+)
+
+var (
+	sharedScheduler *Scheduler
+	schedulerOnce   sync.Once
+)
+
+// Scheduler is a token bucket shared by every Goalserve request in the process.
+// Waiting requests are granted tokens highest priority first, so live polling
+// is never stuck behind a queue of fixture pulls.
+type Scheduler struct {
+	limiter *rate.Limiter
+
+	mu     sync.Mutex
+	queues [numPriorities][]chan struct{}
+	wake   chan struct{}
+}
+
+// SharedScheduler returns the process-wide scheduler, configured from
+// GOALSERVE_RATE_LIMIT (requests per second) and GOALSERVE_RATE_BURST. The
+// budget is per process, so commands run beside the sync worker, such as
+// backfill, add their own.
+func SharedScheduler() *Scheduler {
+	schedulerOnce.Do(func() {
+		perSecond, err := strconv.ParseFloat(getEnv("GOALSERVE_RATE_LIMIT", "1"), 64)
+		if err != nil || perSecond <= 0 {
+			perSecond = 1
+		}
+		burst, err := strconv.Atoi(getEnv("GOALSERVE_RATE_BURST", "1"))
+		if err != nil || burst < 1 {
+			burst = 1
+		}
+		sharedScheduler = NewScheduler(perSecond, burst)
+	})
+
+	return sharedScheduler
+}
+
+// NewScheduler creates a scheduler allowing perSecond requests with the given burst
+func NewScheduler(perSecond float64, burst int) *Scheduler {
+	s := &Scheduler{
+		limiter: rate.NewLimiter(rate.Limit(perSecond), burst),
+		wake:    make(chan struct{}, 1),
+	}
+	go s.dispatch()
+	return s
+}
+
+// Wait blocks until the caller may issue one request or ctx is done
+func (s *Scheduler) Wait(ctx context.Context, priority Priority) error {
+	if priority < PriorityLow || int(priority) >= numPriorities {
+		priority = PriorityNormal
+	}
+
+	ready := make(chan struct{})
+
+	s.mu.Lock()
+	s.queues[priority] = append(s.queues[priority], ready)
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		s.remove(priority, ready)
+		return ctx.Err()
+	}
+}
+
+// Pending returns the number of requests currently waiting for a token
+func (s *Scheduler) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	total := 0
+	for _, queue := range s.queues {
+		total += len(queue)
+	}
+	return total
+}
+
+// dispatch hands out tokens to queued waiters, highest priority first.
+// The waiter is picked only after the token is available so a late
+// high-priority request overtakes low-priority ones already queued.
+func (s *Scheduler) dispatch() {
+	for {
+		if s.Pending() == 0 {
+			<-s.wake
+			continue
+		}
+
+		// Background context: the limiter only fails when the wait exceeds the burst
+		_ = s.limiter.Wait(context.Background())
+
+		if ready := s.next(); ready != nil {
+			close(ready)
+		}
+	}
+}
+
+// next pops the oldest waiter of the highest non-empty priority
+func (s *Scheduler) next() chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for p := numPriorities - 1; p >= 0; p-- {
+		if len(s.queues[p]) > 0 {
+			ready := s.queues[p][0]
+			s.queues[p] = s.queues[p][1:]
+			return ready
+		}
+	}
+	return nil
+}
+
+// remove drops a cancelled waiter from its queue
+func (s *Scheduler) remove(priority Priority, ready chan struct{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queue := s.queues[priority]
+	for i, r := range queue {
+		if r == ready {
+			s.queues[priority] = append(queue[:i], queue[i+1:]...)
+			return
+		}
+	}
+}
+
+type priorityKey struct{}
+
+// WithPriority returns a context whose Goalserve requests use the given priority
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// withDefaultPriority sets priority on ctx unless the caller already chose one
+func withDefaultPriority(ctx context.Context, priority Priority) context.Context {
+	if _, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return ctx
+	}
+	return WithPriority(ctx, priority)
+}
+
+// priorityFrom returns the priority stored in ctx, or fallback if none was set
+func priorityFrom(ctx context.Context, fallback Priority) Priority {
+	if p, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return p
+	}
+	return fallback
+}
@@ -52,18 +52,25 @@ func (m *GoalServeSoccerMatchesData) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalJSON writes the matches the way Goalserve sends them, so the output decodes again
+func (m GoalServeSoccerMatchesData) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Match []GoalServeSoccerMatch `json:"match"`
+	}{m.Match})
+}
+
 // GoalServeSoccerMatch represents a soccer match from GoalServe
 type GoalServeSoccerMatch struct {
-	ID            string               `json:"@id"`
-	Date          string               `json:"@date"`
-	FormattedDate string               `json:"@formatted_date"`
-	Time          string               `json:"@time"`
-	Status        string               `json:"@status"`
-	LocalTeam     GoalServeSoccerTeam  `json:"localteam"`
-	VisitorTeam   GoalServeSoccerTeam  `json:"visitorteam"`
-	HTScore       GoalServeSoccerScore `json:"ht"`
-	FTScore       GoalServeSoccerScore `json:"ft"`
-	Events        interface{}          `json:"events"` // Can be null or object with event array
+	ID            string                `json:"@id"`
+	Date          string                `json:"@date"`
+	FormattedDate string                `json:"@formatted_date"`
+	Time          string                `json:"@time"`
+	Status        string                `json:"@status"`
+	LocalTeam     GoalServeSoccerTeam   `json:"localteam"`
+	VisitorTeam   GoalServeSoccerTeam   `json:"visitorteam"`
+	HTScore       GoalServeSoccerScore  `json:"ht"`
+	FTScore       GoalServeSoccerScore  `json:"ft"`
+	Events        GoalServeSoccerEvents `json:"events"`
 }
 
 // GoalServeSoccerTeam represents a team in a soccer match
@@ -78,15 +85,55 @@ type GoalServeSoccerScore struct {
 	Score string `json:"@score"`
 }
 
-// GoalServeSoccerEvents wraps the soccer event array
+// GoalServeSoccerEvents wraps the soccer event array/object
 type GoalServeSoccerEvents struct {
-	Event []GoalServeSoccerEvent `json:"event"`
+	Event []GoalServeSoccerEvent
+}
+
+// UnmarshalJSON handles null events and both a single event object and an array of events
+func (e *GoalServeSoccerEvents) UnmarshalJSON(data []byte) error {
+	e.Event = []GoalServeSoccerEvent{}
+
+	var temp struct {
+		Event json.RawMessage `json:"event"`
+	}
+
+	if len(data) == 0 || data[0] != '{' {
+		// null or an empty string when the match has no events
+		return nil
+	}
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	if len(temp.Event) > 0 && temp.Event[0] == '[' {
+		return json.Unmarshal(temp.Event, &e.Event)
+	} else if len(temp.Event) > 0 && temp.Event[0] == '{' {
+		var singleEvent GoalServeSoccerEvent
+		if err := json.Unmarshal(temp.Event, &singleEvent); err != nil {
+			return err
+		}
+		e.Event = []GoalServeSoccerEvent{singleEvent}
+	}
+
+	return nil
+}
+
+// MarshalJSON writes the events the way Goalserve sends them
+func (e GoalServeSoccerEvents) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Event []GoalServeSoccerEvent `json:"event"`
+	}{e.Event})
 }
 
-// GoalServeSoccerEvent represents a soccer match event
+// GoalServeSoccerEvent represents a soccer match event (goal, card, substitution)
 type GoalServeSoccerEvent struct {
-	Type   string `json:"@type"`
-	Team   string `json:"@team"`
-	Player string `json:"@player"`
-	Time   string `json:"@time"`
+	EventID  string `json:"@eventid"`
+	Type     string `json:"@type"`   // "goal", "yellowcard", "redcard", "subst", ...
+	Team     string `json:"@team"`   // "localteam" or "visitorteam"
+	Player   string `json:"@player"` // For substitutions, the player coming on
+	PlayerID string `json:"@playerId"`
+	Minute   string `json:"@minute"`
+	ExtraMin string `json:"@extra_min"` // Added time, e.g. "3" for 90+3
+	Result   string `json:"@result"`    // Score after a goal, e.g. "[1-0]"
 }
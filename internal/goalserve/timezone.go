@@ -0,0 +1,37 @@
+package goalserve
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+var (
+	feedLocation     *time.Location
+	feedLocationOnce sync.Once
+)
+
+// FeedLocation returns the timezone the feed dates and times are written in.
+// Goalserve sends them in the account's configured zone, set here with
+// GOALSERVE_TIMEZONE as an IANA name (default UTC).
+func FeedLocation() *time.Location {
+	feedLocationOnce.Do(func() {
+		feedLocation = time.UTC
+		name := getEnv("GOALSERVE_TIMEZONE", "UTC")
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			log.Printf("Warning: invalid GOALSERVE_TIMEZONE %q, using UTC: %v", name, err)
+			return
+		}
+		feedLocation = loc
+	})
+
+	return feedLocation
+}
+
+// FeedDay returns the calendar day of t in FeedLocation, as midnight UTC like
+// the DATE columns. The d-N/dN feeds roll over at this day's midnight.
+func FeedDay(t time.Time) time.Time {
+	t = t.In(FeedLocation())
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,381 @@
+// Package h2h collects the meetings between two teams, from stored matches or,
+// when too few are stored, from the Goalserve h2h feeds. The API only reads
+// the feeds from h2h_cache; the sync worker fetches the pairs it queues.
+package h2h
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/dusanbre/otg-sports-api/internal/goalserve"
+	"golang.org/x/sync/singleflight"
+)
+
+// Sources of a Result
+const (
+	SourceLocal     = "local"     // Stored matches only
+	SourceGoalserve = "goalserve" // Stored matches merged with the cached Goalserve feed
+)
+
+// Options tune when the Goalserve feed is consulted
+type Options struct {
+	MinMeetings  int           // Stored meetings needed to skip the feed
+	CacheTTL     time.Duration // How long a fetched feed is served before it is queued again
+	FetchTimeout time.Duration // Upper bound for one feed fetch by the worker, retries included
+}
+
+// DefaultOptions returns the options used when nothing is configured
+func DefaultOptions() Options {
+	return Options{
+		MinMeetings:  5,
+		CacheTTL:     24 * time.Hour,
+		FetchTimeout: 10 * time.Second,
+	}
+}
+
+// OptionsFromEnv reads H2H_MIN_MEETINGS, H2H_CACHE_TTL and H2H_FETCH_TIMEOUT
+// (Go durations such as "24h"), keeping the default for any that are unset
+func OptionsFromEnv() (Options, error) {
+	opts := DefaultOptions()
+
+	if value := os.Getenv("H2H_MIN_MEETINGS"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return opts, fmt.Errorf("invalid H2H_MIN_MEETINGS %q", value)
+		}
+		opts.MinMeetings = n
+	}
+
+	for key, target := range map[string]*time.Duration{
+		"H2H_CACHE_TTL":     &opts.CacheTTL,
+		"H2H_FETCH_TIMEOUT": &opts.FetchTimeout,
+	} {
+		value := os.Getenv(key)
+		if value == "" {
+			continue
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return opts, fmt.Errorf("invalid %s %q: %w", key, value, err)
+		}
+		if d <= 0 {
+			return opts, fmt.Errorf("%s %s is not positive", key, d)
+		}
+		*target = d
+	}
+
+	return opts, nil
+}
+
+// Team names one side of a Result
+type Team struct {
+	ID   int64 // Goalserve team ID
+	Name string
+}
+
+// Result is every known meeting of TeamA and TeamB, latest first, and their tally
+type Result struct {
+	TeamA     Team
+	TeamB     Team
+	Source    string
+	FetchedAt *time.Time // When the Goalserve meetings were fetched, nil for SourceLocal
+	Pending   bool       // The Goalserve meetings are missing or stale and queued for the sync worker
+	Meetings  []database.MatchResult
+	Summary   Summary
+}
+
+// Tally counts meetings from TeamA's point of view
+type Tally struct {
+	Played      int
+	TeamAWins   int
+	Draws       int
+	TeamBWins   int
+	TeamAScored int // Goals, or points in basketball
+	TeamBScored int
+}
+
+// add counts one meeting where team A scored a and team B scored b
+func (t *Tally) add(a, b int) {
+	t.Played++
+	t.TeamAScored += a
+	t.TeamBScored += b
+	switch {
+	case a > b:
+		t.TeamAWins++
+	case a < b:
+		t.TeamBWins++
+	default:
+		t.Draws++
+	}
+}
+
+// Summary splits the meetings by which team was at home
+type Summary struct {
+	Overall   Tally
+	TeamAHome Tally
+	TeamBHome Tally
+}
+
+// Service answers head-to-head queries
+type Service struct {
+	db      *database.DB
+	opts    Options
+	lookups singleflight.Group // Collapses concurrent cache lookups of a pair
+}
+
+// NewService creates a head-to-head service
+func NewService(db *database.DB, opts Options) *Service {
+	return &Service{db: db, opts: opts}
+}
+
+// Get returns the meetings of two teams, given by Goalserve team ID. The
+// stored matches are used alone when there are at least MinMeetings of them;
+// otherwise they are merged with the Goalserve feed cached in h2h_cache, even
+// a stale one. A missing or stale entry queues the pair for FillRequests, so
+// Get never calls Goalserve itself.
+func (s *Service) Get(ctx context.Context, sport string, teamA, teamB int64) (*Result, error) {
+	local, err := s.db.GetMeetings(sport, teamA, teamB)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		TeamA:    Team{ID: teamA},
+		TeamB:    Team{ID: teamB},
+		Source:   SourceLocal,
+		Meetings: local,
+	}
+
+	if len(local) < s.opts.MinMeetings {
+		cached, pending, err := s.cached(sport, teamA, teamB)
+		if err != nil {
+			return nil, err
+		}
+		if cached != nil {
+			result.Source = SourceGoalserve
+			result.FetchedAt = &cached.FetchedAt
+			result.Meetings = merge(local, cached.Meetings)
+		}
+		result.Pending = pending
+	}
+
+	if err := s.nameTeams(sport, result); err != nil {
+		return nil, err
+	}
+	result.Summary = summarize(teamA, result.Meetings)
+	return result, nil
+}
+
+// cacheLookup is the outcome of one cache lookup shared by concurrent callers
+type cacheLookup struct {
+	cached  *database.H2HCache
+	pending bool
+}
+
+// cached returns the cached Goalserve meetings of a pair, or nil when none are
+// cached, and queues the pair when the entry is missing or older than CacheTTL,
+// reporting whether it is queued
+func (s *Service) cached(sport string, teamA, teamB int64) (*database.H2HCache, bool, error) {
+	a, b := min(teamA, teamB), max(teamA, teamB)
+	v, err, _ := s.lookups.Do(fmt.Sprintf("%s/%d/%d", sport, a, b), func() (any, error) {
+		cached, err := s.db.GetH2HCache(sport, a, b)
+		if err != nil {
+			return nil, err
+		}
+		if cached != nil && time.Since(cached.FetchedAt) < s.opts.CacheTTL {
+			return cacheLookup{cached: cached}, nil
+		}
+
+		// Serving what is cached matters more than the refresh
+		if err := s.db.RequestH2H(sport, a, b); err != nil {
+			log.Printf("Warning: failed to queue %s h2h %d-%d: %v", sport, a, b, err)
+			return cacheLookup{cached: cached}, nil
+		}
+		return cacheLookup{cached: cached, pending: true}, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	lookup := v.(cacheLookup)
+	return lookup.cached, lookup.pending, nil
+}
+
+// FillRequests fetches up to limit queued pairs into h2h_cache with client,
+// oldest first, and returns how many it cached. A pair whose feed fails is
+// dropped from the queue until a request queues it again; a fatal Goalserve
+// error stops the run and leaves the rest queued.
+func (s *Service) FillRequests(ctx context.Context, client *goalserve.Client, limit int) (int, error) {
+	requests, err := s.db.GetH2HRequests(limit)
+	if err != nil {
+		return 0, err
+	}
+
+	var filled int
+	for _, r := range requests {
+		if err := ctx.Err(); err != nil {
+			return filled, err
+		}
+
+		meetings, err := s.fetch(ctx, client, r.Sport, r.TeamA, r.TeamB)
+		switch {
+		case ctx.Err() != nil:
+			return filled, ctx.Err()
+		case goalserve.IsFatal(err):
+			return filled, err
+		case err != nil:
+			log.Printf("Warning: failed to fetch %s h2h %d-%d from GoalServe: %v", r.Sport, r.TeamA, r.TeamB, err)
+		default:
+			if err := s.db.SaveH2HCache(r.Sport, r.TeamA, r.TeamB, meetings); err != nil {
+				return filled, err
+			}
+			filled++
+		}
+
+		if err := s.db.DeleteH2HRequest(r.Sport, r.TeamA, r.TeamB); err != nil {
+			return filled, err
+		}
+	}
+	return filled, nil
+}
+
+// fetch downloads the Goalserve h2h feed of sport and keeps its played meetings
+func (s *Service) fetch(ctx context.Context, client *goalserve.Client, sport string, teamA, teamB int64) ([]database.MatchResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.opts.FetchTimeout)
+	defer cancel()
+
+	var feed *goalserve.GoalServeH2H
+	var err error
+	switch sport {
+	case "soccer":
+		feed, err = client.FetchSoccerH2H(ctx, teamA, teamB)
+	case "basketball":
+		feed, err = client.FetchBasketballH2H(ctx, teamA, teamB)
+	default:
+		return nil, fmt.Errorf("unsupported sport: %s", sport)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return fromFeed(feed), nil
+}
+
+// fromFeed converts the feed meetings with a date and a final score, latest first
+func fromFeed(feed *goalserve.GoalServeH2H) []database.MatchResult {
+	loc := goalserve.FeedLocation()
+	var meetings []database.MatchResult
+	for _, category := range feed.Top50.Categories {
+		leagueID, _ := strconv.ParseInt(category.ID, 10, 64)
+		for _, m := range category.Matches {
+			day, err := time.ParseInLocation("02.01.2006", strings.TrimSpace(m.Date), loc)
+			if err != nil {
+				continue
+			}
+			home, errHome := strconv.Atoi(strings.TrimSpace(m.Team1Score))
+			away, errAway := strconv.Atoi(strings.TrimSpace(m.Team2Score))
+			homeID, errHomeID := strconv.ParseInt(m.Team1ID, 10, 64)
+			awayID, errAwayID := strconv.ParseInt(m.Team2ID, 10, 64)
+			if errHome != nil || errAway != nil || errHomeID != nil || errAwayID != nil {
+				continue // Not played, or not a score we can count
+			}
+
+			matchID, _ := strconv.ParseInt(m.ID, 10, 64)
+			meetings = append(meetings, database.MatchResult{
+				MatchID:      matchID,
+				KickoffAt:    day.UTC(),
+				HomeTeamID:   homeID,
+				HomeTeamName: m.Team1,
+				AwayTeamID:   awayID,
+				AwayTeamName: m.Team2,
+				HomeScore:    home,
+				AwayScore:    away,
+				LeagueID:     leagueID,
+				LeagueName:   category.Name,
+			})
+		}
+	}
+	sortLatestFirst(meetings)
+	return meetings
+}
+
+// merge adds the remote meetings missing from local. The feed only has match
+// days, so a meeting is the same when its ID or its day and sides match.
+func merge(local, remote []database.MatchResult) []database.MatchResult {
+	ids := make(map[int64]bool, len(local))
+	days := make(map[string]bool, len(local))
+	for _, m := range local {
+		ids[m.MatchID] = true
+		days[dayKey(m)] = true
+	}
+
+	merged := slices.Clone(local)
+	for _, m := range remote {
+		if (m.MatchID != 0 && ids[m.MatchID]) || days[dayKey(m)] {
+			continue
+		}
+		merged = append(merged, m)
+	}
+	sortLatestFirst(merged)
+	return merged
+}
+
+// dayKey identifies a meeting by its day in the feed's timezone and its sides
+func dayKey(m database.MatchResult) string {
+	day := m.KickoffAt.In(goalserve.FeedLocation()).Format("2006-01-02")
+	return fmt.Sprintf("%s/%d/%d", day, m.HomeTeamID, m.AwayTeamID)
+}
+
+func sortLatestFirst(meetings []database.MatchResult) {
+	slices.SortStableFunc(meetings, func(a, b database.MatchResult) int {
+		return cmp.Or(b.KickoffAt.Compare(a.KickoffAt), cmp.Compare(b.MatchID, a.MatchID))
+	})
+}
+
+// nameTeams names both sides from the teams table, or from their latest
+// meeting when the team is not stored
+func (s *Service) nameTeams(sport string, result *Result) error {
+	for _, team := range []*Team{&result.TeamA, &result.TeamB} {
+		stored, err := s.db.GetTeam(sport, team.ID)
+		if err != nil {
+			return err
+		}
+		if stored != nil {
+			team.Name = stored.Name
+			continue
+		}
+		for _, m := range result.Meetings {
+			if m.HomeTeamID == team.ID && m.HomeTeamName != "" {
+				team.Name = m.HomeTeamName
+				break
+			}
+			if m.AwayTeamID == team.ID && m.AwayTeamName != "" {
+				team.Name = m.AwayTeamName
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// summarize tallies meetings from teamA's point of view
+func summarize(teamA int64, meetings []database.MatchResult) Summary {
+	var s Summary
+	for _, m := range meetings {
+		if m.HomeTeamID == teamA {
+			s.Overall.add(m.HomeScore, m.AwayScore)
+			s.TeamAHome.add(m.HomeScore, m.AwayScore)
+		} else {
+			s.Overall.add(m.AwayScore, m.HomeScore)
+			s.TeamBHome.add(m.AwayScore, m.HomeScore)
+		}
+	}
+	return s
+}
@@ -0,0 +1,185 @@
+package predictions
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/dusanbre/otg-sports-api/internal/ratings"
+)
+
+const (
+	calibrationBuckets = 10
+	minProbability     = 1e-15 // Floor of the log loss of an outcome predicted impossible
+)
+
+// Report scores the predictions of finished matches against their results
+type Report struct {
+	Sport    string
+	From     time.Time
+	To       time.Time
+	Matches  int     // Matches predicted
+	Skipped  int     // Finished matches in range that could not be predicted
+	Brier    float64 // Mean over matches of the squared errors of the home, draw and away probabilities
+	LogLoss  float64 // Mean negative log probability of the actual outcome
+	ScoreMAE float64 // Mean absolute error of the expected score of a side
+	Buckets  []Bucket
+}
+
+// Bucket compares the outcome probabilities predicted in a range with how
+// often those outcomes happened. Basketball has no draw probabilities to count.
+type Bucket struct {
+	From        float64
+	To          float64
+	Predictions int
+	Predicted   float64 // Mean predicted probability
+	Observed    float64 // Share of the predicted outcomes that happened
+}
+
+// Backtest predicts every finished match of sport kicking off in [from, to)
+// as it would have been predicted on its day, from its league's results
+// before that day, and scores the predictions. Basketball ratings going into
+// each match are replayed from all stored results.
+func (s *Service) Backtest(ctx context.Context, sport string, from, to time.Time) (*Report, error) {
+	results, err := s.db.GetLeagueResults(sport, nil, from.Add(-s.opts.Lookback), to)
+	if err != nil {
+		return nil, err
+	}
+	byLeague := make(map[int64][]database.MatchResult)
+	for _, r := range results {
+		byLeague[r.LeagueID] = append(byLeague[r.LeagueID], r)
+	}
+
+	var before map[int64][2]float64
+	if sport == "basketball" {
+		if before, err = s.ratingsBefore(sport); err != nil {
+			return nil, err
+		}
+	}
+
+	report := newReport(sport, from, to)
+	for _, leagueID := range slices.Sorted(maps.Keys(byLeague)) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		history := byLeague[leagueID]
+		var model *league
+		var modelDay time.Time
+		for _, m := range history {
+			if m.KickoffAt.Before(from) {
+				continue
+			}
+
+			day := m.KickoffAt.UTC().Truncate(24 * time.Hour)
+			if !day.Equal(modelDay) {
+				start := sort.Search(len(history), func(i int) bool { return !history[i].KickoffAt.Before(day.Add(-s.opts.Lookback)) })
+				end := sort.Search(len(history), func(i int) bool { return !history[i].KickoffAt.Before(day) })
+				model, modelDay = s.fit(sport, history[start:end], day), day
+			}
+			if model == nil {
+				report.Skipped++
+				continue
+			}
+
+			var home, away float64
+			if model.poisson == nil {
+				teams, ok := before[m.MatchID]
+				if !ok {
+					report.Skipped++
+					continue
+				}
+				home, away = teams[0], teams[1]
+			}
+			report.add(model.predict(m.HomeTeamID, m.AwayTeamID, home, away), m.HomeScore, m.AwayScore)
+		}
+	}
+
+	report.finish()
+	return report, nil
+}
+
+// ratingsBefore replays the Elo ratings of every finished match of sport and
+// returns both teams' ratings going into each, home first, keyed by match ID
+func (s *Service) ratingsBefore(sport string) (map[int64][2]float64, error) {
+	p, ok := ratings.ParamsFor(sport)
+	if !ok {
+		return nil, fmt.Errorf("unsupported sport: %s", sport)
+	}
+	matches, err := s.db.GetRatingMatches(sport, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	before := make(map[int64][2]float64, len(matches))
+	for _, r := range p.Rate(sport, matches, make(map[int64]database.TeamRating)) {
+		teams := before[r.MatchID]
+		if r.Home {
+			teams[0] = r.RatingBefore
+		} else {
+			teams[1] = r.RatingBefore
+		}
+		before[r.MatchID] = teams
+	}
+	return before, nil
+}
+
+func newReport(sport string, from, to time.Time) *Report {
+	r := &Report{Sport: sport, From: from, To: to, Buckets: make([]Bucket, calibrationBuckets)}
+	for i := range r.Buckets {
+		r.Buckets[i].From = float64(i) / calibrationBuckets
+		r.Buckets[i].To = float64(i+1) / calibrationBuckets
+	}
+	return r
+}
+
+// add scores one prediction. Totals are summed here and averaged by finish.
+func (r *Report) add(p Prediction, homeScore, awayScore int) {
+	probabilities := [3]float64{p.Home, p.Draw, p.Away}
+	actual := 1
+	switch {
+	case homeScore > awayScore:
+		actual = 0
+	case homeScore < awayScore:
+		actual = 2
+	}
+
+	r.Matches++
+	for outcome, probability := range probabilities {
+		observed := 0.0
+		if outcome == actual {
+			observed = 1
+		}
+		r.Brier += (probability - observed) * (probability - observed)
+
+		if p.Model == ModelRating && outcome == 1 {
+			continue
+		}
+		b := &r.Buckets[min(int(probability*calibrationBuckets), calibrationBuckets-1)]
+		b.Predictions++
+		b.Predicted += probability
+		b.Observed += observed
+	}
+	r.LogLoss -= math.Log(max(probabilities[actual], minProbability))
+	r.ScoreMAE += (math.Abs(p.HomeScore-float64(homeScore)) + math.Abs(p.AwayScore-float64(awayScore))) / 2
+}
+
+func (r *Report) finish() {
+	if r.Matches > 0 {
+		n := float64(r.Matches)
+		r.Brier /= n
+		r.LogLoss /= n
+		r.ScoreMAE /= n
+	}
+	for i := range r.Buckets {
+		if b := &r.Buckets[i]; b.Predictions > 0 {
+			b.Predicted /= float64(b.Predictions)
+			b.Observed /= float64(b.Predictions)
+		}
+	}
+}
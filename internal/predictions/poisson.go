@@ -0,0 +1,147 @@
+package predictions
+
+import (
+	"math"
+	"time"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+)
+
+const (
+	fitIterations = 50
+	halfLife      = 180 * 24 * time.Hour // Age at which a result counts half
+	priorMatches  = 3                    // Matches of league average play each team's strength starts from
+	maxGoals      = 10                   // Goals per side summed over for outcome probabilities
+)
+
+// Poisson is a soccer league's goal model. Each side's goals follow a
+// Poisson distribution with mean Mean, scaled by its attack, the opponent's
+// defence and, for the home side, HomeAdvantage.
+type Poisson struct {
+	Matches       int               // Results fit
+	Mean          float64           // Goals per match of an average side away from home
+	HomeAdvantage float64           // Multiplier of the home side's goals
+	Attack        map[int64]float64 // Keyed by Goalserve team ID, 1 for the league average
+	Defence       map[int64]float64 // Multiplier of goals conceded, above 1 for a weaker defence
+}
+
+// FitPoisson fits the attack and defence of every team in a league's results
+// kicking off before at, weighting results by age. It returns nil when the
+// results have no goals to fit.
+func FitPoisson(results []database.MatchResult, at time.Time) *Poisson {
+	weights := make([]float64, len(results))
+	var played, homeGoals, awayGoals float64
+	for i, r := range results {
+		weights[i] = math.Pow(0.5, float64(at.Sub(r.KickoffAt))/float64(halfLife))
+		played += weights[i]
+		homeGoals += weights[i] * float64(r.HomeScore)
+		awayGoals += weights[i] * float64(r.AwayScore)
+	}
+	if homeGoals == 0 || awayGoals == 0 {
+		return nil
+	}
+
+	m := &Poisson{
+		Matches:       len(results),
+		Mean:          awayGoals / played,
+		HomeAdvantage: homeGoals / awayGoals,
+		Attack:        make(map[int64]float64),
+		Defence:       make(map[int64]float64),
+	}
+	for _, r := range results {
+		m.Attack[r.HomeTeamID], m.Attack[r.AwayTeamID] = 1, 1
+		m.Defence[r.HomeTeamID], m.Defence[r.AwayTeamID] = 1, 1
+	}
+
+	// Each step solves for one set of parameters with the others held, which
+	// is the maximum likelihood update of a multiplicative Poisson model. The
+	// prior adds goals at the league rate, so teams with few results stay
+	// near average.
+	prior := priorMatches * m.Mean
+	scored := make(map[int64]float64, len(m.Attack))
+	expected := make(map[int64]float64, len(m.Attack))
+	for range fitIterations {
+		clear(scored)
+		clear(expected)
+		for i, r := range results {
+			w := weights[i]
+			scored[r.HomeTeamID] += w * float64(r.HomeScore)
+			scored[r.AwayTeamID] += w * float64(r.AwayScore)
+			expected[r.HomeTeamID] += w * m.Mean * m.HomeAdvantage * m.Defence[r.AwayTeamID]
+			expected[r.AwayTeamID] += w * m.Mean * m.Defence[r.HomeTeamID]
+		}
+		for team := range m.Attack {
+			m.Attack[team] = (scored[team] + prior) / (expected[team] + prior)
+		}
+
+		clear(scored)
+		clear(expected)
+		for i, r := range results {
+			w := weights[i]
+			scored[r.HomeTeamID] += w * float64(r.AwayScore)
+			scored[r.AwayTeamID] += w * float64(r.HomeScore)
+			expected[r.HomeTeamID] += w * m.Mean * m.Attack[r.AwayTeamID]
+			expected[r.AwayTeamID] += w * m.Mean * m.HomeAdvantage * m.Attack[r.HomeTeamID]
+		}
+		for team := range m.Defence {
+			m.Defence[team] = (scored[team] + prior) / (expected[team] + prior)
+		}
+
+		var homeExpected, awayExpected float64
+		for i, r := range results {
+			homeExpected += weights[i] * m.Attack[r.HomeTeamID] * m.Defence[r.AwayTeamID]
+			awayExpected += weights[i] * m.Attack[r.AwayTeamID] * m.Defence[r.HomeTeamID]
+		}
+		m.Mean = awayGoals / awayExpected
+		m.HomeAdvantage = homeGoals / (m.Mean * homeExpected)
+	}
+	return m
+}
+
+// Predict returns the outcome probabilities and expected goals of home
+// hosting away. A team missing from the fit plays at the league average.
+func (m *Poisson) Predict(home, away int64) Prediction {
+	homeMean := m.Mean * m.HomeAdvantage * strength(m.Attack, home) * strength(m.Defence, away)
+	awayMean := m.Mean * strength(m.Attack, away) * strength(m.Defence, home)
+
+	homeGoals := poisson(homeMean)
+	awayGoals := poisson(awayMean)
+	p := Prediction{Model: ModelPoisson, HomeScore: homeMean, AwayScore: awayMean}
+	var total float64
+	for h, ph := range homeGoals {
+		for a, pa := range awayGoals {
+			switch {
+			case h > a:
+				p.Home += ph * pa
+			case h < a:
+				p.Away += ph * pa
+			default:
+				p.Draw += ph * pa
+			}
+			total += ph * pa
+		}
+	}
+
+	// Scores beyond maxGoals are left out, so the rest is scaled back to 1
+	p.Home /= total
+	p.Draw /= total
+	p.Away /= total
+	return p
+}
+
+func strength(strengths map[int64]float64, team int64) float64 {
+	if s, ok := strengths[team]; ok {
+		return s
+	}
+	return 1
+}
+
+// poisson returns the probability of 0 to maxGoals goals at mean
+func poisson(mean float64) []float64 {
+	p := make([]float64, maxGoals+1)
+	p[0] = math.Exp(-mean)
+	for k := 1; k <= maxGoals; k++ {
+		p[k] = p[k-1] * mean / float64(k)
+	}
+	return p
+}
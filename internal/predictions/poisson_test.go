@@ -0,0 +1,134 @@
+package predictions
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/dusanbre/otg-sports-api/internal/ratings"
+)
+
+// roundRobin plays a double round robin of four teams a week apart, ending
+// before at. Team 1 scores most and team 4 least.
+func roundRobin(at time.Time) []database.MatchResult {
+	goals := map[int64]int{1: 3, 2: 2, 3: 1, 4: 0}
+	var results []database.MatchResult
+	kickoff := at.AddDate(0, 0, -7*12)
+	for home := int64(1); home <= 4; home++ {
+		for away := int64(1); away <= 4; away++ {
+			if home == away {
+				continue
+			}
+			results = append(results, database.MatchResult{
+				KickoffAt:  kickoff,
+				HomeTeamID: home,
+				AwayTeamID: away,
+				HomeScore:  goals[home] + 1,
+				AwayScore:  goals[away],
+			})
+			kickoff = kickoff.AddDate(0, 0, 7)
+		}
+	}
+	return results
+}
+
+func TestPoissonProbabilitiesSumToOne(t *testing.T) {
+	at := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	model := FitPoisson(roundRobin(at), at)
+	if model == nil {
+		t.Fatal("FitPoisson returned nil for a league with goals")
+	}
+	// Attack far beyond maxGoals, so most of the score grid is cut off
+	lopsided := &Poisson{Mean: 8, HomeAdvantage: 1.5, Attack: map[int64]float64{1: 2}, Defence: map[int64]float64{2: 1.5}}
+
+	tests := []struct {
+		name       string
+		model      *Poisson
+		home, away int64
+	}{
+		{"strongest at home", model, 1, 4},
+		{"weakest at home", model, 4, 1},
+		{"even sides", model, 2, 3},
+		{"team missing from the fit", model, 1, 99},
+		{"both missing", model, 98, 99},
+		{"scores past maxGoals", lopsided, 1, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.model.Predict(tt.home, tt.away)
+			for _, prob := range []float64{p.Home, p.Draw, p.Away} {
+				if prob < 0 || prob > 1 {
+					t.Errorf("probability %v out of range in %+v", prob, p)
+				}
+			}
+			if sum := p.Home + p.Draw + p.Away; math.Abs(sum-1) > 1e-9 {
+				t.Errorf("probabilities sum to %v, want 1 (%+v)", sum, p)
+			}
+		})
+	}
+}
+
+func TestFitPoisson(t *testing.T) {
+	at := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		results []database.MatchResult
+		wantNil bool
+	}{
+		{"no results", nil, true},
+		{"no away goals", []database.MatchResult{{KickoffAt: at.AddDate(0, 0, -1), HomeTeamID: 1, AwayTeamID: 2, HomeScore: 2}}, true},
+		{"league", roundRobin(at), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := FitPoisson(tt.results, at)
+			if (model == nil) != tt.wantNil {
+				t.Fatalf("FitPoisson = %+v, want nil %v", model, tt.wantNil)
+			}
+			if model == nil {
+				return
+			}
+			if model.Matches != len(tt.results) {
+				t.Errorf("Matches = %d, want %d", model.Matches, len(tt.results))
+			}
+			if model.HomeAdvantage <= 1 {
+				t.Errorf("HomeAdvantage = %v, want above 1 for a league won by home sides", model.HomeAdvantage)
+			}
+			if !(model.Attack[1] > model.Attack[2] && model.Attack[2] > model.Attack[3] && model.Attack[3] > model.Attack[4]) {
+				t.Errorf("Attack = %v, want team 1 strongest down to team 4", model.Attack)
+			}
+			if p := model.Predict(1, 4); p.Home <= p.Away {
+				t.Errorf("Predict(1, 4) = %+v, want the stronger home side favoured", p)
+			}
+		})
+	}
+}
+
+func TestPredictRatingSumsToOne(t *testing.T) {
+	p, _ := ratings.ParamsFor("basketball")
+
+	tests := []struct {
+		name       string
+		home, away float64
+	}{
+		{"even", 1500, 1500},
+		{"home favourite", 1700, 1400},
+		{"away favourite", 1300, 1800},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pred := PredictRating(p, tt.home, tt.away, 200)
+			if sum := pred.Home + pred.Draw + pred.Away; math.Abs(sum-1) > 1e-9 {
+				t.Errorf("probabilities sum to %v, want 1 (%+v)", sum, pred)
+			}
+			if pred.Draw != 0 {
+				t.Errorf("Draw = %v, want 0 in basketball", pred.Draw)
+			}
+			if total := pred.HomeScore + pred.AwayScore; math.Abs(total-200) > 1e-9 {
+				t.Errorf("expected points sum to %v, want the league average 200", total)
+			}
+		})
+	}
+}
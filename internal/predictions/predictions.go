@@ -0,0 +1,176 @@
+// Package predictions estimates the outcome and score of scheduled matches
+// from stored results: a Poisson attack/defence model fit per league for
+// soccer, and the Elo rating difference for basketball.
+package predictions
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/dusanbre/otg-sports-api/internal/ratings"
+)
+
+// Models behind a Prediction
+const (
+	ModelPoisson = "poisson" // Soccer, fit per league
+	ModelRating  = "elo"     // Basketball, from team ratings
+)
+
+// Prediction is the expected outcome of a match
+type Prediction struct {
+	Model     string
+	Home      float64 // Probability of a home win
+	Draw      float64
+	Away      float64
+	HomeScore float64 // Expected goals, or points in basketball
+	AwayScore float64
+}
+
+// Options tune which results a league's model is built from
+type Options struct {
+	Lookback   time.Duration // How far back from a match its league's results are read
+	MinMatches int           // League results in the lookback needed to predict
+}
+
+// DefaultOptions returns the options used when nothing is configured
+func DefaultOptions() Options {
+	return Options{
+		Lookback:   365 * 24 * time.Hour,
+		MinMatches: 30,
+	}
+}
+
+// OptionsFromEnv reads PREDICTION_LOOKBACK (a Go duration such as "8760h")
+// and PREDICTION_MIN_MATCHES, keeping the default for any that are unset
+func OptionsFromEnv() (Options, error) {
+	opts := DefaultOptions()
+
+	if value := os.Getenv("PREDICTION_LOOKBACK"); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return opts, fmt.Errorf("invalid PREDICTION_LOOKBACK %q: %w", value, err)
+		}
+		if d <= 0 {
+			return opts, fmt.Errorf("PREDICTION_LOOKBACK %s is not positive", d)
+		}
+		opts.Lookback = d
+	}
+
+	if value := os.Getenv("PREDICTION_MIN_MATCHES"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return opts, fmt.Errorf("invalid PREDICTION_MIN_MATCHES %q", value)
+		}
+		opts.MinMatches = n
+	}
+
+	return opts, nil
+}
+
+// Service predicts matches from the stored results
+type Service struct {
+	db   *database.DB
+	opts Options
+}
+
+// NewService creates a prediction service
+func NewService(db *database.DB, opts Options) *Service {
+	return &Service{db: db, opts: opts}
+}
+
+// Predict returns the predictions of the scheduled matches of sport among
+// matchIDs, keyed by match ID. Matches in a league with fewer than
+// MinMatches results in the lookback are left out.
+func (s *Service) Predict(sport string, matchIDs []int64) (map[int64]Prediction, error) {
+	fixtures, err := s.db.GetScheduledFixtures(sport, matchIDs)
+	if err != nil {
+		return nil, err
+	}
+	predictions := make(map[int64]Prediction, len(fixtures))
+	if len(fixtures) == 0 {
+		return predictions, nil
+	}
+
+	var leagueIDs, teamIDs []int64
+	for _, f := range fixtures {
+		leagueIDs = append(leagueIDs, f.LeagueID)
+		teamIDs = append(teamIDs, f.HomeTeamID, f.AwayTeamID)
+	}
+
+	// Scheduled matches are predicted from today's form, whatever their kickoff
+	now := time.Now()
+	results, err := s.db.GetLeagueResults(sport, leagueIDs, now.Add(-s.opts.Lookback), now)
+	if err != nil {
+		return nil, err
+	}
+	byLeague := make(map[int64][]database.MatchResult)
+	for _, r := range results {
+		byLeague[r.LeagueID] = append(byLeague[r.LeagueID], r)
+	}
+
+	var latest map[int64]database.TeamRating
+	if sport == "basketball" {
+		if latest, err = s.db.GetLatestRatings(sport, teamIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	models := make(map[int64]*league)
+	for _, f := range fixtures {
+		model, ok := models[f.LeagueID]
+		if !ok {
+			model = s.fit(sport, byLeague[f.LeagueID], now)
+			models[f.LeagueID] = model
+		}
+		if model == nil {
+			continue
+		}
+
+		var home, away float64
+		if model.poisson == nil {
+			home = model.rating.Before(latest[f.HomeTeamID], f.KickoffAt)
+			away = model.rating.Before(latest[f.AwayTeamID], f.KickoffAt)
+		}
+		predictions[f.MatchID] = model.predict(f.HomeTeamID, f.AwayTeamID, home, away)
+	}
+
+	return predictions, nil
+}
+
+// league is the model of one league's matches at a point in time
+type league struct {
+	poisson  *Poisson       // Soccer
+	rating   ratings.Params // Basketball
+	avgTotal float64
+}
+
+// fit builds the model of a league of sport from its results in the lookback
+// before at, and returns nil when they are too few
+func (s *Service) fit(sport string, results []database.MatchResult, at time.Time) *league {
+	if len(results) < s.opts.MinMatches {
+		return nil
+	}
+	switch sport {
+	case "soccer":
+		if m := FitPoisson(results, at); m != nil {
+			return &league{poisson: m}
+		}
+	case "basketball":
+		if p, ok := ratings.ParamsFor(sport); ok {
+			return &league{rating: p, avgTotal: averageTotal(results)}
+		}
+	}
+	return nil
+}
+
+// predict predicts home hosting away. homeRating and awayRating are the
+// teams' Elo ratings going into the match, which only basketball reads.
+func (l *league) predict(home, away int64, homeRating, awayRating float64) Prediction {
+	if l.poisson != nil {
+		return l.poisson.Predict(home, away)
+	}
+	return PredictRating(l.rating, homeRating, awayRating, l.avgTotal)
+}
@@ -0,0 +1,38 @@
+package predictions
+
+import (
+	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/dusanbre/otg-sports-api/internal/ratings"
+)
+
+// eloPerPoint converts a rating difference to a points margin, as in
+// FiveThirtyEight's NBA model
+const eloPerPoint = 28
+
+// PredictRating returns the outcome probabilities and expected points of a
+// basketball match from both teams' Elo ratings going into it. avgTotal is
+// the league's average combined score, split by the expected margin.
+// Basketball has no draws.
+func PredictRating(p ratings.Params, home, away, avgTotal float64) Prediction {
+	win := p.Expected(home, away)
+	margin := (home + p.HomeAdvantage - away) / eloPerPoint
+	return Prediction{
+		Model:     ModelRating,
+		Home:      win,
+		Away:      1 - win,
+		HomeScore: (avgTotal + margin) / 2,
+		AwayScore: (avgTotal - margin) / 2,
+	}
+}
+
+// averageTotal is the mean combined score of results, zero for none
+func averageTotal(results []database.MatchResult) float64 {
+	if len(results) == 0 {
+		return 0
+	}
+	total := 0
+	for _, r := range results {
+		total += r.HomeScore + r.AwayScore
+	}
+	return float64(total) / float64(len(results))
+}
@@ -0,0 +1,160 @@
+// Package ratings keeps Elo ratings of teams from their finished matches.
+package ratings
+
+import (
+	"math"
+	"time"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+)
+
+// Params tune the Elo model of one sport
+type Params struct {
+	Initial       float64 // Rating of a team before its first match, and the mean ratings regress to
+	K             float64 // Largest change one match can make before margin scaling
+	HomeAdvantage float64 // Rating points added to the home team when predicting a result
+	Regression    float64 // Share of its distance to Initial a rating loses between seasons
+
+	// OffSeason is the longest gap between two of a team's matches within a
+	// season. Season names are per competition, so a team moving between its
+	// league and a cup is not a new season; a break longer than this is.
+	OffSeason time.Duration
+
+	// marginMultiplier scales K by the winning margin. eloDiff is the winner's
+	// rating minus the loser's, home advantage included, so expected blowouts
+	// move ratings less.
+	marginMultiplier func(margin int, eloDiff float64) float64
+}
+
+// ParamsFor returns the model of sport, and false for a sport without one
+func ParamsFor(sport string) (Params, bool) {
+	p, ok := params[sport]
+	return p, ok
+}
+
+// params holds the model of each rated sport. Soccer follows the World
+// Football Elo margin scaling, basketball FiveThirtyEight's NBA model.
+var params = map[string]Params{
+	"soccer": {
+		Initial:       1500,
+		K:             20,
+		HomeAdvantage: 65,
+		Regression:    0.2,
+		OffSeason:     60 * 24 * time.Hour, // Longer than winter breaks in most leagues
+		marginMultiplier: func(margin int, _ float64) float64 {
+			switch {
+			case margin <= 1:
+				return 1
+			case margin == 2:
+				return 1.5
+			}
+			return (11 + float64(margin)) / 8
+		},
+	},
+	"basketball": {
+		Initial:       1500,
+		K:             20,
+		HomeAdvantage: 100,
+		Regression:    0.25,
+		OffSeason:     90 * 24 * time.Hour,
+		marginMultiplier: func(margin int, eloDiff float64) float64 {
+			return math.Pow(float64(margin)+3, 0.8) / (7.5 + 0.006*eloDiff)
+		},
+	},
+}
+
+// Expected is the home team's expected score, 1 for a certain win, given
+// both ratings before home advantage
+func (p Params) Expected(home, away float64) float64 {
+	return 1 / (1 + math.Pow(10, (away-home-p.HomeAdvantage)/400))
+}
+
+// Change is the rating points the home team gains from a result, and the
+// away team loses
+func (p Params) Change(home, away float64, homeScore, awayScore int) float64 {
+	expected := p.Expected(home, away)
+	actual := 0.5
+	eloDiff := 0.0
+	switch {
+	case homeScore > awayScore:
+		actual = 1
+		eloDiff = home + p.HomeAdvantage - away
+	case homeScore < awayScore:
+		actual = 0
+		eloDiff = away - home - p.HomeAdvantage
+	}
+
+	multiplier := 1.0
+	if margin := homeScore - awayScore; margin != 0 {
+		multiplier = p.marginMultiplier(abs(margin), eloDiff)
+	}
+	return p.K * multiplier * (actual - expected)
+}
+
+// Regress pulls a rating toward Initial over an off-season
+func (p Params) Regress(rating float64) float64 {
+	return p.Initial + (rating-p.Initial)*(1-p.Regression)
+}
+
+// Rate replays matches, oldest first, from the teams' latest ratings in
+// states, keyed by teams row ID. A team's rating regresses before its first
+// match after an off-season. It returns the rating of each side after every
+// match; states is updated to the latest ratings.
+func (p Params) Rate(sport string, matches []database.RatingMatch, states map[int64]database.TeamRating) []database.TeamRating {
+	ratings := make([]database.TeamRating, 0, 2*len(matches))
+	for _, m := range matches {
+		home := p.next(states[m.HomeRef], m, true)
+		away := p.next(states[m.AwayRef], m, false)
+		change := p.Change(home.RatingBefore, away.RatingBefore, m.HomeScore, m.AwayScore)
+		home.Rating = home.RatingBefore + change
+		away.Rating = away.RatingBefore - change
+
+		home.Sport, away.Sport = sport, sport
+		ratings = append(ratings, home, away)
+		states[home.TeamRef] = home
+		states[away.TeamRef] = away
+	}
+	return ratings
+}
+
+// next starts a team's rating for match m from its latest rating, which is
+// empty for a team not rated yet. A match without a season keeps the team's
+// last known one.
+func (p Params) next(latest database.TeamRating, m database.RatingMatch, home bool) database.TeamRating {
+	r := database.TeamRating{
+		TeamRef:      m.AwayRef,
+		OpponentRef:  m.HomeRef,
+		MatchID:      m.MatchID,
+		KickoffAt:    m.KickoffAt,
+		Season:       m.Season,
+		Home:         home,
+		RatingBefore: p.Before(latest, m.KickoffAt),
+	}
+	if home {
+		r.TeamRef, r.OpponentRef = m.HomeRef, m.AwayRef
+	}
+	if r.Season == "" {
+		r.Season = latest.Season
+	}
+	return r
+}
+
+// Before is a team's rating going into a match kicking off at kickoff given
+// its latest rating: Initial for a team not rated yet, and regressed when the
+// team has not played for longer than OffSeason
+func (p Params) Before(latest database.TeamRating, kickoff time.Time) float64 {
+	switch {
+	case latest.TeamRef == 0:
+		return p.Initial
+	case kickoff.Sub(latest.KickoffAt) > p.OffSeason:
+		return p.Regress(latest.Rating)
+	}
+	return latest.Rating
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
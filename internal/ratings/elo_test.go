@@ -0,0 +1,144 @@
+package ratings
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+)
+
+func TestRateDoesNotRegressBetweenCompetitions(t *testing.T) {
+	p, _ := ParamsFor("soccer")
+	start := time.Date(2026, time.March, 1, 18, 0, 0, 0, time.UTC)
+
+	// Team 1 alternates between a January-start league and a July-start cup,
+	// whose season names never match
+	var matches []database.RatingMatch
+	for i := range 8 {
+		season := "2026"
+		if i%2 == 1 {
+			season = "2025/2026"
+		}
+		matches = append(matches, database.RatingMatch{
+			MatchID:   int64(i + 1),
+			KickoffAt: start.AddDate(0, 0, 7*i),
+			Season:    season,
+			HomeRef:   1,
+			AwayRef:   int64(i + 2),
+			HomeScore: 2,
+			AwayScore: 0,
+		})
+	}
+
+	ratings := p.Rate("soccer", matches, make(map[int64]database.TeamRating))
+	var previous float64
+	for _, r := range ratings {
+		if r.TeamRef != 1 {
+			continue
+		}
+		if previous != 0 && r.RatingBefore != previous {
+			t.Errorf("match %d: rating before %.2f, want the previous rating %.2f", r.MatchID, r.RatingBefore, previous)
+		}
+		previous = r.Rating
+	}
+}
+
+func TestBeforeRegressesAfterOffSeason(t *testing.T) {
+	p, _ := ParamsFor("soccer")
+	last := time.Date(2026, time.May, 24, 15, 0, 0, 0, time.UTC)
+	latest := database.TeamRating{TeamRef: 1, KickoffAt: last, Rating: 1600}
+
+	tests := []struct {
+		name    string
+		latest  database.TeamRating
+		kickoff time.Time
+		want    float64
+	}{
+		{"not rated yet", database.TeamRating{}, last, 1500},
+		{"next week", latest, last.AddDate(0, 0, 7), 1600},
+		{"international break", latest, last.AddDate(0, 0, 16), 1600},
+		{"exactly the off-season", latest, last.Add(p.OffSeason), 1600},
+		{"after the summer", latest, last.AddDate(0, 0, 84), 1580},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.Before(tt.latest, tt.kickoff); got != tt.want {
+				t.Errorf("Before = %.2f, want %.2f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChangeScalesWithMargin(t *testing.T) {
+	tests := []struct {
+		name       string
+		sport      string
+		home, away float64
+		homeScore  int
+		awayScore  int
+		want       float64
+	}{
+		// Home advantage offsets the rating gap, so the home side is expected 0.5
+		{"soccer draw", "soccer", 1435, 1500, 1, 1, 0},
+		{"soccer one goal", "soccer", 1435, 1500, 1, 0, 10},
+		{"soccer two goals", "soccer", 1435, 1500, 2, 0, 15},
+		{"soccer three goals", "soccer", 1435, 1500, 3, 0, 17.5},
+		{"soccer five goals", "soccer", 1435, 1500, 5, 0, 20},
+		{"soccer away by two", "soccer", 1435, 1500, 0, 2, -15},
+		{"basketball even", "basketball", 1400, 1500, 87, 80, 8.412764593069245},
+		// The same margin moves a favourite less than an underdog
+		{"basketball favourite by ten", "basketball", 1600, 1500, 90, 80, 4.298672675610004},
+		{"basketball underdog by ten", "basketball", 1300, 1500, 90, 80, 14.439749740533397},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, _ := ParamsFor(tt.sport)
+			got := p.Change(tt.home, tt.away, tt.homeScore, tt.awayScore)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Change = %.6f, want %.6f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateStartsFromLatestRating(t *testing.T) {
+	p, _ := ParamsFor("soccer")
+	last := time.Date(2026, time.May, 24, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		kickoff    time.Time
+		wantBefore float64 // Team 1's rating going into the match
+	}{
+		{"same season", last.AddDate(0, 0, 7), 1600},
+		{"after the off-season", last.AddDate(0, 0, 84), 1580},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			states := map[int64]database.TeamRating{
+				1: {TeamRef: 1, KickoffAt: last, Rating: 1600, Season: "2025/2026"},
+			}
+			match := database.RatingMatch{MatchID: 10, KickoffAt: tt.kickoff, HomeRef: 1, AwayRef: 2, HomeScore: 2, AwayScore: 0}
+
+			ratings := p.Rate("soccer", []database.RatingMatch{match}, states)
+			if len(ratings) != 2 {
+				t.Fatalf("got %d ratings, want 2", len(ratings))
+			}
+			home, away := ratings[0], ratings[1]
+			if home.RatingBefore != tt.wantBefore || away.RatingBefore != p.Initial {
+				t.Errorf("ratings before = %.2f / %.2f, want %.2f / %.2f", home.RatingBefore, away.RatingBefore, tt.wantBefore, p.Initial)
+			}
+			change := p.Change(home.RatingBefore, away.RatingBefore, 2, 0)
+			if home.Rating != home.RatingBefore+change || away.Rating != away.RatingBefore-change {
+				t.Errorf("ratings after = %.2f / %.2f, want a change of %.2f each way", home.Rating, away.Rating, change)
+			}
+			if home.Season != "2025/2026" {
+				t.Errorf("season = %q, want the latest known season", home.Season)
+			}
+			if states[1] != home || states[2] != away {
+				t.Errorf("states not updated to the match ratings")
+			}
+		})
+	}
+}
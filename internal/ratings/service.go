@@ -0,0 +1,79 @@
+package ratings
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+)
+
+// Service stores ratings computed from the match tables
+type Service struct {
+	db *database.DB
+}
+
+// NewService creates a rating service
+func NewService(db *database.DB) *Service {
+	return &Service{db: db}
+}
+
+// Update rates the finished matches of sport that have no ratings yet, or
+// whose score was corrected after they were rated, and returns how many
+// matches it rated. Ratings are replayed from the earliest such match, so a
+// match finishing late, synced by a backfill or rescored shifts the ratings
+// after it. It does nothing when every rating is up to date.
+func (s *Service) Update(ctx context.Context, sport string) (int, error) {
+	unrated, err := s.db.GetEarliestUnratedKickoff(sport)
+	if err != nil {
+		return 0, err
+	}
+	rescored, err := s.db.GetEarliestRescoredKickoff(sport)
+	if err != nil {
+		return 0, err
+	}
+
+	from := unrated
+	if rescored != nil && (from == nil || rescored.Before(*from)) {
+		from = rescored
+	}
+	if from == nil {
+		return 0, nil
+	}
+	return s.rateFrom(ctx, sport, *from)
+}
+
+// Rebuild recomputes every rating of sport from its first match and returns
+// how many matches it rated
+func (s *Service) Rebuild(ctx context.Context, sport string) (int, error) {
+	return s.rateFrom(ctx, sport, time.Time{})
+}
+
+// rateFrom replaces the ratings of sport from matches kicking off at or
+// after from, starting from each team's latest rating before it
+func (s *Service) rateFrom(ctx context.Context, sport string, from time.Time) (int, error) {
+	p, ok := ParamsFor(sport)
+	if !ok {
+		return 0, fmt.Errorf("unsupported sport: %s", sport)
+	}
+
+	states, err := s.db.GetRatingStates(sport, from)
+	if err != nil {
+		return 0, err
+	}
+	matches, err := s.db.GetRatingMatches(sport, from)
+	if err != nil {
+		return 0, err
+	}
+
+	ratings := p.Rate(sport, matches, states)
+	if err := s.db.ReplaceRatings(ctx, sport, from, ratings); err != nil {
+		return 0, err
+	}
+
+	if len(matches) > 0 {
+		log.Printf("Rated %d %s matches from %s", len(matches), sport, matches[0].KickoffAt.UTC().Format(time.RFC3339))
+	}
+	return len(matches), nil
+}
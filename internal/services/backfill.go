@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/dusanbre/otg-sports-api/internal/goalserve"
+)
+
+// BackfillOptions selects what a backfill run fetches
+type BackfillOptions struct {
+	Sport string    // "soccer" or "basketball"
+	From  time.Time // First feed day, inclusive, read from its year, month and day
+	To    time.Time // Last feed day, inclusive
+	Force bool      // Re-fetch days already recorded as completed
+}
+
+// BackfillDay reports the outcome of one day of a backfill run
+type BackfillDay struct {
+	Day     time.Time
+	Skipped bool // Already completed by an earlier run
+	Result  SyncResult
+	Err     error
+}
+
+// BackfillService replays Goalserve day feeds into the match tables. Completed
+// days are stored in backfill_progress so an interrupted run picks up where it stopped.
+type BackfillService struct {
+	db         *database.DB
+	soccer     *SoccerSyncService
+	basketball *BasketballSyncService
+}
+
+// NewBackfillService creates a new backfill service
+func NewBackfillService(db *database.DB) *BackfillService {
+	return &BackfillService{
+		db:         db,
+		soccer:     NewSoccerSyncService(db),
+		basketball: NewBasketballSyncService(db),
+	}
+}
+
+// Run walks the days from opts.From to opts.To, calling report after each one.
+// Fatal Goalserve errors and cancellation stop the run; other fetch failures are
+// reported and the day is left for the next run. The whole range is recorded as
+// one sync run with the backfill feed, failed if any day failed.
+func (s *BackfillService) Run(ctx context.Context, opts BackfillOptions, report func(BackfillDay)) (total SyncResult, err error) {
+	syncDay := s.soccer.syncDay
+	if opts.Sport == "basketball" {
+		syncDay = s.basketball.syncDay
+	} else if opts.Sport != "soccer" {
+		return total, fmt.Errorf("unsupported sport: %s", opts.Sport)
+	}
+
+	from := time.Date(opts.From.Year(), opts.From.Month(), opts.From.Day(), 0, 0, 0, 0, time.UTC)
+	to := time.Date(opts.To.Year(), opts.To.Month(), opts.To.Day(), 0, 0, 0, 0, time.UTC)
+	today := goalserve.FeedDay(time.Now())
+
+	run := startSyncRun(ctx, s.db, opts.Sport, database.SyncFeedBackfill)
+	var dayErrs []error
+	defer func() {
+		runErr := err
+		if runErr == nil {
+			runErr = errors.Join(dayErrs...)
+		}
+		run.finish(total, runErr)
+	}()
+
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+
+		if !opts.Force {
+			done, err := s.isCompleted(opts.Sport, day)
+			if err != nil {
+				return total, err
+			}
+			if done {
+				report(BackfillDay{Day: day, Skipped: true})
+				continue
+			}
+		}
+
+		result, err := syncDay(ctx, run.id, day)
+		if err != nil {
+			report(BackfillDay{Day: day, Err: err})
+			if goalserve.IsFatal(err) {
+				return total, err
+			}
+			dayErrs = append(dayErrs, fmt.Errorf("%s: %w", day.Format("2006-01-02"), err))
+			continue
+		}
+		total.Add(result)
+
+		// Today and future days keep changing, so only past days count as done
+		if day.Before(today) {
+			if err := s.markCompleted(opts.Sport, day, result); err != nil {
+				log.Printf("Warning: failed to record backfill progress for %s: %v", day.Format("2006-01-02"), err)
+			}
+		}
+
+		report(BackfillDay{Day: day, Result: result})
+	}
+
+	return total, nil
+}
+
+// isCompleted reports whether an earlier run already finished the given day
+func (s *BackfillService) isCompleted(sport string, day time.Time) (bool, error) {
+	query := s.db.Builder.
+		Select("1").
+		From("backfill_progress").
+		Where("sport = ? AND day = ?", sport, day)
+
+	querySQL, args, err := query.ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var one int
+	err = s.db.Conn.QueryRow(querySQL, args...).Scan(&one)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check backfill progress: %w", err)
+	}
+	return true, nil
+}
+
+// markCompleted stores the result of a finished day
+func (s *BackfillService) markCompleted(sport string, day time.Time, result SyncResult) error {
+	query := s.db.Builder.
+		Insert("backfill_progress").
+		Columns("sport", "day", "inserted", "updated", "unchanged", "failed", "completed_at").
+		Values(sport, day, result.Inserted, result.Updated, result.Unchanged, result.Failed, time.Now()).
+		Suffix(`ON CONFLICT (sport, day) DO UPDATE SET
+			inserted = EXCLUDED.inserted,
+			updated = EXCLUDED.updated,
+			unchanged = EXCLUDED.unchanged,
+			failed = EXCLUDED.failed,
+			completed_at = EXCLUDED.completed_at`)
+
+	querySQL, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	_, err = s.db.Conn.Exec(querySQL, args...)
+	return err
+}
@@ -1,7 +1,10 @@
 package services
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"strconv"
@@ -9,6 +12,7 @@ import (
 
 	"github.com/dusanbre/otg-sports-api/internal/database"
 	"github.com/dusanbre/otg-sports-api/internal/goalserve"
+	"github.com/google/uuid"
 )
 
 // BasketballSyncService handles syncing basketball matches from Goalserve to database
@@ -19,73 +23,198 @@ type BasketballSyncService struct {
 
 // NewBasketballSyncService creates a new basketball sync service
 func NewBasketballSyncService(db *database.DB) *BasketballSyncService {
+	client := goalserve.NewClient()
+	setRawFeedArchive(client, db)
+
 	return &BasketballSyncService{
 		db:              db,
-		goalserveClient: goalserve.NewClient(),
+		goalserveClient: client,
 	}
 }
 
-// SyncMatches fetches basketball matches from Goalserve and syncs them to the database
-func (s *BasketballSyncService) SyncMatches() error {
-	log.Println("Starting basketball match sync...")
+// SyncMatches fetches today's and the next 7 days' basketball matches and syncs them to the database.
+// A failed future-day sync is only logged, unless Goalserve rejected the API key.
+func (s *BasketballSyncService) SyncMatches(ctx context.Context) error {
+	if err := s.SyncToday(ctx); err != nil {
+		return err
+	}
 
-	// Fetch today's matches
-	basketballData, err := s.goalserveClient.FetchBasketballTodayMatches()
+	err := s.SyncFuture(ctx)
+	if errors.Is(err, context.Canceled) || errors.Is(err, goalserve.ErrAuthRejected) {
+		return err
+	} else if err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	return nil
+}
+
+// SyncToday fetches today's basketball feed, which carries the live scores, and syncs it
+func (s *BasketballSyncService) SyncToday(ctx context.Context) (err error) {
+	run := startSyncRun(ctx, s.db, "basketball", database.SyncFeedToday)
+	var result SyncResult
+	defer func() { run.finish(result, err) }()
+	log.Printf("Starting basketball today sync (run %s)...", run.id)
+
+	basketballData, err := s.goalserveClient.FetchBasketballTodayMatches(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to fetch today's basketball matches from Goalserve: %w", err)
 	}
+	run.fetched()
 
-	matchesInserted := 0
-	matchesUpdated := 0
+	result, err = s.processScores(ctx, run.id, basketballData, feedDays(0, 0))
+	if err != nil {
+		return fmt.Errorf("failed to store today's basketball matches: %w", err)
+	}
 
-	// Process today's matches
-	for _, category := range basketballData.Categories {
+	log.Printf("Basketball today sync completed: %s", result)
+	return nil
+}
+
+// SyncFuture fetches the next 7 days of basketball fixtures and syncs them
+func (s *BasketballSyncService) SyncFuture(ctx context.Context) (err error) {
+	run := startSyncRun(ctx, s.db, "basketball", database.SyncFeedFuture)
+	var result SyncResult
+	defer func() { run.finish(result, err) }()
+	log.Printf("Starting basketball future sync (run %s)...", run.id)
+
+	futureData, err := s.goalserveClient.FetchBasketballMatchesFuture7Days(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch future basketball matches from Goalserve: %w", err)
+	}
+	run.fetched()
+
+	result, err = s.processScores(ctx, run.id, futureData, feedDays(1, 7))
+	if err != nil {
+		return fmt.Errorf("failed to store future basketball matches: %w", err)
+	}
+
+	log.Printf("Basketball future sync completed: %s", result)
+	return nil
+}
+
+// RetryDeadLetters reprocesses up to limit unresolved basketball dead letters,
+// resolving the ones that now sync
+func (s *BasketballSyncService) RetryDeadLetters(ctx context.Context, limit int) (result SyncResult, err error) {
+	run := startSyncRun(ctx, s.db, "basketball", database.SyncFeedRetry)
+	defer func() { run.finish(result, err) }()
+
+	letters, err := s.db.GetDeadLetters("basketball", limit)
+	if err != nil {
+		return result, err
+	}
+	log.Printf("Retrying %d basketball dead letters (run %s)...", len(letters), run.id)
+
+	scores := &goalserve.GoalServeBasketballScores{}
+	for _, letter := range letters {
+		var category goalserve.GoalServeBasketballCategory
+		if err := json.Unmarshal(letter.Payload, &category); err != nil {
+			log.Printf("Warning: skipping unreadable basketball dead letter %d: %v", letter.ID, err)
+			continue
+		}
+		scores.Categories = append(scores.Categories, category)
+	}
+
+	result, err = s.processScores(ctx, run.id, scores, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to store basketball dead letters: %w", err)
+	}
+
+	log.Printf("Basketball dead letter retry completed: %s", result)
+	return result, nil
+}
+
+// syncDay fetches and upserts the basketball matches of a single feed day
+func (s *BasketballSyncService) syncDay(ctx context.Context, runID uuid.UUID, day time.Time) (SyncResult, error) {
+	scores, err := s.goalserveClient.FetchBasketballMatchesForDate(ctx, day)
+	if err != nil {
+		return SyncResult{}, fmt.Errorf("failed to fetch basketball matches for %s: %w", day.Format("2006-01-02"), err)
+	}
+
+	result, err := s.processScores(ctx, runID, scores, []time.Time{day})
+	if err != nil {
+		return result, fmt.Errorf("failed to store basketball matches for %s: %w", day.Format("2006-01-02"), err)
+	}
+	return result, nil
+}
+
+// processScores upserts every match in a fetched feed and dead-letters the ones
+// that fail. days are the feed days the payload covers, reconciled against the
+// stored matches; nil skips reconciliation.
+func (s *BasketballSyncService) processScores(ctx context.Context, runID uuid.UUID, scores *goalserve.GoalServeBasketballScores, days []time.Time) (SyncResult, error) {
+	var rows []matchRow
+	var listed []int64 // Every match in the feed, including those that fail below
+	var parseFailures SyncResult
+	for _, category := range scores.Categories {
 		for _, match := range category.Match.Matches {
-			inserted, err := s.upsertBasketballMatch(category, match)
+			if id, err := strconv.ParseInt(match.ID, 10, 64); err == nil {
+				listed = append(listed, id)
+			}
+
+			item := basketballFeedItem(category, match)
+			row, err := basketballMatchRow(category, match)
 			if err != nil {
-				log.Printf("Failed to upsert basketball match %s: %v", match.ID, err)
+				log.Printf("Failed to parse basketball match %s: %v", match.ID, err)
+				parseFailures.fail(match.ID, item, err)
 				continue
 			}
-			if inserted {
-				matchesInserted++
-			} else {
-				matchesUpdated++
-			}
+			row.item = item
+			rows = append(rows, row)
 		}
 	}
 
-	// Fetch future matches (next 7 days)
-	futureData, err := s.goalserveClient.FetchBasketballMatchesFuture7Days()
-	if err != nil {
-		log.Printf("Warning: failed to fetch future basketball matches: %v", err)
-	} else {
-		// Process future matches
-		for _, category := range futureData.Categories {
-			for _, match := range category.Match.Matches {
-				inserted, err := s.upsertBasketballMatch(category, match)
-				if err != nil {
-					log.Printf("Failed to upsert future basketball match %s: %v", match.ID, err)
-					continue
-				}
-				if inserted {
-					matchesInserted++
-				} else {
-					matchesUpdated++
-				}
+	result, err := upsertMatches(ctx, s.db, basketballMatchTable, runID, rows)
+	result.Add(parseFailures)
+	if err == nil {
+		// After a failed write nothing was stored, and the next run tries the whole feed again
+		recordDeadLetters(ctx, s.db, "basketball", runID, rows, result.deadLetters)
+
+		if len(days) > 0 {
+			if err := reconcileMissing(ctx, s.db, basketballMatchTable, runID, days, rows, listed); err != nil {
+				log.Printf("Warning: failed to reconcile basketball matches: %v", err)
 			}
 		}
 	}
+	return result, err
+}
 
-	log.Printf("Basketball match sync completed: %d inserted, %d updated", matchesInserted, matchesUpdated)
-	return nil
+// basketballMatchTable lists the basketball_matches columns written by sync
+var basketballMatchTable = matchTable{
+	name:       "basketball_matches",
+	sport:      "basketball",
+	dateColumn: "match_date",
+	columns: []string{
+		"match_id", "league_gid", "league_id", "league_name", "file_group",
+		"match_status", "status", "match_date", "match_time", "kickoff_at", "timer",
+		"h_team_id", "h_team_name", "h_team_score",
+		"h_team_q1", "h_team_q2", "h_team_q3", "h_team_q4", "h_team_ot",
+		"a_team_id", "a_team_name", "a_team_score",
+		"a_team_q1", "a_team_q2", "a_team_q3", "a_team_q4", "a_team_ot",
+	},
+	updateColumns: []string{
+		"match_status", "status", "match_date", "match_time", "kickoff_at", "timer",
+		"h_team_score", "h_team_q1", "h_team_q2", "h_team_q3", "h_team_q4", "h_team_ot",
+		"a_team_score", "a_team_q1", "a_team_q2", "a_team_q3", "a_team_q4", "a_team_ot",
+	},
+	// The timer ticks every minute, so it is updated but not kept in the history
+	historyColumns: []string{
+		"match_status", "kickoff_at",
+		"h_team_score", "h_team_q1", "h_team_q2", "h_team_q3", "h_team_q4", "h_team_ot",
+		"a_team_score", "a_team_q1", "a_team_q2", "a_team_q3", "a_team_q4", "a_team_ot",
+	},
+}
+
+// basketballFeedItem wraps one match in its category, the way Goalserve lists it
+func basketballFeedItem(category goalserve.GoalServeBasketballCategory, match goalserve.GoalServeBasketballMatch) goalserve.GoalServeBasketballCategory {
+	category.Match.Matches = []goalserve.GoalServeBasketballMatch{match}
+	return category
 }
 
-// upsertBasketballMatch inserts or updates a basketball match in the database
-func (s *BasketballSyncService) upsertBasketballMatch(category goalserve.GoalServeBasketballCategory, match goalserve.GoalServeBasketballMatch) (bool, error) {
+// basketballMatchRow parses a feed match into the values written to basketball_matches
+func basketballMatchRow(category goalserve.GoalServeBasketballCategory, match goalserve.GoalServeBasketballMatch) (matchRow, error) {
 	// Parse match ID
 	matchID, err := strconv.ParseInt(match.ID, 10, 64)
 	if err != nil {
-		return false, fmt.Errorf("invalid match ID: %w", err)
+		return matchRow{}, fmt.Errorf("invalid match ID: %w", err)
 	}
 
 	// Parse league IDs
@@ -96,28 +225,12 @@ func (s *BasketballSyncService) upsertBasketballMatch(category goalserve.GoalSer
 	hTeamID, _ := strconv.ParseInt(match.LocalTeam.ID, 10, 64)
 	aTeamID, _ := strconv.ParseInt(match.AwayTeam.ID, 10, 64)
 
-	// Parse date and time
-	dateStr := match.Date
-	if dateStr == "" || match.Time == "" {
-		return false, fmt.Errorf("missing date or time data: date='%s', time='%s'", dateStr, match.Time)
-	}
-
-	// Parse date (format: "29.01.2026")
-	matchDate, err := time.Parse("02.01.2006", dateStr)
-	if err != nil {
-		return false, fmt.Errorf("invalid date format: %s", dateStr)
-	}
-
-	// Parse time (format: "23:30")
-	timeOnly, err := time.Parse("15:04", match.Time)
+	// Parse date (format: "29.01.2026") and time (format: "23:30")
+	start, err := parseKickoff(match.Date, match.Time, goalserve.FeedLocation(), time.Now())
 	if err != nil {
-		return false, fmt.Errorf("invalid time format: %s", match.Time)
+		return matchRow{}, err
 	}
 
-	// Create time value for TIME column
-	year, month, day := time.Now().Date()
-	matchTime := time.Date(year, month, day, timeOnly.Hour(), timeOnly.Minute(), 0, 0, time.UTC)
-
 	// Parse scores - helper function for nullable int32
 	parseScore := func(s string) sql.NullInt32 {
 		if s == "" {
@@ -150,83 +263,23 @@ func (s *BasketballSyncService) upsertBasketballMatch(category goalserve.GoalSer
 		timer = sql.NullString{String: match.Timer, Valid: true}
 	}
 
-	// Check if match exists
-	var existingID int64
-	checkQuery := s.db.Builder.
-		Select("id").
-		From("basketball_matches").
-		Where("match_id = ?", matchID)
-
-	checkSQL, checkArgs, _ := checkQuery.ToSql()
-	err = s.db.Conn.QueryRow(checkSQL, checkArgs...).Scan(&existingID)
-
-	if err == sql.ErrNoRows {
-		// Insert new match
-		insertQuery := s.db.Builder.
-			Insert("basketball_matches").
-			Columns(
-				"match_id", "league_gid", "league_id", "league_name", "file_group",
-				"match_status", "match_date", "match_time", "timer",
-				"h_team_id", "h_team_name", "h_team_score",
-				"h_team_q1", "h_team_q2", "h_team_q3", "h_team_q4", "h_team_ot",
-				"a_team_id", "a_team_name", "a_team_score",
-				"a_team_q1", "a_team_q2", "a_team_q3", "a_team_q4", "a_team_ot",
-			).
-			Values(
-				matchID, leagueGid, leagueID, category.Name, category.FileGroup,
-				match.Status, matchDate, matchTime, timer,
-				hTeamID, match.LocalTeam.Name, hTeamScore,
-				hTeamQ1, hTeamQ2, hTeamQ3, hTeamQ4, hTeamOt,
-				aTeamID, match.AwayTeam.Name, aTeamScore,
-				aTeamQ1, aTeamQ2, aTeamQ3, aTeamQ4, aTeamOt,
-			)
-
-		insertSQL, insertArgs, err := insertQuery.ToSql()
-		if err != nil {
-			return false, fmt.Errorf("failed to build insert query: %w", err)
-		}
-
-		_, err = s.db.Conn.Exec(insertSQL, insertArgs...)
-		if err != nil {
-			return false, fmt.Errorf("failed to insert basketball match: %w", err)
-		}
-
-		log.Printf("Inserted basketball match: %s vs %s", match.LocalTeam.Name, match.AwayTeam.Name)
-		return true, nil
-	} else if err == nil {
-		// Update existing match
-		updateQuery := s.db.Builder.
-			Update("basketball_matches").
-			Set("match_status", match.Status).
-			Set("timer", timer).
-			Set("h_team_score", hTeamScore).
-			Set("h_team_q1", hTeamQ1).
-			Set("h_team_q2", hTeamQ2).
-			Set("h_team_q3", hTeamQ3).
-			Set("h_team_q4", hTeamQ4).
-			Set("h_team_ot", hTeamOt).
-			Set("a_team_score", aTeamScore).
-			Set("a_team_q1", aTeamQ1).
-			Set("a_team_q2", aTeamQ2).
-			Set("a_team_q3", aTeamQ3).
-			Set("a_team_q4", aTeamQ4).
-			Set("a_team_ot", aTeamOt).
-			Set("updated_at", time.Now()).
-			Where("match_id = ?", matchID)
-
-		updateSQL, updateArgs, err := updateQuery.ToSql()
-		if err != nil {
-			return false, fmt.Errorf("failed to build update query: %w", err)
-		}
-
-		_, err = s.db.Conn.Exec(updateSQL, updateArgs...)
-		if err != nil {
-			return false, fmt.Errorf("failed to update basketball match: %w", err)
-		}
-
-		log.Printf("Updated basketball match: %s vs %s", match.LocalTeam.Name, match.AwayTeam.Name)
-		return false, nil
-	} else {
-		return false, fmt.Errorf("failed to check if basketball match exists: %w", err)
-	}
+	return matchRow{
+		matchID: matchID,
+		feedID:  match.ID,
+		label:   match.LocalTeam.Name + " vs " + match.AwayTeam.Name,
+		day:     start.date(),
+		values: []any{
+			matchID, leagueGid, leagueID, category.Name, category.FileGroup,
+			match.Status, NormalizeBasketballStatus(match.Status), start.date(), start.clock(), start.at, timer,
+			hTeamID, match.LocalTeam.Name, hTeamScore,
+			hTeamQ1, hTeamQ2, hTeamQ3, hTeamQ4, hTeamOt,
+			aTeamID, match.AwayTeam.Name, aTeamScore,
+			aTeamQ1, aTeamQ2, aTeamQ3, aTeamQ4, aTeamOt,
+		},
+		entities: matchEntities{
+			leagueID: leagueID, leagueGID: leagueGid, leagueName: category.Name,
+			homeID: hTeamID, homeName: match.LocalTeam.Name,
+			awayID: aTeamID, awayName: match.AwayTeam.Name,
+		},
+	}, nil
 }
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// deadLetter is a match that failed to sync
+type deadLetter struct {
+	feedID string
+	item   any // Goalserve category holding just this match
+	err    string
+}
+
+// recordDeadLetters parks the failed matches of a run in sync_dead_letters
+// and resolves the dead letters of the matches it stored. A match failing
+// again keeps its first_failed_at and counts another attempt. Recording is
+// best effort, like sync_runs.
+func recordDeadLetters(ctx context.Context, db *database.DB, sport string, runID uuid.UUID, rows []matchRow, letters []deadLetter) {
+	failed := make(map[string]bool, len(letters))
+	byKey := make(map[string][]any, len(letters))
+	var keys []string
+	for _, l := range letters {
+		failed[l.feedID] = true
+
+		payload, err := json.Marshal(l.item)
+		if err != nil {
+			log.Printf("Warning: failed to encode %s dead letter %s: %v", sport, l.feedID, err)
+			continue
+		}
+
+		key := l.feedID
+		if key == "" {
+			sum := sha256.Sum256(payload)
+			key = "sha256:" + hex.EncodeToString(sum[:16])
+		}
+		if len(key) > 100 {
+			key = key[:100]
+		}
+
+		// One row per key, ON CONFLICT can't touch the same row twice
+		if _, seen := byKey[key]; !seen {
+			keys = append(keys, key)
+		}
+		byKey[key] = []any{sport, key, string(payload), l.err, runID.String()}
+	}
+
+	var stored []string
+	for _, row := range rows {
+		if !failed[row.feedID] {
+			stored = append(stored, row.feedID)
+		}
+	}
+
+	if err := writeDeadLetters(ctx, db, sport, keys, byKey, stored); err != nil {
+		log.Printf("Warning: failed to record %s dead letters: %v", sport, err)
+	}
+}
+
+// writeDeadLetters upserts the dead letters in byKey and resolves those of stored matches
+func writeDeadLetters(ctx context.Context, db *database.DB, sport string, keys []string, byKey map[string][]any, stored []string) error {
+	suffix := `ON CONFLICT (sport, match_key) DO UPDATE SET
+		payload = EXCLUDED.payload,
+		error = EXCLUDED.error,
+		sync_run_id = EXCLUDED.sync_run_id,
+		attempts = CASE WHEN sync_dead_letters.resolved_at IS NULL THEN sync_dead_letters.attempts + 1 ELSE 1 END,
+		first_failed_at = CASE WHEN sync_dead_letters.resolved_at IS NULL THEN sync_dead_letters.first_failed_at ELSE now() END,
+		last_failed_at = now(),
+		resolved_at = NULL`
+
+	for start := 0; start < len(keys); start += upsertBatchSize {
+		query := db.Builder.
+			Insert("sync_dead_letters").
+			Columns("sport", "match_key", "payload", "error", "sync_run_id")
+		for _, key := range keys[start:min(start+upsertBatchSize, len(keys))] {
+			query = query.Values(byKey[key]...)
+		}
+
+		querySQL, args, err := query.Suffix(suffix).ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build dead letter query: %w", err)
+		}
+		if _, err := db.Conn.ExecContext(ctx, querySQL, args...); err != nil {
+			return fmt.Errorf("failed to upsert dead letters: %w", err)
+		}
+	}
+
+	if len(stored) == 0 {
+		return nil
+	}
+
+	query := db.Builder.
+		Update("sync_dead_letters").
+		Set("resolved_at", sq.Expr("now()")).
+		Where("sport = ? AND resolved_at IS NULL", sport).
+		Where("match_key = ANY(?)", pq.Array(stored))
+
+	querySQL, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build dead letter query: %w", err)
+	}
+	res, err := db.Conn.ExecContext(ctx, querySQL, args...)
+	if err != nil {
+		return fmt.Errorf("failed to resolve dead letters: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n > 0 {
+		log.Printf("Resolved %d %s dead letters", n, sport)
+	}
+
+	return nil
+}
@@ -0,0 +1,243 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/dusanbre/otg-sports-api/internal/standings"
+)
+
+// entityRefColumns are the match columns pointing at leagues, seasons and
+// teams. Every upsert writes them after matchTable.columns.
+var entityRefColumns = []string{"league_ref", "season_ref", "h_team_ref", "a_team_ref"}
+
+// matchEntities identifies the league and teams of a match by their Goalserve IDs
+type matchEntities struct {
+	leagueID   int64
+	leagueGID  int64
+	leagueName string // Category name, e.g. "England: Premier League"
+	homeID     int64
+	homeName   string
+	awayID     int64
+	awayName   string
+}
+
+// refValues returns the entityRefColumns values of a match played on day.
+// They are looked up by Goalserve ID in the rows upsertEntities wrote in the
+// same transaction, and are NULL for entities without an ID.
+func (e matchEntities) refValues(rules *standings.Config, sport string, day time.Time) []any {
+	return []any{
+		sq.Expr("(SELECT id FROM leagues WHERE sport = ? AND league_id = ?)", sport, e.leagueID),
+		sq.Expr(`(SELECT s.id FROM seasons s JOIN leagues l ON l.id = s.league_ref
+			WHERE l.sport = ? AND l.league_id = ? AND s.name = ?)`, sport, e.leagueID, e.season(rules, sport, day)),
+		sq.Expr("(SELECT id FROM teams WHERE sport = ? AND team_id = ?)", sport, e.homeID),
+		sq.Expr("(SELECT id FROM teams WHERE sport = ? AND team_id = ?)", sport, e.awayID),
+	}
+}
+
+// season names the league season day falls in. Goalserve feeds carry no
+// season, so it follows the league's season_start in rules.
+func (e matchEntities) season(rules *standings.Config, sport string, day time.Time) string {
+	return seasonName(rules.Rules(sport, e.leagueID, e.leagueName).SeasonStart, day)
+}
+
+// seasonName names the season starting in month start that day falls in:
+// "2026/2027" for a season spanning the turn of the year, "2026" for one
+// starting in January
+func seasonName(start time.Month, day time.Time) string {
+	year := day.Year()
+	if day.Month() < start {
+		year--
+	}
+	if start == time.January {
+		return fmt.Sprintf("%d", year)
+	}
+	return fmt.Sprintf("%d/%d", year, year+1)
+}
+
+var (
+	seasonConfig     *standings.Config
+	seasonConfigErr  error
+	seasonConfigOnce sync.Once
+)
+
+// LoadSeasonRules loads the standings config match seasons are named from.
+// Commands that write matches call it at startup to fail early; otherwise the
+// first write fails with the same error.
+func LoadSeasonRules() error {
+	_, err := seasonRules()
+	return err
+}
+
+// seasonRules returns the standings config the season starts are read from,
+// loaded once from STANDINGS_CONFIG. A config that fails to load stops every
+// write rather than putting matches in seasons named from the wrong month.
+func seasonRules() (*standings.Config, error) {
+	seasonConfigOnce.Do(func() {
+		seasonConfig, seasonConfigErr = standings.ConfigFromEnv()
+	})
+	return seasonConfig, seasonConfigErr
+}
+
+// RegroupSeasons regroups the stored matches of every league of sport into
+// seasons named from the league's season_start in config, for matches synced
+// before it was set. It returns how many matches moved season.
+func RegroupSeasons(ctx context.Context, db *database.DB, config *standings.Config, sport string) (int64, error) {
+	leagues, err := db.GetLeagues(sport)
+	if err != nil {
+		return 0, err
+	}
+
+	var moved int64
+	for _, l := range leagues {
+		if err := ctx.Err(); err != nil {
+			return moved, err
+		}
+		start := config.Rules(sport, l.LeagueID, l.Name).SeasonStart
+		n, err := db.RegroupSeasons(ctx, sport, l.ID, start)
+		if err != nil {
+			return moved, fmt.Errorf("failed to regroup seasons of %s: %w", l.Name, err)
+		}
+		moved += n
+	}
+	return moved, nil
+}
+
+// leagueCountry returns the country prefix of a category name such as
+// "England: Premier League", or "" when the name has none
+func leagueCountry(name string) string {
+	country, _, found := strings.Cut(name, ": ")
+	if !found {
+		return ""
+	}
+	return strings.TrimSpace(country)
+}
+
+// seasonSpan is the range of match days seen for one league season
+type seasonSpan struct {
+	leagueID int64
+	name     string
+	from, to time.Time
+}
+
+// upsertEntities creates or refreshes the leagues, seasons and teams of batch,
+// so refValues can resolve them when the matches are written
+func upsertEntities(ctx context.Context, db *database.DB, tx *sql.Tx, rules *standings.Config, sport string, batch []matchRow) error {
+	leagues := make(map[int64]matchEntities)
+	seasons := make(map[string]*seasonSpan)
+	teams := make(map[int64]string)
+	var leagueOrder, teamOrder []int64
+	var seasonOrder []string
+
+	for _, row := range batch {
+		e := row.entities
+		if e.leagueID != 0 {
+			if _, seen := leagues[e.leagueID]; !seen {
+				leagueOrder = append(leagueOrder, e.leagueID)
+			}
+			leagues[e.leagueID] = e
+
+			name := e.season(rules, sport, row.day)
+			key := fmt.Sprintf("%d/%s", e.leagueID, name)
+			if span, ok := seasons[key]; ok {
+				span.from = minTime(span.from, row.day)
+				span.to = maxTime(span.to, row.day)
+			} else {
+				seasons[key] = &seasonSpan{leagueID: e.leagueID, name: name, from: row.day, to: row.day}
+				seasonOrder = append(seasonOrder, key)
+			}
+		}
+
+		for _, team := range []struct {
+			id   int64
+			name string
+		}{{e.homeID, e.homeName}, {e.awayID, e.awayName}} {
+			if team.id == 0 {
+				continue
+			}
+			if _, seen := teams[team.id]; !seen {
+				teamOrder = append(teamOrder, team.id)
+			}
+			teams[team.id] = team.name
+		}
+	}
+
+	if len(leagueOrder) > 0 {
+		query := db.Builder.
+			Insert("leagues").
+			Columns("sport", "league_id", "gid", "name", "country")
+		for _, id := range leagueOrder {
+			e := leagues[id]
+			gid := sql.NullInt64{Int64: e.leagueGID, Valid: e.leagueGID != 0}
+			query = query.Values(sport, id, gid, e.leagueName, nullString(leagueCountry(e.leagueName)))
+		}
+		query = query.Suffix(`ON CONFLICT (sport, league_id) DO UPDATE SET
+			gid = EXCLUDED.gid,
+			name = EXCLUDED.name,
+			country = EXCLUDED.country,
+			updated_at = now()
+			WHERE (leagues.gid, leagues.name, leagues.country) IS DISTINCT FROM (EXCLUDED.gid, EXCLUDED.name, EXCLUDED.country)`)
+		if err := execQuery(ctx, tx, query); err != nil {
+			return fmt.Errorf("failed to upsert leagues: %w", err)
+		}
+	}
+
+	if len(seasonOrder) > 0 {
+		query := db.Builder.
+			Insert("seasons").
+			Columns("league_ref", "name", "starts_on", "ends_on")
+		for _, key := range seasonOrder {
+			span := seasons[key]
+			query = query.Values(
+				sq.Expr("(SELECT id FROM leagues WHERE sport = ? AND league_id = ?)", sport, span.leagueID),
+				span.name, span.from.Format("2006-01-02"), span.to.Format("2006-01-02"),
+			)
+		}
+		query = query.Suffix(`ON CONFLICT (league_ref, name) DO UPDATE SET
+			starts_on = LEAST(seasons.starts_on, EXCLUDED.starts_on),
+			ends_on = GREATEST(seasons.ends_on, EXCLUDED.ends_on),
+			updated_at = now()
+			WHERE EXCLUDED.starts_on < seasons.starts_on OR EXCLUDED.ends_on > seasons.ends_on`)
+		if err := execQuery(ctx, tx, query); err != nil {
+			return fmt.Errorf("failed to upsert seasons: %w", err)
+		}
+	}
+
+	if len(teamOrder) > 0 {
+		query := db.Builder.
+			Insert("teams").
+			Columns("sport", "team_id", "name")
+		for _, id := range teamOrder {
+			query = query.Values(sport, id, teams[id])
+		}
+		query = query.Suffix(`ON CONFLICT (sport, team_id) DO UPDATE SET
+			name = EXCLUDED.name,
+			updated_at = now()
+			WHERE teams.name IS DISTINCT FROM EXCLUDED.name`)
+		if err := execQuery(ctx, tx, query); err != nil {
+			return fmt.Errorf("failed to upsert teams: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func minTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
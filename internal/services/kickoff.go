@@ -0,0 +1,68 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// kickoff is a match start time parsed in the feed's timezone
+type kickoff struct {
+	at time.Time
+}
+
+// parseKickoff combines a feed date and clock time in loc. Dates come either
+// as "02.01.2006" or without a year as "Jan 2"; the year of the latter is the
+// one that puts the match closest to ref, so late-December feeds read in
+// January (and the other way round) land in the right year.
+func parseKickoff(date, clock string, loc *time.Location, ref time.Time) (kickoff, error) {
+	date, clock = strings.TrimSpace(date), strings.TrimSpace(clock)
+	if date == "" || clock == "" {
+		return kickoff{}, fmt.Errorf("missing date or time data: date='%s', time='%s'", date, clock)
+	}
+
+	clockTime, err := time.Parse("15:04", clock)
+	if err != nil {
+		return kickoff{}, fmt.Errorf("invalid time format: %s", clock)
+	}
+
+	if day, err := time.ParseInLocation("02.01.2006", date, loc); err == nil {
+		return kickoff{at: atClock(day, clockTime)}, nil
+	}
+
+	day, err := time.ParseInLocation("Jan 2", date, loc)
+	if err != nil {
+		return kickoff{}, fmt.Errorf("invalid date format: %s", date)
+	}
+
+	ref = ref.In(loc)
+	var best time.Time
+	for _, year := range []int{ref.Year() - 1, ref.Year(), ref.Year() + 1} {
+		candidate := atClock(time.Date(year, day.Month(), day.Day(), 0, 0, 0, 0, loc), clockTime)
+		if candidate.Day() != day.Day() {
+			continue // Feb 29 outside a leap year
+		}
+		if best.IsZero() || candidate.Sub(ref).Abs() < best.Sub(ref).Abs() {
+			best = candidate
+		}
+	}
+	if best.IsZero() {
+		return kickoff{}, fmt.Errorf("invalid date %s near %d", date, ref.Year())
+	}
+	return kickoff{at: best}, nil
+}
+
+// atClock sets the clock time on day, keeping day's location
+func atClock(day, clock time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, day.Location())
+}
+
+// date is the calendar day of the kickoff in the feed's timezone, for the DATE column
+func (k kickoff) date() time.Time {
+	return time.Date(k.at.Year(), k.at.Month(), k.at.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// clock is the kickoff time of day in the feed's timezone, for the TIME column
+func (k kickoff) clock() string {
+	return k.at.Format("15:04:05")
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseKickoff(t *testing.T) {
+	cet := time.FixedZone("CET", 2*60*60)
+	at := func(loc *time.Location, year int, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, loc)
+	}
+
+	tests := []struct {
+		name     string
+		date     string
+		clock    string
+		loc      *time.Location
+		ref      time.Time
+		want     time.Time
+		wantDate string // kickoff.date(), the DATE column
+	}{
+		{"full date", "31.12.2026", "20:00", time.UTC, at(time.UTC, 2027, 1, 1, 10, 0), at(time.UTC, 2026, 12, 31, 20, 0), "2026-12-31"},
+		{"New Year's Eve read on New Year's Day", "Dec 31", "20:00", time.UTC, at(time.UTC, 2027, 1, 1, 10, 0), at(time.UTC, 2026, 12, 31, 20, 0), "2026-12-31"},
+		{"New Year's Day read on New Year's Eve", "Jan 1", "15:00", time.UTC, at(time.UTC, 2026, 12, 31, 10, 0), at(time.UTC, 2027, 1, 1, 15, 0), "2027-01-01"},
+		{"a week into January read in December", "Jan 7", "18:30", time.UTC, at(time.UTC, 2026, 12, 28, 9, 0), at(time.UTC, 2027, 1, 7, 18, 30), "2027-01-07"},
+		{"mid-season", "Oct 16", "21:00", time.UTC, at(time.UTC, 2026, 10, 16, 8, 0), at(time.UTC, 2026, 10, 16, 21, 0), "2026-10-16"},
+		{"feed zone already in the new year", "Jan 1", "01:00", cet, at(time.UTC, 2026, 12, 31, 23, 30), at(cet, 2027, 1, 1, 1, 0), "2027-01-01"},
+		{"leap day", "Feb 29", "19:45", time.UTC, at(time.UTC, 2028, 3, 1, 12, 0), at(time.UTC, 2028, 2, 29, 19, 45), "2028-02-29"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k, err := parseKickoff(tt.date, tt.clock, tt.loc, tt.ref)
+			if err != nil {
+				t.Fatalf("parseKickoff: %v", err)
+			}
+			if !k.at.Equal(tt.want) {
+				t.Errorf("kickoff = %s, want %s", k.at, tt.want)
+			}
+			if got := k.date().Format("2006-01-02"); got != tt.wantDate {
+				t.Errorf("date = %s, want %s", got, tt.wantDate)
+			}
+		})
+	}
+}
+
+func TestParseKickoffErrors(t *testing.T) {
+	ref := time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name, date, clock string
+	}{
+		{"no date", "", "15:00"},
+		{"no time", "Oct 16", ""},
+		{"bad time", "Oct 16", "25:00"},
+		{"bad date", "16/10/2026", "15:00"},
+		{"no such day", "31.02.2026", "15:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if k, err := parseKickoff(tt.date, tt.clock, time.UTC, ref); err == nil {
+				t.Errorf("parseKickoff(%q, %q) = %s, want an error", tt.date, tt.clock, k.at)
+			}
+		})
+	}
+}
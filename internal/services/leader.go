@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/google/uuid"
+)
+
+const (
+	leaderHeartbeatInterval = 5 * time.Second
+	leaderRetryInterval     = 5 * time.Second
+)
+
+// leaderLockClass is the high half of every leadership advisory lock key ("otg")
+const leaderLockClass = 0x6f7467
+
+// LeaderElector runs work on at most one instance at a time using a Postgres
+// session-level advisory lock. The lock lives on a dedicated connection, so it
+// is released as soon as the holder's session ends; the holder also
+// heartbeats into sync_leader, which is what /health reports.
+type LeaderElector struct {
+	db         *database.DB
+	name       string
+	lockID     int64 // Low half of the lock key, derived from name
+	instanceID uuid.UUID
+	hostname   string
+}
+
+// NewLeaderElector creates an elector for the named leadership
+func NewLeaderElector(db *database.DB, name string) *LeaderElector {
+	hash := fnv.New32a()
+	hash.Write([]byte(name))
+	hostname, _ := os.Hostname()
+
+	return &LeaderElector{
+		db:         db,
+		name:       name,
+		lockID:     int64(hash.Sum32() & 0x7fffffff),
+		instanceID: uuid.New(),
+		hostname:   hostname,
+	}
+}
+
+// Run blocks until ctx is done. Whenever this instance wins the election it
+// calls lead with a context that is cancelled when leadership is lost, and
+// waits for lead to return before standing by again.
+func (e *LeaderElector) Run(ctx context.Context, lead func(ctx context.Context)) {
+	log.Printf("Instance %s (%s, pid %d) standing by for %s leadership", e.instanceID, e.hostname, os.Getpid(), e.name)
+
+	for {
+		conn, err := e.tryAcquire(ctx)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			log.Printf("Warning: %s leader election failed: %v", e.name, err)
+		case conn != nil:
+			e.lead(ctx, conn, lead)
+		default:
+			e.evictStaleLeader(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(leaderRetryInterval):
+		}
+	}
+}
+
+// TryLead runs lead once if this instance can take leadership right away,
+// heartbeating as Run does, and reports false without running it when
+// another session holds the lock
+func (e *LeaderElector) TryLead(ctx context.Context, lead func(ctx context.Context)) (bool, error) {
+	conn, err := e.tryAcquire(ctx)
+	if err != nil || conn == nil {
+		return false, err
+	}
+	e.lead(ctx, conn, lead)
+	return true, nil
+}
+
+// tryAcquire takes the advisory lock on a dedicated connection. It returns a
+// nil connection when another session holds the lock.
+func (e *LeaderElector) tryAcquire(ctx context.Context) (*sql.Conn, error) {
+	conn, err := e.db.Conn.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock connection: %w", err)
+	}
+
+	var acquired bool
+	err = conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1::int, $2::int)", leaderLockClass, e.lockID).Scan(&acquired)
+	if err != nil || !acquired {
+		discardConn(conn)
+		return nil, err
+	}
+
+	query := e.db.Builder.
+		Insert("sync_leader").
+		Columns("name", "instance_id", "hostname", "pid", "backend_pid", "acquired_at", "heartbeat_at").
+		Values(e.name, e.instanceID.String(), e.hostname, os.Getpid(),
+			sq.Expr("pg_backend_pid()"), sq.Expr("now()"), sq.Expr("now()")).
+		Suffix(`ON CONFLICT (name) DO UPDATE SET
+			instance_id = EXCLUDED.instance_id,
+			hostname = EXCLUDED.hostname,
+			pid = EXCLUDED.pid,
+			backend_pid = EXCLUDED.backend_pid,
+			acquired_at = EXCLUDED.acquired_at,
+			heartbeat_at = EXCLUDED.heartbeat_at`)
+
+	querySQL, args, err := query.ToSql()
+	if err == nil {
+		_, err = conn.ExecContext(ctx, querySQL, args...)
+	}
+	if err != nil {
+		discardConn(conn)
+		return nil, fmt.Errorf("failed to record %s leader: %w", e.name, err)
+	}
+
+	log.Printf("Instance %s became %s leader", e.instanceID, e.name)
+	return conn, nil
+}
+
+// lead runs lead while heartbeating on conn, and gives up leadership when the
+// heartbeat fails or ctx is done
+func (e *LeaderElector) lead(ctx context.Context, conn *sql.Conn, lead func(ctx context.Context)) {
+	leaderCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer cancel() // lead returning early also ends the term
+		lead(leaderCtx)
+	}()
+
+	ticker := time.NewTicker(leaderHeartbeatInterval)
+	defer ticker.Stop()
+
+heartbeat:
+	for {
+		select {
+		case <-leaderCtx.Done():
+			break heartbeat
+		case <-ticker.C:
+			if err := e.heartbeat(leaderCtx, conn); err != nil {
+				if leaderCtx.Err() == nil {
+					log.Printf("Instance %s lost %s leadership: %v", e.instanceID, e.name, err)
+				}
+				break heartbeat
+			}
+		}
+	}
+
+	cancel()
+	wg.Wait()
+	e.release(conn)
+}
+
+// heartbeat refreshes heartbeat_at, failing if another instance has taken over the row
+func (e *LeaderElector) heartbeat(ctx context.Context, conn *sql.Conn) error {
+	ctx, cancel := context.WithTimeout(ctx, leaderHeartbeatInterval)
+	defer cancel()
+
+	query := e.db.Builder.
+		Update("sync_leader").
+		Set("heartbeat_at", sq.Expr("now()")).
+		Where("name = ? AND instance_id = ?", e.name, e.instanceID.String())
+
+	querySQL, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build heartbeat query: %w", err)
+	}
+
+	res, err := conn.ExecContext(ctx, querySQL, args...)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return errors.New("leader record was taken over")
+	}
+	return nil
+}
+
+// release clears this instance's sync_leader row and drops the lock connection.
+// Closing the session is what releases the advisory lock, so it happens even
+// when the database can't be reached.
+func (e *LeaderElector) release(conn *sql.Conn) {
+	ctx, cancel := context.WithTimeout(context.Background(), leaderHeartbeatInterval)
+	defer cancel()
+
+	query := e.db.Builder.
+		Delete("sync_leader").
+		Where("name = ? AND instance_id = ?", e.name, e.instanceID.String())
+	if querySQL, args, err := query.ToSql(); err == nil {
+		conn.ExecContext(ctx, querySQL, args...)
+	}
+
+	discardConn(conn)
+	log.Printf("Instance %s released %s leadership", e.instanceID, e.name)
+}
+
+// evictStaleLeader terminates the session holding the lock when its heartbeat
+// is older than the lease. A crashed leader's session normally ends by itself;
+// this covers a leader cut off from the database, e.g. by a network partition,
+// whose session Postgres still sees.
+func (e *LeaderElector) evictStaleLeader(ctx context.Context) {
+	// pg_terminate_backend sits in the select list so it only runs on rows that passed the checks
+	var pid int
+	var terminated bool
+	err := e.db.Conn.QueryRowContext(ctx, `
+		SELECT l.pid, pg_terminate_backend(l.pid)
+		FROM pg_locks l
+		JOIN sync_leader s ON s.name = $3
+		WHERE l.locktype = 'advisory' AND l.granted
+			AND l.classid = $1::oid AND l.objid = $2::oid AND l.objsubid = 2
+			AND s.heartbeat_at < now()::timestamp - make_interval(secs => $4)`,
+		leaderLockClass, e.lockID, e.name, database.SyncLeaderLease.Seconds(),
+	).Scan(&pid, &terminated)
+	if errors.Is(err, sql.ErrNoRows) || ctx.Err() != nil {
+		return
+	}
+	if err != nil {
+		log.Printf("Warning: failed to check for a stale %s leader: %v", e.name, err)
+		return
+	}
+	if !terminated {
+		log.Printf("Warning: could not terminate stale %s leader session (backend pid %d)", e.name, pid)
+		return
+	}
+	log.Printf("Terminated stale %s leader session (backend pid %d), heartbeat older than %s", e.name, pid, database.SyncLeaderLease)
+}
+
+// discardConn closes the connection's session instead of returning it to the
+// pool, so an advisory lock can never leak into a pooled connection
+func discardConn(conn *sql.Conn) {
+	conn.Raw(func(any) error { return driver.ErrBadConn })
+	conn.Close()
+}
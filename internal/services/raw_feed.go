@@ -0,0 +1,149 @@
+package services
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/dusanbre/otg-sports-api/internal/goalserve"
+)
+
+// defaultRawFeedRetention is how long archived payloads are kept when RAW_FEED_RETENTION is unset
+const defaultRawFeedRetention = 7 * 24 * time.Hour
+
+// RawFeedRetentionFromEnv reads RAW_FEED_RETENTION (a Go duration such as
+// "168h"). Zero disables the archive.
+func RawFeedRetentionFromEnv() (time.Duration, error) {
+	value := os.Getenv("RAW_FEED_RETENTION")
+	if value == "" {
+		return defaultRawFeedRetention, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid RAW_FEED_RETENTION %q: %w", value, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("RAW_FEED_RETENTION %s is negative", d)
+	}
+	return d, nil
+}
+
+// RawFeedArchive stores every decoded Goalserve payload gzipped in raw_feeds,
+// so the sync transforms can be re-run over them later. A payload identical to
+// the previous one of the same feed is skipped; the live feed rarely changes
+// between polls.
+type RawFeedArchive struct {
+	db *database.DB
+
+	mu   sync.Mutex
+	last map[string]string // Feed -> sha256 of its latest archived payload
+}
+
+// NewRawFeedArchive creates an archive writing to raw_feeds
+func NewRawFeedArchive(db *database.DB) *RawFeedArchive {
+	return &RawFeedArchive{db: db, last: make(map[string]string)}
+}
+
+// setRawFeedArchive attaches an archive to client unless RAW_FEED_RETENTION disables it
+func setRawFeedArchive(client *goalserve.Client, db *database.DB) {
+	retention, err := RawFeedRetentionFromEnv()
+	if err != nil {
+		log.Printf("Warning: %v, archiving raw feeds for %s", err, defaultRawFeedRetention)
+	} else if retention == 0 {
+		return
+	}
+	client.Archiver = NewRawFeedArchive(db)
+}
+
+// ArchiveFeed implements goalserve.FeedArchiver
+func (a *RawFeedArchive) ArchiveFeed(ctx context.Context, feed string, fetchedAt time.Time, body []byte) error {
+	sport := goalserve.FeedSport(feed)
+	if sport == "" {
+		return fmt.Errorf("unknown sport for feed %s", feed)
+	}
+
+	plain, compressed, err := gzipPair(body)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(plain)
+	hash := hex.EncodeToString(sum[:])
+
+	a.mu.Lock()
+	unchanged := a.last[feed] == hash
+	a.mu.Unlock()
+	if unchanged {
+		return nil
+	}
+
+	query := a.db.Builder.
+		Insert("raw_feeds").
+		Columns("sport", "feed", "fetched_at", "sha256", "size", "body").
+		Values(sport, feed, fetchedAt, hash, len(plain), compressed)
+
+	querySQL, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build raw feed query: %w", err)
+	}
+	if _, err := a.db.Conn.ExecContext(ctx, querySQL, args...); err != nil {
+		return fmt.Errorf("failed to insert raw feed: %w", err)
+	}
+
+	a.mu.Lock()
+	a.last[feed] = hash
+	a.mu.Unlock()
+	return nil
+}
+
+// gzipPair returns body both inflated and gzipped, compressing or inflating
+// whichever form Goalserve did not send
+func gzipPair(body []byte) (plain, compressed []byte, err error) {
+	if len(body) >= 2 && body[0] == 0x1f && body[1] == 0x8b {
+		gz, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open gzip body: %w", err)
+		}
+		plain, err = io.ReadAll(gz)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to inflate body: %w", err)
+		}
+		return plain, body, nil
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(body); err != nil {
+		return nil, nil, fmt.Errorf("failed to compress body: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, nil, fmt.Errorf("failed to compress body: %w", err)
+	}
+	return body, buf.Bytes(), nil
+}
+
+// PruneRawFeeds deletes archived payloads fetched more than retention ago
+func PruneRawFeeds(ctx context.Context, db *database.DB, retention time.Duration) (int64, error) {
+	query := db.Builder.
+		Delete("raw_feeds").
+		Where("fetched_at < ?", time.Now().Add(-retention))
+
+	querySQL, args, err := query.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build raw feed query: %w", err)
+	}
+	res, err := db.Conn.ExecContext(ctx, querySQL, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune raw feeds: %w", err)
+	}
+	return res.RowsAffected()
+}
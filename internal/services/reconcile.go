@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/dusanbre/otg-sports-api/internal/goalserve"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// missingAfterSyncs is how many consecutive syncs of its day a scheduled
+// match can be absent from before it is marked missing
+const missingAfterSyncs = 3
+
+// feedDays returns the days, in the feed's timezone, covered by the day feeds
+// from offset first to last, where home is 0 and dN is N
+func feedDays(first, last int) []time.Time {
+	today := goalserve.FeedDay(time.Now())
+
+	days := make([]time.Time, 0, last-first+1)
+	for offset := first; offset <= last; offset++ {
+		days = append(days, today.AddDate(0, 0, offset))
+	}
+	return days
+}
+
+// reconcileMissing compares the stored matches of the days a feed covers with
+// the matches it listed. A scheduled match the feed left out counts a missed
+// sync and is marked missing after missingAfterSyncs in a row; a listed match
+// starts over, and its upsert has already restored the feed's status. A day
+// is only reconciled when the feed carries matches on it, so an empty or
+// stale feed can't mark a whole day missing.
+func reconcileMissing(ctx context.Context, db *database.DB, table matchTable, runID uuid.UUID, days []time.Time, rows []matchRow, listed []int64) error {
+	carried := make(map[string]bool, len(days))
+	for _, row := range rows {
+		carried[row.day.Format("2006-01-02")] = true
+	}
+
+	var covered []string
+	for _, day := range days {
+		if date := day.Format("2006-01-02"); carried[date] {
+			covered = append(covered, date)
+		}
+	}
+
+	var missing []matchChange
+	err := db.WithTx(ctx, func(tx *sql.Tx) error {
+		missing = nil
+
+		if len(listed) > 0 {
+			query := db.Builder.
+				Update(table.name).
+				Set("missed_syncs", 0).
+				Where("match_id = ANY(?) AND missed_syncs > 0", pq.Array(listed))
+			if err := execQuery(ctx, tx, query); err != nil {
+				return fmt.Errorf("failed to reset missed syncs: %w", err)
+			}
+		}
+
+		if len(covered) == 0 {
+			return nil
+		}
+
+		query := db.Builder.
+			Update(table.name).
+			Set("missed_syncs", sq.Expr("missed_syncs + 1")).
+			Where(table.dateColumn+" = ANY(?::date[])", pq.Array(covered)).
+			Where("status = ANY(?)", pq.Array([]string{database.StatusScheduled, database.StatusMissing})).
+			Where("NOT (match_id = ANY(?))", pq.Array(listed))
+		if err := execQuery(ctx, tx, query); err != nil {
+			return fmt.Errorf("failed to count missed syncs: %w", err)
+		}
+
+		querySQL, args, err := db.Builder.
+			Update(table.name).
+			Set("status", database.StatusMissing).
+			Set("updated_at", sq.Expr("now()")).
+			Where(table.dateColumn+" = ANY(?::date[])", pq.Array(covered)).
+			Where("status = ? AND missed_syncs >= ?", database.StatusScheduled, missingAfterSyncs).
+			Suffix("RETURNING match_id").
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build missing matches query: %w", err)
+		}
+
+		dbRows, err := tx.QueryContext(ctx, querySQL, args...)
+		if err != nil {
+			return fmt.Errorf("failed to mark missing matches: %w", err)
+		}
+		defer dbRows.Close()
+
+		for dbRows.Next() {
+			change := matchChange{
+				field:    "status",
+				oldValue: sql.NullString{String: database.StatusScheduled, Valid: true},
+				newValue: sql.NullString{String: database.StatusMissing, Valid: true},
+			}
+			if err := dbRows.Scan(&change.matchID); err != nil {
+				return fmt.Errorf("failed to scan missing match: %w", err)
+			}
+			missing = append(missing, change)
+		}
+		if err := dbRows.Err(); err != nil {
+			return fmt.Errorf("failed to mark missing matches: %w", err)
+		}
+
+		return insertChanges(ctx, db, tx, table.sport, runID, missing)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, change := range missing {
+		log.Printf("Marked %s match %d missing, absent from %d syncs of its day", table.sport, change.matchID, missingAfterSyncs)
+	}
+	return nil
+}
+
+// execQuery builds and runs a statement that returns no rows
+func execQuery(ctx context.Context, tx *sql.Tx, query sq.Sqlizer) error {
+	querySQL, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, querySQL, args...)
+	return err
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/dusanbre/otg-sports-api/internal/goalserve"
+)
+
+// ReprocessOptions selects the matches a reprocess run rebuilds
+type ReprocessOptions struct {
+	Sport string    // "soccer", "basketball", or empty for both
+	Feed  string    // Feed path such as "soccernew/home"; only matches it listed are rebuilt
+	Since time.Time // Earliest fetch time, inclusive
+	Until time.Time // Only matches listed before this fetch time are rebuilt; zero for no bound
+}
+
+// ErrSyncRunning is returned by ReprocessService.Run while a sync worker holds
+// the sync leadership
+var ErrSyncRunning = errors.New("a sync worker is running; stop it before reprocessing")
+
+// ReprocessedFeed reports one archived payload read by a reprocess run
+type ReprocessedFeed struct {
+	Feed     database.RawFeed
+	Matches  int  // Matches in the payload
+	Selected bool // Whether the payload's matches are rebuilt, see ReprocessOptions
+	Err      error
+}
+
+// ReprocessService re-runs the current sync transforms over payloads stored
+// by RawFeedArchive, so a parser fix or a new column can be applied to
+// history without fetching from Goalserve again.
+type ReprocessService struct {
+	db         *database.DB
+	soccer     *SoccerSyncService
+	basketball *BasketballSyncService
+}
+
+// NewReprocessService creates a new reprocess service
+func NewReprocessService(db *database.DB) *ReprocessService {
+	return &ReprocessService{
+		db:         db,
+		soccer:     NewSoccerSyncService(db),
+		basketball: NewBasketballSyncService(db),
+	}
+}
+
+// Run rebuilds the selected matches from archived payloads and calls report
+// after reading each payload. It holds the sync leadership for the whole run,
+// so a live sync can't write in between, and returns ErrSyncRunning when
+// another instance holds it.
+//
+// Every payload fetched since opts.Since is read, whatever its feed, and each
+// match is written once, from the newest payload that listed it. Feed and
+// Until only choose which matches are written, so a subset can't roll a
+// match back to an older state. Each sport is written as one sync run with
+// the reprocess feed. A payload that no longer decodes is reported and
+// skipped.
+func (s *ReprocessService) Run(ctx context.Context, opts ReprocessOptions, report func(ReprocessedFeed)) (SyncResult, error) {
+	var total SyncResult
+	var runErr error
+	elector := NewLeaderElector(s.db, database.SyncLeadership)
+	led, err := elector.TryLead(ctx, func(ctx context.Context) {
+		total, runErr = s.run(ctx, opts, report)
+	})
+	if err != nil {
+		return total, fmt.Errorf("failed to take sync leadership: %w", err)
+	}
+	if !led {
+		return total, ErrSyncRunning
+	}
+	return total, runErr
+}
+
+// run reads the archive and writes each sport's latest match states
+func (s *ReprocessService) run(ctx context.Context, opts ReprocessOptions, report func(ReprocessedFeed)) (total SyncResult, err error) {
+	feeds, err := s.db.GetRawFeeds(database.RawFeedParams{
+		Sport: opts.Sport,
+		Since: opts.Since,
+	})
+	if err != nil {
+		return total, err
+	}
+	log.Printf("Reading %d archived feeds...", len(feeds))
+
+	soccer := newLatestMatches[goalserve.GoalServeSoccerCategory]()
+	basketball := newLatestMatches[goalserve.GoalServeBasketballCategory]()
+	for _, feed := range feeds {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+
+		selected := (opts.Feed == "" || feed.Feed == opts.Feed) &&
+			(opts.Until.IsZero() || feed.FetchedAt.Before(opts.Until))
+		matches, err := s.read(feed, selected, soccer, basketball)
+		report(ReprocessedFeed{Feed: feed, Matches: matches, Selected: selected, Err: err})
+
+		var decodeErr *feedDecodeError
+		if err != nil && !errors.As(err, &decodeErr) {
+			return total, err
+		}
+	}
+
+	if items := soccer.selected(); len(items) > 0 {
+		result, err := s.write(ctx, "soccer", func(run *syncRun) (SyncResult, error) {
+			return s.soccer.processScores(ctx, run.id, &goalserve.GoalServeSoccerScores{Categories: items}, nil)
+		})
+		total.Add(result)
+		if err != nil {
+			return total, err
+		}
+	}
+	if items := basketball.selected(); len(items) > 0 {
+		result, err := s.write(ctx, "basketball", func(run *syncRun) (SyncResult, error) {
+			return s.basketball.processScores(ctx, run.id, &goalserve.GoalServeBasketballScores{Categories: items}, nil)
+		})
+		total.Add(result)
+		if err != nil {
+			return total, err
+		}
+	}
+
+	return total, nil
+}
+
+// write records one sport's rebuild as a reprocess sync run
+func (s *ReprocessService) write(ctx context.Context, sport string, process func(run *syncRun) (SyncResult, error)) (SyncResult, error) {
+	run := startSyncRun(ctx, s.db, sport, database.SyncFeedReprocess)
+	result, err := process(run)
+	run.finish(result, err)
+	return result, err
+}
+
+// feedDecodeError marks an archived payload the current decoder can't read
+type feedDecodeError struct {
+	err error
+}
+
+func (e *feedDecodeError) Error() string { return e.err.Error() }
+
+func (e *feedDecodeError) Unwrap() error { return e.err }
+
+// read decodes one archived payload into its sport's latest match states and
+// returns how many matches it listed
+func (s *ReprocessService) read(feed database.RawFeed, selected bool, soccer *latestMatches[goalserve.GoalServeSoccerCategory], basketball *latestMatches[goalserve.GoalServeBasketballCategory]) (int, error) {
+	body, err := s.db.GetRawFeedBody(feed.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	matches := 0
+	switch feed.Sport {
+	case "soccer":
+		scores, err := goalserve.DecodeSoccerScores(bytes.NewReader(body))
+		if err != nil {
+			return 0, &feedDecodeError{fmt.Errorf("failed to decode raw feed %d: %w", feed.ID, err)}
+		}
+		for _, category := range scores.Categories {
+			for _, match := range category.Matches.Match {
+				soccer.add(match.ID, soccerFeedItem(category, match), selected)
+				matches++
+			}
+		}
+	case "basketball":
+		scores, err := goalserve.DecodeBasketballScores(bytes.NewReader(body))
+		if err != nil {
+			return 0, &feedDecodeError{fmt.Errorf("failed to decode raw feed %d: %w", feed.ID, err)}
+		}
+		for _, category := range scores.Categories {
+			for _, match := range category.Match.Matches {
+				basketball.add(match.ID, basketballFeedItem(category, match), selected)
+				matches++
+			}
+		}
+	default:
+		return 0, &feedDecodeError{fmt.Errorf("raw feed %d has unsupported sport %q", feed.ID, feed.Sport)}
+	}
+
+	return matches, nil
+}
+
+// latestMatches keeps the newest feed item of each match, in first-seen order
+type latestMatches[T any] struct {
+	order  []string
+	items  map[string]T
+	chosen map[string]bool
+}
+
+func newLatestMatches[T any]() *latestMatches[T] {
+	return &latestMatches[T]{items: make(map[string]T), chosen: make(map[string]bool)}
+}
+
+// add replaces the stored item of match id, marking the match for writing when
+// selected. A later unselected payload still updates a selected match.
+func (l *latestMatches[T]) add(id string, item T, selected bool) {
+	if _, ok := l.items[id]; !ok {
+		l.order = append(l.order, id)
+	}
+	l.items[id] = item
+	if selected {
+		l.chosen[id] = true
+	}
+}
+
+// selected returns the latest item of every match marked for writing
+func (l *latestMatches[T]) selected() []T {
+	var items []T
+	for _, id := range l.order {
+		if l.chosen[id] {
+			items = append(items, l.items[id])
+		}
+	}
+	return items
+}
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/dusanbre/otg-sports-api/internal/goalserve"
+	"github.com/lib/pq"
+)
+
+// matchEvent is one parsed soccer event ready to be written to soccer_match_events
+type matchEvent struct {
+	key         string // Unique per match, keeps the row stable across syncs
+	sequence    int
+	eventType   string
+	teamSide    sql.NullString
+	player      sql.NullString
+	playerID    sql.NullInt64
+	minute      sql.NullInt32
+	extraMinute sql.NullInt32
+	result      sql.NullString
+}
+
+// soccerEventColumns lists the soccer_match_events columns written by sync, in matchEvent order
+var soccerEventColumns = []string{
+	"match_id", "event_key", "sequence", "type", "team_side",
+	"player", "player_id", "minute", "extra_minute", "result",
+}
+
+// soccerMatchEvents parses the feed events of a match in feed order
+func soccerMatchEvents(events []goalserve.GoalServeSoccerEvent) []matchEvent {
+	parsed := make([]matchEvent, 0, len(events))
+	seen := make(map[string]int, len(events))
+
+	for i, e := range events {
+		event := matchEvent{
+			sequence:  i + 1,
+			eventType: strings.ToLower(strings.TrimSpace(e.Type)),
+			player:    nullString(e.Player),
+			result:    nullString(e.Result),
+		}
+		if event.eventType == "" {
+			event.eventType = "unknown"
+		}
+
+		switch e.Team {
+		case "localteam":
+			event.teamSide = sql.NullString{String: "home", Valid: true}
+		case "visitorteam":
+			event.teamSide = sql.NullString{String: "away", Valid: true}
+		}
+
+		if id, err := strconv.ParseInt(e.PlayerID, 10, 64); err == nil {
+			event.playerID = sql.NullInt64{Int64: id, Valid: true}
+		}
+
+		// Some feeds put added time into the minute itself, e.g. "90+3"
+		minute, extra := e.Minute, e.ExtraMin
+		if before, after, ok := strings.Cut(minute, "+"); ok {
+			minute = before
+			if extra == "" {
+				extra = after
+			}
+		}
+		event.minute = nullInt32(minute)
+		event.extraMinute = nullInt32(extra)
+
+		// Goalserve event IDs are stable; without one, fall back to the event's content
+		key := "id:" + e.EventID
+		if e.EventID == "" {
+			key = fmt.Sprintf("%s|%s|%s|%s|%s", event.eventType, e.Team, e.Player, minute, extra)
+		}
+		if n := seen[key]; n > 0 {
+			// Identical events (e.g. two unnamed cards in the same minute) stay distinct
+			seen[key] = n + 1
+			key = fmt.Sprintf("%s#%d", key, n+1)
+		} else {
+			seen[key] = 1
+		}
+		if len(key) > 100 {
+			key = key[:100]
+		}
+		event.key = key
+
+		parsed = append(parsed, event)
+	}
+
+	return parsed
+}
+
+// upsertSoccerEvents writes the events of a batch of matches. Events whose key
+// is already stored are only rewritten when they changed; stored events missing
+// from the feed (e.g. a goal ruled out) are removed. Matches whose feed carries
+// no events keep what is stored, as Goalserve sometimes drops the list briefly.
+func upsertSoccerEvents(ctx context.Context, db *database.DB, tx *sql.Tx, batch []matchRow) error {
+	type eventRow struct {
+		matchID int64
+		event   matchEvent
+	}
+
+	var rows []eventRow
+	var matchIDs []int64
+	var keyIDs []int64
+	var keys []string
+	for _, match := range batch {
+		if len(match.events) == 0 {
+			continue
+		}
+		matchIDs = append(matchIDs, match.matchID)
+		for _, e := range match.events {
+			rows = append(rows, eventRow{matchID: match.matchID, event: e})
+			keyIDs = append(keyIDs, match.matchID)
+			keys = append(keys, e.key)
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	deleteSQL := `DELETE FROM soccer_match_events e
+		WHERE e.match_id = ANY($1)
+		AND NOT EXISTS (
+			SELECT 1 FROM unnest($2::bigint[], $3::text[]) AS k(match_id, event_key)
+			WHERE k.match_id = e.match_id AND k.event_key = e.event_key
+		)`
+	if _, err := tx.ExecContext(ctx, deleteSQL, pq.Array(matchIDs), pq.Array(keyIDs), pq.Array(keys)); err != nil {
+		return fmt.Errorf("failed to remove stale soccer events: %w", err)
+	}
+
+	suffix := `ON CONFLICT (match_id, event_key) DO UPDATE SET
+		sequence = EXCLUDED.sequence,
+		type = EXCLUDED.type,
+		team_side = EXCLUDED.team_side,
+		player = EXCLUDED.player,
+		player_id = EXCLUDED.player_id,
+		minute = EXCLUDED.minute,
+		extra_minute = EXCLUDED.extra_minute,
+		result = EXCLUDED.result,
+		updated_at = now()
+		WHERE (soccer_match_events.sequence, soccer_match_events.type, soccer_match_events.team_side,
+			soccer_match_events.player, soccer_match_events.player_id, soccer_match_events.minute,
+			soccer_match_events.extra_minute, soccer_match_events.result)
+		IS DISTINCT FROM (EXCLUDED.sequence, EXCLUDED.type, EXCLUDED.team_side,
+			EXCLUDED.player, EXCLUDED.player_id, EXCLUDED.minute,
+			EXCLUDED.extra_minute, EXCLUDED.result)`
+
+	for start := 0; start < len(rows); start += upsertBatchSize {
+		chunk := rows[start:min(start+upsertBatchSize, len(rows))]
+
+		query := db.Builder.Insert("soccer_match_events").Columns(soccerEventColumns...)
+		for _, r := range chunk {
+			e := r.event
+			query = query.Values(r.matchID, e.key, e.sequence, e.eventType, e.teamSide,
+				e.player, e.playerID, e.minute, e.extraMinute, e.result)
+		}
+
+		querySQL, args, err := query.Suffix(suffix).ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build soccer events query: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, querySQL, args...); err != nil {
+			return fmt.Errorf("failed to upsert soccer events: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// nullString treats an empty feed attribute as NULL
+func nullString(s string) sql.NullString {
+	s = strings.TrimSpace(s)
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// nullInt32 parses a numeric feed attribute, NULL when empty or malformed
+func nullInt32(s string) sql.NullInt32 {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: int32(n), Valid: true}
+}
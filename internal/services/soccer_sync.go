@@ -1,8 +1,10 @@
 package services
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"strconv"
@@ -10,6 +12,7 @@ import (
 
 	"github.com/dusanbre/otg-sports-api/internal/database"
 	"github.com/dusanbre/otg-sports-api/internal/goalserve"
+	"github.com/google/uuid"
 )
 
 // SoccerSyncService handles syncing soccer matches from Goalserve to database
@@ -20,73 +23,193 @@ type SoccerSyncService struct {
 
 // NewSoccerSyncService creates a new soccer sync service
 func NewSoccerSyncService(db *database.DB) *SoccerSyncService {
+	client := goalserve.NewClient()
+	setRawFeedArchive(client, db)
+
 	return &SoccerSyncService{
 		db:              db,
-		goalserveClient: goalserve.NewClient(),
+		goalserveClient: client,
 	}
 }
 
-// SyncMatches fetches soccer matches from Goalserve and syncs them to the database
-func (s *SoccerSyncService) SyncMatches() error {
-	log.Println("Starting soccer match sync...")
+// SyncMatches fetches today's and the next 7 days' soccer matches and syncs them to the database.
+// A failed future-day sync is only logged, unless Goalserve rejected the API key.
+func (s *SoccerSyncService) SyncMatches(ctx context.Context) error {
+	if err := s.SyncToday(ctx); err != nil {
+		return err
+	}
 
-	// Fetch today's matches
-	soccerData, err := s.goalserveClient.FetchSoccerTodayMatches()
+	err := s.SyncFuture(ctx)
+	if errors.Is(err, context.Canceled) || errors.Is(err, goalserve.ErrAuthRejected) {
+		return err
+	} else if err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	return nil
+}
+
+// SyncToday fetches today's soccer feed, which carries the live scores, and syncs it
+func (s *SoccerSyncService) SyncToday(ctx context.Context) (err error) {
+	run := startSyncRun(ctx, s.db, "soccer", database.SyncFeedToday)
+	var result SyncResult
+	defer func() { run.finish(result, err) }()
+	log.Printf("Starting soccer today sync (run %s)...", run.id)
+
+	soccerData, err := s.goalserveClient.FetchSoccerTodayMatches(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to fetch today's soccer matches from Goalserve: %w", err)
 	}
+	run.fetched()
+
+	result, err = s.processScores(ctx, run.id, soccerData, feedDays(0, 0))
+	if err != nil {
+		return fmt.Errorf("failed to store today's soccer matches: %w", err)
+	}
+
+	log.Printf("Soccer today sync completed: %s", result)
+	return nil
+}
+
+// SyncFuture fetches the next 7 days of soccer fixtures and syncs them
+func (s *SoccerSyncService) SyncFuture(ctx context.Context) (err error) {
+	run := startSyncRun(ctx, s.db, "soccer", database.SyncFeedFuture)
+	var result SyncResult
+	defer func() { run.finish(result, err) }()
+	log.Printf("Starting soccer future sync (run %s)...", run.id)
+
+	futureData, err := s.goalserveClient.FetchSoccerMatchesFuture7Days(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch future soccer matches from Goalserve: %w", err)
+	}
+	run.fetched()
+
+	result, err = s.processScores(ctx, run.id, futureData, feedDays(1, 7))
+	if err != nil {
+		return fmt.Errorf("failed to store future soccer matches: %w", err)
+	}
+
+	log.Printf("Soccer future sync completed: %s", result)
+	return nil
+}
+
+// RetryDeadLetters reprocesses up to limit unresolved soccer dead letters,
+// resolving the ones that now sync
+func (s *SoccerSyncService) RetryDeadLetters(ctx context.Context, limit int) (result SyncResult, err error) {
+	run := startSyncRun(ctx, s.db, "soccer", database.SyncFeedRetry)
+	defer func() { run.finish(result, err) }()
+
+	letters, err := s.db.GetDeadLetters("soccer", limit)
+	if err != nil {
+		return result, err
+	}
+	log.Printf("Retrying %d soccer dead letters (run %s)...", len(letters), run.id)
+
+	scores := &goalserve.GoalServeSoccerScores{}
+	for _, letter := range letters {
+		var category goalserve.GoalServeSoccerCategory
+		if err := json.Unmarshal(letter.Payload, &category); err != nil {
+			log.Printf("Warning: skipping unreadable soccer dead letter %d: %v", letter.ID, err)
+			continue
+		}
+		scores.Categories = append(scores.Categories, category)
+	}
+
+	result, err = s.processScores(ctx, run.id, scores, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to store soccer dead letters: %w", err)
+	}
 
-	matchesInserted := 0
-	matchesUpdated := 0
+	log.Printf("Soccer dead letter retry completed: %s", result)
+	return result, nil
+}
+
+// syncDay fetches and upserts the soccer matches of a single feed day
+func (s *SoccerSyncService) syncDay(ctx context.Context, runID uuid.UUID, day time.Time) (SyncResult, error) {
+	scores, err := s.goalserveClient.FetchSoccerMatchesForDate(ctx, day)
+	if err != nil {
+		return SyncResult{}, fmt.Errorf("failed to fetch soccer matches for %s: %w", day.Format("2006-01-02"), err)
+	}
 
-	// Process today's matches
-	for _, category := range soccerData.Categories {
+	result, err := s.processScores(ctx, runID, scores, []time.Time{day})
+	if err != nil {
+		return result, fmt.Errorf("failed to store soccer matches for %s: %w", day.Format("2006-01-02"), err)
+	}
+	return result, nil
+}
+
+// processScores upserts every match in a fetched feed and dead-letters the ones
+// that fail. days are the feed days the payload covers, reconciled against the
+// stored matches; nil skips reconciliation.
+func (s *SoccerSyncService) processScores(ctx context.Context, runID uuid.UUID, scores *goalserve.GoalServeSoccerScores, days []time.Time) (SyncResult, error) {
+	var rows []matchRow
+	var listed []int64 // Every match in the feed, including those that fail below
+	var parseFailures SyncResult
+	for _, category := range scores.Categories {
 		for _, match := range category.Matches.Match {
-			inserted, err := s.upsertSoccerMatch(category, match)
+			if id, err := strconv.ParseInt(match.ID, 10, 64); err == nil {
+				listed = append(listed, id)
+			}
+
+			item := soccerFeedItem(category, match)
+			row, err := soccerMatchRow(category, match)
 			if err != nil {
-				log.Printf("Failed to upsert soccer match %s: %v", match.ID, err)
+				log.Printf("Failed to parse soccer match %s: %v", match.ID, err)
+				parseFailures.fail(match.ID, item, err)
 				continue
 			}
-			if inserted {
-				matchesInserted++
-			} else {
-				matchesUpdated++
-			}
+			row.item = item
+			rows = append(rows, row)
 		}
 	}
 
-	// Fetch future matches (next 7 days)
-	futureData, err := s.goalserveClient.FetchSoccerMatchesFuture7Days()
-	if err != nil {
-		log.Printf("Warning: failed to fetch future soccer matches: %v", err)
-	} else {
-		// Process future matches
-		for _, category := range futureData.Categories {
-			for _, match := range category.Matches.Match {
-				inserted, err := s.upsertSoccerMatch(category, match)
-				if err != nil {
-					log.Printf("Failed to upsert future soccer match %s: %v", match.ID, err)
-					continue
-				}
-				if inserted {
-					matchesInserted++
-				} else {
-					matchesUpdated++
-				}
+	result, err := upsertMatches(ctx, s.db, soccerMatchTable, runID, rows)
+	result.Add(parseFailures)
+	if err == nil {
+		// After a failed write nothing was stored, and the next run tries the whole feed again
+		recordDeadLetters(ctx, s.db, "soccer", runID, rows, result.deadLetters)
+
+		if len(days) > 0 {
+			if err := reconcileMissing(ctx, s.db, soccerMatchTable, runID, days, rows, listed); err != nil {
+				log.Printf("Warning: failed to reconcile soccer matches: %v", err)
 			}
 		}
 	}
+	return result, err
+}
 
-	log.Printf("Soccer match sync completed: %d inserted, %d updated", matchesInserted, matchesUpdated)
-	return nil
+// soccerMatchTable lists the soccer_matches columns written by sync
+var soccerMatchTable = matchTable{
+	name:       "soccer_matches",
+	sport:      "soccer",
+	dateColumn: "match_start_date",
+	columns: []string{
+		"match_id", "league_gid", "league_id", "league_name",
+		"match_status", "status", "match_start_date", "match_start_time", "kickoff_at",
+		"h_team_id", "a_team_id", "h_team_name", "a_team_name",
+		"h_team_goals", "a_team_goals", "ht_score", "ft_score", "events",
+	},
+	updateColumns: []string{
+		"match_status", "status", "match_start_date", "match_start_time", "kickoff_at",
+		"h_team_goals", "a_team_goals", "ht_score", "ft_score", "events",
+	},
+	// The raw status is the running minute while a match is live, so history keeps the canonical one
+	historyColumns: []string{"status", "kickoff_at", "h_team_goals", "a_team_goals", "ht_score", "ft_score"},
+	jsonColumns:    map[string]bool{"events": true},
+	afterBatch:     upsertSoccerEvents,
+}
+
+// soccerFeedItem wraps one match in its category, the way Goalserve lists it
+func soccerFeedItem(category goalserve.GoalServeSoccerCategory, match goalserve.GoalServeSoccerMatch) goalserve.GoalServeSoccerCategory {
+	category.Matches.Match = []goalserve.GoalServeSoccerMatch{match}
+	return category
 }
 
-// upsertSoccerMatch inserts or updates a soccer match in the database
-func (s *SoccerSyncService) upsertSoccerMatch(category goalserve.GoalServeSoccerCategory, match goalserve.GoalServeSoccerMatch) (bool, error) {
+// soccerMatchRow parses a feed match into the values written to soccer_matches
+func soccerMatchRow(category goalserve.GoalServeSoccerCategory, match goalserve.GoalServeSoccerMatch) (matchRow, error) {
 	// Parse match ID
 	matchID, err := strconv.ParseInt(match.ID, 10, 64)
 	if err != nil {
-		return false, fmt.Errorf("invalid match ID: %w", err)
+		return matchRow{}, fmt.Errorf("invalid match ID: %w", err)
 	}
 
 	// Parse league IDs
@@ -97,47 +220,15 @@ func (s *SoccerSyncService) upsertSoccerMatch(category goalserve.GoalServeSoccer
 	hTeamID, _ := strconv.ParseInt(match.LocalTeam.ID, 10, 64)
 	aTeamID, _ := strconv.ParseInt(match.VisitorTeam.ID, 10, 64)
 
-	// Parse date and time - use FormattedDate if available, otherwise fallback to Date
+	// Prefer FormattedDate (dd.MM.yyyy), Date is "Jan 2" without a year
 	dateStr := match.FormattedDate
 	if dateStr == "" {
 		dateStr = match.Date
 	}
 
-	// Parse combined date and time
-	var matchDate, matchTime time.Time
-	if dateStr == "" || match.Time == "" {
-		return false, fmt.Errorf("missing date or time data: date='%s', time='%s'", dateStr, match.Time)
-	}
-
-	// Try to parse combined date and time
-	dateTimeStr := dateStr + " " + match.Time
-	combinedDateTime, err := time.Parse("02.01.2006 15:04", dateTimeStr)
+	start, err := parseKickoff(dateStr, match.Time, goalserve.FeedLocation(), time.Now())
 	if err != nil {
-		// Try alternative date format (e.g., "Dec 22 2025 15:04")
-		combinedDateTime, err = time.Parse("Jan 2 2006 15:04", dateStr+" "+fmt.Sprintf("%d", time.Now().Year())+" "+match.Time)
-	}
-
-	if err == nil {
-		matchDate = combinedDateTime.Truncate(24 * time.Hour) // Date only (start of day)
-		// Extract only time portion for TIME column
-		year, month, day := time.Now().Date()
-		matchTime = time.Date(year, month, day, combinedDateTime.Hour(), combinedDateTime.Minute(), combinedDateTime.Second(), 0, time.UTC)
-	} else {
-		// Try separate parsing as fallback
-		matchDate, err = time.Parse("02.01.2006", dateStr)
-		if err != nil {
-			return false, fmt.Errorf("invalid date format: %s", dateStr)
-		}
-
-		// For time, extract only time portion for TIME column
-		timeOnly, err := time.Parse("15:04", match.Time)
-		if err != nil {
-			return false, fmt.Errorf("invalid time format: %s", match.Time)
-		}
-
-		// Use consistent date reference with parsed time
-		year, month, day := time.Now().Date()
-		matchTime = time.Date(year, month, day, timeOnly.Hour(), timeOnly.Minute(), 0, 0, time.UTC)
+		return matchRow{}, err
 	}
 
 	// Parse goals
@@ -164,86 +255,28 @@ func (s *SoccerSyncService) upsertSoccerMatch(category goalserve.GoalServeSoccer
 		ftScore = sql.NullString{String: match.FTScore.Score, Valid: true}
 	}
 
-	// Convert events to JSON - handle events which can be null or an object with event array
+	// Events are also kept as JSON on the match for older clients
 	eventsJSON := []byte("[]")
-	if match.Events != nil {
-		// Try to extract events if it's an object with event array
-		eventsBytes, err := json.Marshal(match.Events)
-		if err == nil {
-			var eventsWrapper struct {
-				Event []goalserve.GoalServeSoccerEvent `json:"event"`
-			}
-			if err := json.Unmarshal(eventsBytes, &eventsWrapper); err == nil && len(eventsWrapper.Event) > 0 {
-				eventsJSON, _ = json.Marshal(eventsWrapper.Event)
-			}
-		}
+	if len(match.Events.Event) > 0 {
+		eventsJSON, _ = json.Marshal(match.Events.Event)
 	}
 
-	// Check if match exists
-	var existingID int64
-	checkQuery := s.db.Builder.
-		Select("id").
-		From("soccer_matches").
-		Where("match_id = ?", matchID)
-
-	checkSQL, checkArgs, _ := checkQuery.ToSql()
-	err = s.db.Conn.QueryRow(checkSQL, checkArgs...).Scan(&existingID)
-
-	if err == sql.ErrNoRows {
-		// Insert new match
-		insertQuery := s.db.Builder.
-			Insert("soccer_matches").
-			Columns(
-				"match_id", "league_gid", "league_id", "league_name",
-				"match_status", "match_start_date", "match_start_time",
-				"h_team_id", "a_team_id", "h_team_name", "a_team_name",
-				"h_team_goals", "a_team_goals", "ht_score", "ft_score", "events",
-			).
-			Values(
-				matchID, leagueGid, leagueID, category.Name,
-				match.Status, matchDate, matchTime,
-				hTeamID, aTeamID, match.LocalTeam.Name, match.VisitorTeam.Name,
-				hGoals, aGoals, htScore, ftScore, string(eventsJSON),
-			)
-
-		insertSQL, insertArgs, err := insertQuery.ToSql()
-		if err != nil {
-			return false, fmt.Errorf("failed to build insert query: %w", err)
-		}
-
-		_, err = s.db.Conn.Exec(insertSQL, insertArgs...)
-		if err != nil {
-			return false, fmt.Errorf("failed to insert match: %w", err)
-		}
-
-		log.Printf("Inserted match: %s vs %s", match.LocalTeam.Name, match.VisitorTeam.Name)
-		return true, nil
-	} else if err == nil {
-		// Update existing match
-		updateQuery := s.db.Builder.
-			Update("soccer_matches").
-			Set("match_status", match.Status).
-			Set("h_team_goals", hGoals).
-			Set("a_team_goals", aGoals).
-			Set("ht_score", htScore).
-			Set("ft_score", ftScore).
-			Set("events", string(eventsJSON)).
-			Set("updated_at", time.Now()).
-			Where("match_id = ?", matchID)
-
-		updateSQL, updateArgs, err := updateQuery.ToSql()
-		if err != nil {
-			return false, fmt.Errorf("failed to build update query: %w", err)
-		}
-
-		_, err = s.db.Conn.Exec(updateSQL, updateArgs...)
-		if err != nil {
-			return false, fmt.Errorf("failed to update match: %w", err)
-		}
-
-		log.Printf("Updated match: %s vs %s", match.LocalTeam.Name, match.VisitorTeam.Name)
-		return false, nil
-	} else {
-		return false, fmt.Errorf("failed to check if match exists: %w", err)
-	}
+	return matchRow{
+		matchID: matchID,
+		feedID:  match.ID,
+		label:   match.LocalTeam.Name + " vs " + match.VisitorTeam.Name,
+		day:     start.date(),
+		values: []any{
+			matchID, leagueGid, leagueID, category.Name,
+			match.Status, NormalizeSoccerStatus(match.Status), start.date(), start.clock(), start.at,
+			hTeamID, aTeamID, match.LocalTeam.Name, match.VisitorTeam.Name,
+			hGoals, aGoals, htScore, ftScore, string(eventsJSON),
+		},
+		events: soccerMatchEvents(match.Events.Event),
+		entities: matchEntities{
+			leagueID: leagueID, leagueGID: leagueGid, leagueName: category.Name,
+			homeID: hTeamID, homeName: match.LocalTeam.Name,
+			awayID: aTeamID, awayName: match.VisitorTeam.Name,
+		},
+	}, nil
 }
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+)
+
+// soccerStatuses maps the named Goalserve soccer statuses, lowercased and
+// without trailing dots. Anything else is a minute, a kickoff time or unknown.
+var soccerStatuses = map[string]string{
+	"":            database.StatusScheduled,
+	"ns":          database.StatusScheduled,
+	"not started": database.StatusScheduled,
+	"tba":         database.StatusScheduled,
+	"delayed":     database.StatusScheduled,
+	"del":         database.StatusScheduled,
+	"1h":          database.StatusLive,
+	"2h":          database.StatusLive,
+	"et":          database.StatusLive,
+	"p":           database.StatusLive, // Penalty shootout in progress
+	"live":        database.StatusLive,
+	"in play":     database.StatusLive,
+	"ht":          database.StatusBreak,
+	"break time":  database.StatusBreak,
+	"bt":          database.StatusBreak,
+	"ft":          database.StatusFinished,
+	"aet":         database.StatusFinished,
+	"pen":         database.StatusFinished, // Decided on penalties
+	"ap":          database.StatusFinished,
+	"awarded":     database.StatusFinished,
+	"awd":         database.StatusFinished,
+	"wo":          database.StatusFinished,
+	"postp":       database.StatusPostponed,
+	"postponed":   database.StatusPostponed,
+	"canc":        database.StatusCancelled,
+	"cancelled":   database.StatusCancelled,
+	"cancl":       database.StatusCancelled,
+	"aban":        database.StatusAbandoned,
+	"abandoned":   database.StatusAbandoned,
+	"int":         database.StatusInterrupted,
+	"interrupted": database.StatusInterrupted,
+	"susp":        database.StatusInterrupted,
+	"suspended":   database.StatusInterrupted,
+}
+
+// basketballStatuses maps the named Goalserve basketball statuses, lowercased
+// and without trailing dots
+var basketballStatuses = map[string]string{
+	"":                database.StatusScheduled,
+	"not started":     database.StatusScheduled,
+	"ns":              database.StatusScheduled,
+	"tba":             database.StatusScheduled,
+	"delayed":         database.StatusScheduled,
+	"1st quarter":     database.StatusLive,
+	"2nd quarter":     database.StatusLive,
+	"3rd quarter":     database.StatusLive,
+	"4th quarter":     database.StatusLive,
+	"q1":              database.StatusLive,
+	"q2":              database.StatusLive,
+	"q3":              database.StatusLive,
+	"q4":              database.StatusLive,
+	"1st half":        database.StatusLive,
+	"2nd half":        database.StatusLive,
+	"overtime":        database.StatusLive,
+	"ot":              database.StatusLive,
+	"live":            database.StatusLive,
+	"in play":         database.StatusLive,
+	"break time":      database.StatusBreak,
+	"halftime":        database.StatusBreak,
+	"half time":       database.StatusBreak,
+	"ht":              database.StatusBreak,
+	"finished":        database.StatusFinished,
+	"final":           database.StatusFinished,
+	"ft":              database.StatusFinished,
+	"after over time": database.StatusFinished,
+	"aot":             database.StatusFinished,
+	"awarded":         database.StatusFinished,
+	"postponed":       database.StatusPostponed,
+	"postp":           database.StatusPostponed,
+	"cancelled":       database.StatusCancelled,
+	"canc":            database.StatusCancelled,
+	"abandoned":       database.StatusAbandoned,
+	"aban":            database.StatusAbandoned,
+	"interrupted":     database.StatusInterrupted,
+	"int":             database.StatusInterrupted,
+	"suspended":       database.StatusInterrupted,
+	"susp":            database.StatusInterrupted,
+}
+
+// NormalizeSoccerStatus maps a raw Goalserve soccer status to its canonical status.
+// While a match is running Goalserve sends the minute ("23", "45+2", "90+") instead of a name.
+func NormalizeSoccerStatus(raw string) string {
+	key := statusKey(raw)
+	if status, ok := soccerStatuses[key]; ok {
+		return status
+	}
+	if isMinute(key) {
+		return database.StatusLive
+	}
+	return database.StatusScheduled // Kickoff time ("15:00") or a status we don't know yet
+}
+
+// NormalizeBasketballStatus maps a raw Goalserve basketball status to its canonical status
+func NormalizeBasketballStatus(raw string) string {
+	if status, ok := basketballStatuses[statusKey(raw)]; ok {
+		return status
+	}
+	return database.StatusScheduled // Tip-off time or a status we don't know yet
+}
+
+// statusKey lowercases a raw status and drops abbreviation dots ("Postp." -> "postp")
+func statusKey(raw string) string {
+	return strings.TrimRight(strings.ToLower(strings.TrimSpace(raw)), ".")
+}
+
+// isMinute reports whether s is a match minute such as "67", "45+2" or "90+"
+func isMinute(s string) bool {
+	base, _, _ := strings.Cut(s, "+")
+	base = strings.TrimSuffix(base, "'")
+	n, err := strconv.Atoi(base)
+	return err == nil && n >= 0 && n <= 130
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+)
+
+func TestNormalizeSoccerStatus(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"", database.StatusScheduled},
+		{"NS", database.StatusScheduled},
+		{"15:00", database.StatusScheduled},
+		{"TBA", database.StatusScheduled},
+		{"1H", database.StatusLive},
+		{"23", database.StatusLive},
+		{"45+2", database.StatusLive},
+		{"90+", database.StatusLive},
+		{"67'", database.StatusLive},
+		{"0", database.StatusLive},
+		{"120+3", database.StatusLive},
+		{"131", database.StatusScheduled},
+		{"-5", database.StatusScheduled},
+		{"HT", database.StatusBreak},
+		{"Break Time", database.StatusBreak},
+		{"FT", database.StatusFinished},
+		{" ft ", database.StatusFinished},
+		{"AET", database.StatusFinished},
+		{"Pen.", database.StatusFinished},
+		{"Awarded", database.StatusFinished},
+		{"Postp.", database.StatusPostponed},
+		{"Canc.", database.StatusCancelled},
+		{"Aban.", database.StatusAbandoned},
+		{"Int.", database.StatusInterrupted},
+		{"Susp", database.StatusInterrupted},
+		{"Something new", database.StatusScheduled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			if got := NormalizeSoccerStatus(tt.raw); got != tt.want {
+				t.Errorf("NormalizeSoccerStatus(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+)
+
+// SyncCadence sets how often each kind of feed is refreshed
+type SyncCadence struct {
+	Live          time.Duration // Today feed while matches are in play or near kickoff
+	Today         time.Duration // Today feed when nothing is in play
+	Future        time.Duration // Next 7 days of fixtures
+	KickoffWindow time.Duration // How close to kickoff a scheduled match counts as active
+}
+
+// DefaultSyncCadence returns the cadence used when nothing is configured
+func DefaultSyncCadence() SyncCadence {
+	return SyncCadence{
+		Live:          10 * time.Second,
+		Today:         5 * time.Minute,
+		Future:        20 * time.Minute,
+		KickoffWindow: 15 * time.Minute,
+	}
+}
+
+// SyncCadenceFromEnv reads SYNC_LIVE_INTERVAL, SYNC_TODAY_INTERVAL,
+// SYNC_FUTURE_INTERVAL and SYNC_KICKOFF_WINDOW (Go durations such as "10s"),
+// keeping the default for any that are unset. Call Validate on the final cadence.
+func SyncCadenceFromEnv() (SyncCadence, error) {
+	cadence := DefaultSyncCadence()
+
+	for key, target := range map[string]*time.Duration{
+		"SYNC_LIVE_INTERVAL":   &cadence.Live,
+		"SYNC_TODAY_INTERVAL":  &cadence.Today,
+		"SYNC_FUTURE_INTERVAL": &cadence.Future,
+		"SYNC_KICKOFF_WINDOW":  &cadence.KickoffWindow,
+	} {
+		value := os.Getenv(key)
+		if value == "" {
+			continue
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return cadence, fmt.Errorf("invalid %s %q: %w", key, value, err)
+		}
+		*target = d
+	}
+
+	return cadence, nil
+}
+
+// Validate checks that the intervals are usable and ordered live <= today <= future
+func (c SyncCadence) Validate() error {
+	if c.Live < time.Second {
+		return fmt.Errorf("live interval %s is below 1s", c.Live)
+	}
+	if c.Today < c.Live {
+		return fmt.Errorf("today interval %s is shorter than the live interval %s", c.Today, c.Live)
+	}
+	if c.Future < c.Today {
+		return fmt.Errorf("future interval %s is shorter than the today interval %s", c.Future, c.Today)
+	}
+	if c.KickoffWindow < 0 {
+		return fmt.Errorf("kickoff window %s is negative", c.KickoffWindow)
+	}
+	return nil
+}
+
+// SyncPacer decides when one sport's today feed is due. It is meant to be
+// ticked every Live interval: each tick checks the stored matches and only
+// fetches when something is in play or about to start, or when the slower
+// Today interval has passed. Ticks must not overlap.
+type SyncPacer struct {
+	sport   string
+	db      *database.DB
+	cadence SyncCadence
+	today   func(context.Context) error
+	future  func(context.Context) error
+
+	lastToday time.Time
+	active    bool          // Whether the last tick saw active matches, to log transitions
+	backoff   time.Duration // Wait before retrying a failed sync, zero after a success
+	retryAt   time.Time
+}
+
+// NewSoccerSyncPacer creates a pacer for soccer syncs
+func NewSoccerSyncPacer(db *database.DB, cadence SyncCadence) *SyncPacer {
+	service := NewSoccerSyncService(db)
+	return &SyncPacer{sport: "soccer", db: db, cadence: cadence, today: service.SyncToday, future: service.SyncFuture}
+}
+
+// NewBasketballSyncPacer creates a pacer for basketball syncs
+func NewBasketballSyncPacer(db *database.DB, cadence SyncCadence) *SyncPacer {
+	service := NewBasketballSyncService(db)
+	return &SyncPacer{sport: "basketball", db: db, cadence: cadence, today: service.SyncToday, future: service.SyncFuture}
+}
+
+// Sport returns the sport this pacer syncs
+func (p *SyncPacer) Sport() string {
+	return p.sport
+}
+
+// Tick syncs today's feed if it is due at the current pace, reporting
+// whether it tried. The pace counts from the last successful sync. After a
+// failure the retry waits twice the Live interval, doubling with each failure
+// in a row up to the Today interval, so an outage isn't polled every tick.
+func (p *SyncPacer) Tick(ctx context.Context) (bool, error) {
+	active, err := p.db.CountActiveMatches(p.sport, p.cadence.KickoffWindow)
+	if err != nil {
+		// Keep the previous pace rather than guessing
+		log.Printf("Warning: failed to check active %s matches: %v", p.sport, err)
+	} else if (active > 0) != p.active {
+		p.active = active > 0
+		if p.active {
+			log.Printf("%d %s matches in play or near kickoff, syncing every %s", active, p.sport, p.cadence.Live)
+		} else {
+			log.Printf("No %s matches in play, syncing every %s", p.sport, p.cadence.Today)
+		}
+	}
+
+	interval := p.cadence.Today
+	if p.active {
+		interval = p.cadence.Live
+	}
+
+	// Half a tick of slack so scheduler jitter doesn't skip a due run
+	now := time.Now()
+	slack := p.cadence.Live / 2
+	switch {
+	case p.backoff > 0:
+		if now.Before(p.retryAt.Add(-slack)) {
+			return false, nil
+		}
+	case !p.lastToday.IsZero() && now.Sub(p.lastToday) < interval-slack:
+		return false, nil
+	}
+
+	if err := p.today(ctx); err != nil {
+		p.backoff = min(max(2*p.backoff, 2*p.cadence.Live), p.cadence.Today)
+		p.retryAt = now.Add(p.backoff)
+		return true, fmt.Errorf("%w (retrying in %s)", err, p.backoff)
+	}
+	p.lastToday = now
+	p.backoff = 0
+	return true, nil
+}
+
+// SyncFuture syncs the next 7 days of fixtures
+func (p *SyncPacer) SyncFuture(ctx context.Context) error {
+	return p.future(ctx)
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+)
+
+// SyncResult counts what a sync pass did to the stored matches
+type SyncResult struct {
+	Inserted  int `json:"inserted"`
+	Updated   int `json:"updated"`   // Existing rows whose data changed
+	Unchanged int `json:"unchanged"` // Existing rows that already matched the feed
+	Failed    int `json:"failed"`
+
+	Failures []database.SyncRunFailure `json:"failures,omitempty"` // Matches that failed to parse or store
+
+	deadLetters []deadLetter // The failed matches with their feed data
+}
+
+// Add accumulates other into r
+func (r *SyncResult) Add(other SyncResult) {
+	r.Inserted += other.Inserted
+	r.Updated += other.Updated
+	r.Unchanged += other.Unchanged
+	r.Failed += other.Failed
+	r.Failures = append(r.Failures, other.Failures...)
+	r.deadLetters = append(r.deadLetters, other.deadLetters...)
+}
+
+// fail counts a match that could not be synced. item is the feed data it came
+// from, kept in sync_dead_letters so it can be retried.
+func (r *SyncResult) fail(feedID string, item any, err error) {
+	r.Failed++
+	r.Failures = append(r.Failures, database.SyncRunFailure{MatchID: feedID, Error: err.Error()})
+	r.deadLetters = append(r.deadLetters, deadLetter{feedID: feedID, item: item, err: err.Error()})
+}
+
+// Total returns the number of matches processed
+func (r SyncResult) Total() int {
+	return r.Inserted + r.Updated + r.Unchanged + r.Failed
+}
+
+// String formats the counters for log lines
+func (r SyncResult) String() string {
+	return fmt.Sprintf("%d inserted, %d updated, %d unchanged, %d failed", r.Inserted, r.Updated, r.Unchanged, r.Failed)
+}
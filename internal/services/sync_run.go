@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/dusanbre/otg-sports-api/internal/goalserve"
+	"github.com/google/uuid"
+)
+
+// maxRecordedFailures caps the failures stored per run; the failed count still covers them all
+const maxRecordedFailures = 100
+
+// syncRun records one scheduled feed sync in sync_runs. Recording is best
+// effort: an audit row that can't be written is logged and never fails the sync.
+type syncRun struct {
+	db         *database.DB
+	id         uuid.UUID
+	sport      string
+	feed       string
+	httpStatus sql.NullInt32
+}
+
+// startSyncRun inserts the run as running
+func startSyncRun(ctx context.Context, db *database.DB, sport, feed string) *syncRun {
+	run := &syncRun{db: db, id: uuid.New(), sport: sport, feed: feed}
+
+	query := db.Builder.
+		Insert("sync_runs").
+		Columns("id", "sport", "feed", "status").
+		Values(run.id.String(), sport, feed, database.SyncRunRunning)
+
+	querySQL, args, err := query.ToSql()
+	if err == nil {
+		_, err = db.Conn.ExecContext(ctx, querySQL, args...)
+	}
+	if err != nil {
+		log.Printf("Warning: failed to record start of %s %s sync run %s: %v", sport, feed, run.id, err)
+	}
+
+	return run
+}
+
+// fetched records that Goalserve answered the feed request
+func (r *syncRun) fetched() {
+	r.httpStatus = sql.NullInt32{Int32: http.StatusOK, Valid: true}
+}
+
+// finish stores the outcome of the run. A failed Goalserve request records
+// its status code instead of the one set by fetched.
+func (r *syncRun) finish(result SyncResult, err error) {
+	status := database.SyncRunSucceeded
+	var errText sql.NullString
+	if err != nil {
+		status = database.SyncRunFailed
+		errText = sql.NullString{String: err.Error(), Valid: true}
+
+		var apiErr *goalserve.APIError
+		if errors.As(err, &apiErr) {
+			r.httpStatus = sql.NullInt32{Int32: int32(apiErr.StatusCode), Valid: apiErr.StatusCode != 0}
+		}
+	}
+
+	failures := result.Failures
+	if failures == nil {
+		failures = []database.SyncRunFailure{}
+	}
+	failures = failures[:min(len(failures), maxRecordedFailures)]
+	failuresJSON, _ := json.Marshal(failures)
+
+	// The sync's own context may be the reason it failed, so don't reuse it
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Upsert, in case the start row couldn't be written
+	query := r.db.Builder.
+		Insert("sync_runs").
+		Columns("id", "sport", "feed", "status", "finished_at", "http_status",
+			"inserted", "updated", "unchanged", "failed", "failures", "error").
+		Values(r.id.String(), r.sport, r.feed, status, sq.Expr("now()"), r.httpStatus,
+			result.Inserted, result.Updated, result.Unchanged, result.Failed, string(failuresJSON), errText).
+		Suffix(`ON CONFLICT (id) DO UPDATE SET
+			status = EXCLUDED.status,
+			finished_at = EXCLUDED.finished_at,
+			http_status = EXCLUDED.http_status,
+			inserted = EXCLUDED.inserted,
+			updated = EXCLUDED.updated,
+			unchanged = EXCLUDED.unchanged,
+			failed = EXCLUDED.failed,
+			failures = EXCLUDED.failures,
+			error = EXCLUDED.error`)
+
+	querySQL, args, qErr := query.ToSql()
+	if qErr == nil {
+		_, qErr = r.db.Conn.ExecContext(ctx, querySQL, args...)
+	}
+	if qErr != nil {
+		log.Printf("Warning: failed to record %s %s sync run %s: %v", r.sport, r.feed, r.id, qErr)
+	}
+}
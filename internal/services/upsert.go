@@ -0,0 +1,383 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// upsertBatchSize caps rows per INSERT statement, well below Postgres' 65535 bind parameters
+const upsertBatchSize = 500
+
+// matchTable describes how a sport's matches are upserted
+type matchTable struct {
+	name           string
+	sport          string
+	dateColumn     string          // Kickoff day in the feed's timezone, reconciled by reconcileMissing
+	columns        []string        // Insert columns, match_id first
+	updateColumns  []string        // Columns refreshed when the match already exists
+	historyColumns []string        // Update columns whose changes are kept in match_changes
+	jsonColumns    map[string]bool // Compared as text, json has no equality operator
+
+	// afterBatch writes rows that hang off the batch's matches, inside the same transaction
+	afterBatch func(ctx context.Context, db *database.DB, tx *sql.Tx, batch []matchRow) error
+}
+
+// matchRow is one parsed match ready to be written
+type matchRow struct {
+	matchID  int64
+	feedID   string    // Match ID as sent by Goalserve, keys dead letters
+	label    string    // "Home vs Away", for logs
+	day      time.Time // Kickoff day in the feed's timezone, as written to matchTable.dateColumn
+	values   []any     // In matchTable.columns order
+	events   []matchEvent
+	entities matchEntities // League and teams, upserted before the match and referenced by entityRefColumns
+	item     any           // Feed data the row was parsed from, dead-lettered if it can't be stored
+}
+
+// matchChange is one field of one match that changed during a sync run
+type matchChange struct {
+	matchID  int64
+	field    string
+	oldValue sql.NullString
+	newValue sql.NullString
+}
+
+// upsertMatches writes rows in one transaction using multi-row
+// INSERT ... ON CONFLICT (match_id) DO UPDATE. Rows are only rewritten when an
+// update column actually changed, and changes to history columns are recorded
+// in match_changes under runID. When Postgres rejects the data of a match, the
+// feed is written again in savepoints inside the same transaction, leaving
+// only the offending matches in result.Failures; any other failure rolls the
+// whole feed back.
+func upsertMatches(ctx context.Context, db *database.DB, table matchTable, runID uuid.UUID, rows []matchRow) (SyncResult, error) {
+	rows = dedupeRows(rows)
+	if len(rows) == 0 {
+		return SyncResult{}, nil
+	}
+
+	var result SyncResult
+	err := db.WithTx(ctx, func(tx *sql.Tx) error {
+		result = SyncResult{}
+		err := savepoint(ctx, tx, func() error {
+			return writeRows(ctx, db, tx, table, runID, rows, &result)
+		})
+		if err == nil || !isDataError(err) {
+			return err
+		}
+
+		log.Printf("Warning: %v; isolating the failing %s matches", err, table.sport)
+		result = SyncResult{}
+		return isolateFailures(ctx, db, tx, table, runID, rows, err, &result)
+	})
+	if err != nil {
+		return SyncResult{Failed: len(rows)}, err
+	}
+
+	return result, nil
+}
+
+// writeRows upserts rows within tx, adding their counts to result
+func writeRows(ctx context.Context, db *database.DB, tx *sql.Tx, table matchTable, runID uuid.UUID, rows []matchRow, result *SyncResult) error {
+	suffix := table.conflictClause()
+	rules, err := seasonRules()
+	if err != nil {
+		return fmt.Errorf("failed to load season rules: %w", err)
+	}
+
+	for start := 0; start < len(rows); start += upsertBatchSize {
+		batch := rows[start:min(start+upsertBatchSize, len(rows))]
+
+		// Lock the stored rows first so the old values can't move under us
+		previous, err := lockCurrentValues(ctx, tx, table, batch)
+		if err != nil {
+			return err
+		}
+
+		if err := upsertEntities(ctx, db, tx, rules, table.sport, batch); err != nil {
+			return err
+		}
+
+		query := db.Builder.Insert(table.name).Columns(slices.Concat(table.columns, entityRefColumns)...)
+		labels := make(map[int64]string, len(batch))
+		for _, row := range batch {
+			query = query.Values(slices.Concat(row.values, row.entities.refValues(rules, table.sport, row.day))...)
+			labels[row.matchID] = row.label
+		}
+
+		querySQL, args, err := query.Suffix(suffix).ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build upsert query: %w", err)
+		}
+
+		changes, written, err := execUpsert(ctx, tx, table, querySQL, args, labels, previous, result)
+		if err != nil {
+			return err
+		}
+		result.Unchanged += len(batch) - written
+
+		if err := insertChanges(ctx, db, tx, table.sport, runID, changes); err != nil {
+			return err
+		}
+
+		if table.afterBatch != nil {
+			if err := table.afterBatch(ctx, db, tx, batch); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// isolateFailures bisects rows that failed together with a data error,
+// writing each half in its own savepoint until the failing matches are
+// singled out. Other errors abort, as retrying smaller pieces won't help.
+func isolateFailures(ctx context.Context, db *database.DB, tx *sql.Tx, table matchTable, runID uuid.UUID, rows []matchRow, cause error, result *SyncResult) error {
+	if len(rows) == 1 {
+		log.Printf("Failed to store %s match %s: %v", table.sport, rows[0].label, cause)
+		result.fail(rows[0].feedID, rows[0].item, cause)
+		return nil
+	}
+
+	mid := len(rows) / 2
+	for _, half := range [][]matchRow{rows[:mid], rows[mid:]} {
+		var partial SyncResult
+		err := savepoint(ctx, tx, func() error {
+			return writeRows(ctx, db, tx, table, runID, half, &partial)
+		})
+		if err == nil {
+			result.Add(partial)
+			continue
+		}
+		if !isDataError(err) {
+			return err
+		}
+		if err := isolateFailures(ctx, db, tx, table, runID, half, err, result); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// savepoint runs fn inside a savepoint of tx, so a failure undoes only fn's
+// writes and leaves the transaction usable. Each savepoint is released before
+// the next one starts, so they can share a name.
+func savepoint(ctx context.Context, tx *sql.Tx, fn func() error) error {
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT upsert_rows"); err != nil {
+		return fmt.Errorf("failed to create savepoint: %w", err)
+	}
+
+	if err := fn(); err != nil {
+		if _, rbErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT upsert_rows"); rbErr != nil {
+			return fmt.Errorf("failed to roll back to savepoint: %w (after: %v)", rbErr, err)
+		}
+		if _, relErr := tx.ExecContext(ctx, "RELEASE SAVEPOINT upsert_rows"); relErr != nil {
+			return fmt.Errorf("failed to release savepoint: %w (after: %v)", relErr, err)
+		}
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT upsert_rows"); err != nil {
+		return fmt.Errorf("failed to release savepoint: %w", err)
+	}
+	return nil
+}
+
+// isDataError reports whether Postgres rejected the values being written
+// (data exception or integrity violation), as opposed to a connection or
+// server problem that would fail any statement
+func isDataError(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	class := pqErr.Code.Class()
+	return class == "22" || class == "23"
+}
+
+// lockCurrentValues loads the history columns of the stored rows in batch as
+// text and locks them until the transaction ends
+func lockCurrentValues(ctx context.Context, tx *sql.Tx, table matchTable, batch []matchRow) (map[int64][]sql.NullString, error) {
+	previous := make(map[int64][]sql.NullString)
+	if len(table.historyColumns) == 0 {
+		return previous, nil
+	}
+
+	ids := make([]int64, len(batch))
+	for i, row := range batch {
+		ids[i] = row.matchID
+	}
+
+	query := fmt.Sprintf("SELECT match_id, %s FROM %s WHERE match_id = ANY($1) FOR UPDATE",
+		table.textColumns(""), table.name)
+
+	rows, err := tx.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock %s: %w", table.name, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var matchID int64
+		values := make([]sql.NullString, len(table.historyColumns))
+		dest := []any{&matchID}
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", table.name, err)
+		}
+		previous[matchID] = values
+	}
+
+	return previous, rows.Err()
+}
+
+// execUpsert runs one batch, tallies the rows Postgres reports as written and
+// diffs updated rows against their previous values
+func execUpsert(ctx context.Context, tx *sql.Tx, table matchTable, querySQL string, args []any, labels map[int64]string, previous map[int64][]sql.NullString, result *SyncResult) ([]matchChange, int, error) {
+	dbRows, err := tx.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to upsert %s: %w", table.name, err)
+	}
+	defer dbRows.Close()
+
+	var changes []matchChange
+	written := 0
+	for dbRows.Next() {
+		var matchID int64
+		var inserted bool
+		current := make([]sql.NullString, len(table.historyColumns))
+		dest := []any{&matchID, &inserted}
+		for i := range current {
+			dest = append(dest, &current[i])
+		}
+		if err := dbRows.Scan(dest...); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan upsert result: %w", err)
+		}
+		written++
+
+		if inserted {
+			result.Inserted++
+			log.Printf("Inserted %s match: %s", table.sport, labels[matchID])
+			continue
+		}
+
+		result.Updated++
+		log.Printf("Updated %s match: %s", table.sport, labels[matchID])
+
+		old := previous[matchID]
+		for i, field := range table.historyColumns {
+			if old != nil && old[i] == current[i] {
+				continue
+			}
+			change := matchChange{matchID: matchID, field: field, newValue: current[i]}
+			if old != nil {
+				change.oldValue = old[i]
+			}
+			changes = append(changes, change)
+		}
+	}
+	if err := dbRows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to upsert %s: %w", table.name, err)
+	}
+
+	return changes, written, nil
+}
+
+// insertChanges appends field changes to match_changes
+func insertChanges(ctx context.Context, db *database.DB, tx *sql.Tx, sport string, runID uuid.UUID, changes []matchChange) error {
+	// changed_at defaults to now(), the transaction time also written to updated_at.
+	// Six columns per change, so the same batch size stays far below the parameter limit
+	for start := 0; start < len(changes); start += upsertBatchSize {
+		batch := changes[start:min(start+upsertBatchSize, len(changes))]
+
+		query := db.Builder.
+			Insert("match_changes").
+			Columns("sport", "match_id", "field", "old_value", "new_value", "sync_run_id")
+		for _, c := range batch {
+			query = query.Values(sport, c.matchID, c.field, c.oldValue, c.newValue, runID.String())
+		}
+
+		querySQL, args, err := query.ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build change history query: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, querySQL, args...); err != nil {
+			return fmt.Errorf("failed to record match changes: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// conflictClause builds the ON CONFLICT suffix. xmax is 0 only for freshly
+// inserted tuples, which tells inserts and updates apart in RETURNING.
+func (t matchTable) conflictClause() string {
+	sets := make([]string, 0, len(t.updateColumns)+2)
+	current := make([]string, 0, len(t.updateColumns))
+	incoming := make([]string, 0, len(t.updateColumns))
+	for _, col := range slices.Concat(t.updateColumns, entityRefColumns) {
+		sets = append(sets, fmt.Sprintf("%s = EXCLUDED.%s", col, col))
+		cast := ""
+		if t.jsonColumns[col] {
+			cast = "::text"
+		}
+		current = append(current, fmt.Sprintf("%s.%s%s", t.name, col, cast))
+		incoming = append(incoming, fmt.Sprintf("EXCLUDED.%s%s", col, cast))
+	}
+	// Keep the kickoff a match is moved away from, so reschedules stay visible
+	sets = append(sets, fmt.Sprintf(
+		"previous_kickoff_at = CASE WHEN %[1]s.kickoff_at IS NOT NULL AND %[1]s.kickoff_at IS DISTINCT FROM EXCLUDED.kickoff_at THEN %[1]s.kickoff_at ELSE %[1]s.previous_kickoff_at END",
+		t.name,
+	))
+	sets = append(sets, "updated_at = now()")
+
+	returning := "match_id, (xmax = 0) AS inserted"
+	if len(t.historyColumns) > 0 {
+		returning += ", " + t.textColumns(t.name+".")
+	}
+
+	return fmt.Sprintf(
+		"ON CONFLICT (match_id) DO UPDATE SET %s WHERE (%s) IS DISTINCT FROM (%s) RETURNING %s",
+		strings.Join(sets, ", "), strings.Join(current, ", "), strings.Join(incoming, ", "), returning,
+	)
+}
+
+// textColumns lists the history columns cast to text, so old and new values
+// compare and store the same way regardless of column type
+func (t matchTable) textColumns(prefix string) string {
+	cols := make([]string, len(t.historyColumns))
+	for i, col := range t.historyColumns {
+		cols[i] = fmt.Sprintf("%s%s::text", prefix, col)
+	}
+	return strings.Join(cols, ", ")
+}
+
+// dedupeRows keeps the last occurrence of each match_id. ON CONFLICT cannot
+// touch the same row twice in one statement, and feeds merged from several
+// days can repeat a match.
+func dedupeRows(rows []matchRow) []matchRow {
+	index := make(map[int64]int, len(rows))
+	deduped := make([]matchRow, 0, len(rows))
+	for _, row := range rows {
+		if i, ok := index[row.matchID]; ok {
+			deduped[i] = row
+			continue
+		}
+		index[row.matchID] = len(deduped)
+		deduped = append(deduped, row)
+	}
+	return deduped
+}
@@ -0,0 +1,188 @@
+package standings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// defaultConfigPath is read when STANDINGS_CONFIG is unset
+const defaultConfigPath = "etc/standings.json"
+
+// Tie-break criteria, compared highest first. The h2h_ criteria only count the
+// matches between the teams still tied at that point.
+const (
+	ByPoints        = "points"
+	ByWinPct        = "win_pct"
+	ByWins          = "wins"
+	ByDifference    = "difference" // Goal or point difference
+	ByScored        = "scored"     // Goals or points for
+	ByAwayScored    = "away_scored"
+	ByH2HPoints     = "h2h_points"
+	ByH2HWinPct     = "h2h_win_pct"
+	ByH2HDifference = "h2h_difference"
+	ByH2HScored     = "h2h_scored"
+	ByH2HAwayScored = "h2h_away_scored"
+)
+
+// criteria lists every tie-break criterion
+var criteria = []string{
+	ByPoints, ByWinPct, ByWins, ByDifference, ByScored, ByAwayScored,
+	ByH2HPoints, ByH2HWinPct, ByH2HDifference, ByH2HScored, ByH2HAwayScored,
+}
+
+// Points awards table points per result
+type Points struct {
+	Win  int `json:"win"`
+	Draw int `json:"draw"`
+	Loss int `json:"loss"`
+}
+
+// Rules decide how a competition's table is built. The first tie-breaker is
+// the main ranking; teams equal on every criterion are ordered by name.
+type Rules struct {
+	Points      Points     `json:"points"`
+	TieBreakers []string   `json:"tie_breakers"`
+	SeasonStart time.Month `json:"season_start"` // Month the league's seasons start in, 1-12
+}
+
+// Validate checks that every tie-breaker is a known criterion and the season
+// start is a month
+func (r Rules) Validate() error {
+	if len(r.TieBreakers) == 0 {
+		return errors.New("no tie_breakers")
+	}
+	if r.SeasonStart < time.January || r.SeasonStart > time.December {
+		return fmt.Errorf("season_start %d is not a month", r.SeasonStart)
+	}
+	for _, c := range r.TieBreakers {
+		if !slices.Contains(criteria, c) {
+			return fmt.Errorf("unknown tie-breaker %q", c)
+		}
+	}
+	return nil
+}
+
+// defaultRules rank soccer by points and basketball by win percentage, in
+// seasons running from July to June
+var defaultRules = map[string]Rules{
+	"soccer": {
+		Points:      Points{Win: 3, Draw: 1, Loss: 0},
+		TieBreakers: []string{ByPoints, ByDifference, ByScored},
+		SeasonStart: time.July,
+	},
+	"basketball": {
+		Points:      Points{Win: 2, Draw: 1, Loss: 1},
+		TieBreakers: []string{ByWinPct, ByH2HWinPct, ByH2HDifference, ByDifference, ByScored},
+		SeasonStart: time.July,
+	},
+}
+
+// sportRules are the rules of one sport in the config file
+type sportRules struct {
+	Default      *rulesOverride           `json:"default"`
+	Competitions map[string]rulesOverride `json:"competitions"` // Keyed by Goalserve league ID or league name
+}
+
+// rulesOverride replaces the fields it sets
+type rulesOverride struct {
+	Points      *Points    `json:"points"`
+	TieBreakers []string   `json:"tie_breakers"`
+	SeasonStart time.Month `json:"season_start"`
+}
+
+func (o rulesOverride) apply(r Rules) Rules {
+	if o.Points != nil {
+		r.Points = *o.Points
+	}
+	if len(o.TieBreakers) > 0 {
+		r.TieBreakers = o.TieBreakers
+	}
+	if o.SeasonStart != 0 {
+		r.SeasonStart = o.SeasonStart
+	}
+	return r
+}
+
+// Config holds the table and season rules of every sport and competition
+type Config struct {
+	sports map[string]sportRules
+}
+
+// DefaultConfig returns the built-in rules, with no competition overrides
+func DefaultConfig() *Config {
+	return &Config{sports: map[string]sportRules{}}
+}
+
+// LoadConfig reads competition rules from a JSON file such as etc/standings.json
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read standings config: %w", err)
+	}
+
+	var sports map[string]sportRules
+	if err := json.Unmarshal(data, &sports); err != nil {
+		return nil, fmt.Errorf("failed to parse standings config %s: %w", path, err)
+	}
+
+	c := &Config{sports: sports}
+	for sport, sr := range sports {
+		if _, ok := defaultRules[sport]; !ok {
+			return nil, fmt.Errorf("standings config %s: unsupported sport %q", path, sport)
+		}
+		if err := c.rulesFor(sport, sr.Default, nil).Validate(); err != nil {
+			return nil, fmt.Errorf("standings config %s: %s default: %w", path, sport, err)
+		}
+		for key, o := range sr.Competitions {
+			if err := c.rulesFor(sport, sr.Default, &o).Validate(); err != nil {
+				return nil, fmt.Errorf("standings config %s: %s competition %q: %w", path, sport, key, err)
+			}
+		}
+	}
+	return c, nil
+}
+
+// ConfigFromEnv loads STANDINGS_CONFIG, or etc/standings.json when it is
+// unset. A missing default file leaves the built-in rules.
+func ConfigFromEnv() (*Config, error) {
+	path := os.Getenv("STANDINGS_CONFIG")
+	if path == "" {
+		c, err := LoadConfig(defaultConfigPath)
+		if errors.Is(err, fs.ErrNotExist) {
+			return DefaultConfig(), nil
+		}
+		return c, err
+	}
+	return LoadConfig(path)
+}
+
+// Rules returns the rules of a competition, matched by Goalserve league ID
+// first and league name second
+func (c *Config) Rules(sport string, leagueID int64, leagueName string) Rules {
+	sr := c.sports[sport]
+	if o, ok := sr.Competitions[strconv.FormatInt(leagueID, 10)]; ok {
+		return c.rulesFor(sport, sr.Default, &o)
+	}
+	if o, ok := sr.Competitions[leagueName]; ok {
+		return c.rulesFor(sport, sr.Default, &o)
+	}
+	return c.rulesFor(sport, sr.Default, nil)
+}
+
+// rulesFor layers the sport default and a competition override over the built-in rules
+func (c *Config) rulesFor(sport string, def, competition *rulesOverride) Rules {
+	r := defaultRules[sport]
+	if def != nil {
+		r = def.apply(r)
+	}
+	if competition != nil {
+		r = competition.apply(r)
+	}
+	return r
+}
@@ -0,0 +1,192 @@
+// Package standings builds league tables from stored match results.
+package standings
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+)
+
+// Record sums a team's results over a set of matches
+type Record struct {
+	Played  int
+	Won     int
+	Drawn   int
+	Lost    int
+	For     int // Goals, or points in basketball
+	Against int
+	Points  int // Table points under the competition's Rules
+	AwayFor int // For, in away matches only
+}
+
+// Difference is For minus Against
+func (r Record) Difference() int {
+	return r.For - r.Against
+}
+
+// WinPct is the share of matches won, a draw counting half. Zero before any
+// match is played.
+func (r Record) WinPct() float64 {
+	if r.Played == 0 {
+		return 0
+	}
+	return (float64(r.Won) + float64(r.Drawn)/2) / float64(r.Played)
+}
+
+// add counts one result of a team scoring own and conceding other
+func (r *Record) add(own, other int, away bool, points Points) {
+	r.Played++
+	r.For += own
+	r.Against += other
+	if away {
+		r.AwayFor += own
+	}
+	switch {
+	case own > other:
+		r.Won++
+		r.Points += points.Win
+	case own < other:
+		r.Lost++
+		r.Points += points.Loss
+	default:
+		r.Drawn++
+		r.Points += points.Draw
+	}
+}
+
+// Row is one team's line of a table
+type Row struct {
+	Position int
+	TeamID   int64 // Goalserve team ID
+	TeamName string
+	Record          // All matches
+	Home     Record // Home matches only
+	Away     Record // Away matches only
+}
+
+// Compute builds the table of results under rules. Results should be oldest
+// first; a team is named as in its latest match.
+func Compute(results []database.MatchResult, rules Rules) []Row {
+	index := make(map[int64]int)
+	var rows []Row
+	team := func(id int64, name string) *Row {
+		i, ok := index[id]
+		if !ok {
+			i = len(rows)
+			index[id] = i
+			rows = append(rows, Row{TeamID: id})
+		}
+		if name != "" {
+			rows[i].TeamName = name
+		}
+		return &rows[i]
+	}
+
+	for _, m := range results {
+		home := team(m.HomeTeamID, m.HomeTeamName)
+		home.Record.add(m.HomeScore, m.AwayScore, false, rules.Points)
+		home.Home.add(m.HomeScore, m.AwayScore, false, rules.Points)
+
+		away := team(m.AwayTeamID, m.AwayTeamName)
+		away.Record.add(m.AwayScore, m.HomeScore, true, rules.Points)
+		away.Away.add(m.AwayScore, m.HomeScore, true, rules.Points)
+	}
+
+	// Settle ties one criterion at a time: each criterion only reorders the
+	// teams the previous ones left level, which is what head-to-head needs
+	slices.SortFunc(rows, func(a, b Row) int {
+		return cmp.Or(cmp.Compare(a.TeamName, b.TeamName), cmp.Compare(a.TeamID, b.TeamID))
+	})
+	groups := [][]Row{rows}
+	for _, criterion := range rules.TieBreakers {
+		var next [][]Row
+		for _, group := range groups {
+			next = append(next, rank(group, criterion, results, rules.Points)...)
+		}
+		groups = next
+	}
+
+	for i := range rows {
+		rows[i].Position = i + 1
+	}
+	return rows
+}
+
+// rank stably orders a group of level teams by criterion, best first, and
+// splits it into the groups that are still level
+func rank(group []Row, criterion string, results []database.MatchResult, points Points) [][]Row {
+	if len(group) < 2 {
+		return [][]Row{group}
+	}
+
+	var mini map[int64]*Record
+	if isHeadToHead(criterion) {
+		mini = headToHead(group, results, points)
+	}
+	key := func(r Row) float64 {
+		rec := r.Record
+		if mini != nil {
+			rec = *mini[r.TeamID]
+		}
+		switch criterion {
+		case ByPoints, ByH2HPoints:
+			return float64(rec.Points)
+		case ByWinPct, ByH2HWinPct:
+			return rec.WinPct()
+		case ByWins:
+			return float64(rec.Won)
+		case ByDifference, ByH2HDifference:
+			return float64(rec.Difference())
+		case ByScored, ByH2HScored:
+			return float64(rec.For)
+		case ByAwayScored, ByH2HAwayScored:
+			return float64(rec.AwayFor)
+		}
+		return 0
+	}
+
+	keys := make(map[int64]float64, len(group))
+	for _, r := range group {
+		keys[r.TeamID] = key(r)
+	}
+	slices.SortStableFunc(group, func(a, b Row) int {
+		return cmp.Compare(keys[b.TeamID], keys[a.TeamID])
+	})
+
+	var split [][]Row
+	start := 0
+	for i := 1; i <= len(group); i++ {
+		if i == len(group) || keys[group[i].TeamID] != keys[group[start].TeamID] {
+			split = append(split, group[start:i])
+			start = i
+		}
+	}
+	return split
+}
+
+// isHeadToHead reports whether criterion only counts matches among the tied teams
+func isHeadToHead(criterion string) bool {
+	switch criterion {
+	case ByH2HPoints, ByH2HWinPct, ByH2HDifference, ByH2HScored, ByH2HAwayScored:
+		return true
+	}
+	return false
+}
+
+// headToHead sums the results of the matches played between teams of group
+func headToHead(group []Row, results []database.MatchResult, points Points) map[int64]*Record {
+	mini := make(map[int64]*Record, len(group))
+	for _, r := range group {
+		mini[r.TeamID] = &Record{}
+	}
+	for _, m := range results {
+		home, away := mini[m.HomeTeamID], mini[m.AwayTeamID]
+		if home == nil || away == nil {
+			continue
+		}
+		home.add(m.HomeScore, m.AwayScore, false, points)
+		away.add(m.AwayScore, m.HomeScore, true, points)
+	}
+	return mini
+}
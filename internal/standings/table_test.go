@@ -0,0 +1,175 @@
+package standings
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dusanbre/otg-sports-api/internal/database"
+)
+
+// teamIDs names the teams of the test tables
+var teamIDs = map[string]int64{"A": 1, "B": 2, "C": 3, "D": 4}
+
+// played is a finished match between two teams of teamIDs
+func played(home, away string, homeScore, awayScore int) database.MatchResult {
+	return database.MatchResult{
+		HomeTeamID:   teamIDs[home],
+		HomeTeamName: home,
+		AwayTeamID:   teamIDs[away],
+		AwayTeamName: away,
+		HomeScore:    homeScore,
+		AwayScore:    awayScore,
+	}
+}
+
+func TestCompute(t *testing.T) {
+	soccer := defaultRules["soccer"]
+	basketball := defaultRules["basketball"]
+
+	tests := []struct {
+		name    string
+		rules   Rules
+		results []database.MatchResult
+		want    []string
+	}{
+		{
+			name:  "points",
+			rules: soccer,
+			results: []database.MatchResult{
+				played("A", "B", 2, 0),
+				played("A", "C", 1, 1),
+				played("B", "C", 1, 0),
+			},
+			want: []string{"A", "B", "C"},
+		},
+		{
+			name:  "difference settles level points",
+			rules: soccer,
+			results: []database.MatchResult{
+				played("C", "A", 0, 3),
+				played("B", "C", 1, 0),
+				played("A", "B", 0, 0),
+			},
+			want: []string{"A", "B", "C"},
+		},
+		{
+			name:  "scored settles level difference",
+			rules: soccer,
+			results: []database.MatchResult{
+				played("B", "A", 2, 2),
+				played("A", "C", 2, 1),
+				played("B", "C", 1, 0),
+			},
+			want: []string{"A", "B", "C"},
+		},
+		{
+			name:    "name orders teams level on everything",
+			rules:   soccer,
+			results: []database.MatchResult{played("B", "A", 1, 1)},
+			want:    []string{"A", "B"},
+		},
+		{
+			// A, B and C win two each and beat one another in a circle, so
+			// the mini-table of their three matches decides, not B's rout of D
+			name:  "h2h difference in a three-way tie",
+			rules: basketball,
+			results: []database.MatchResult{
+				played("A", "B", 90, 80),
+				played("B", "C", 85, 80),
+				played("C", "A", 83, 80),
+				played("A", "D", 81, 80),
+				played("B", "D", 130, 80),
+				played("C", "D", 81, 80),
+			},
+			want: []string{"A", "C", "B", "D"},
+		},
+		{
+			// h2h_points splits C off, then h2h_difference only counts the
+			// A-B draw, so overall difference puts B ahead of A although A
+			// beat C by more
+			name: "h2h recomputed for the teams still level",
+			rules: Rules{
+				Points:      Points{Win: 3, Draw: 1},
+				TieBreakers: []string{ByPoints, ByH2HPoints, ByH2HDifference, ByDifference},
+				SeasonStart: time.July,
+			},
+			results: []database.MatchResult{
+				played("A", "C", 3, 0),
+				played("B", "C", 1, 0),
+				played("A", "B", 0, 0),
+				played("D", "A", 5, 0),
+				played("D", "B", 1, 0),
+				played("C", "D", 1, 0),
+				played("D", "C", 0, 0),
+			},
+			want: []string{"D", "B", "A", "C"},
+		},
+		{
+			name: "h2h away scored",
+			rules: Rules{
+				Points:      Points{Win: 3, Draw: 1},
+				TieBreakers: []string{ByPoints, ByH2HPoints, ByH2HDifference, ByH2HAwayScored},
+				SeasonStart: time.July,
+			},
+			results: []database.MatchResult{
+				played("A", "B", 1, 2),
+				played("B", "A", 0, 1),
+			},
+			want: []string{"B", "A"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := Compute(tt.results, tt.rules)
+
+			var got []string
+			for i, row := range rows {
+				if row.Position != i+1 {
+					t.Errorf("%s at index %d has position %d", row.TeamName, i, row.Position)
+				}
+				got = append(got, row.TeamName)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeRecords(t *testing.T) {
+	rows := Compute([]database.MatchResult{
+		played("A", "B", 2, 1),
+		played("B", "A", 3, 3),
+		played("C", "A", 2, 0),
+	}, defaultRules["soccer"])
+
+	tests := []struct {
+		team string
+		want Row
+	}{
+		{"A", Row{
+			Record: Record{Played: 3, Won: 1, Drawn: 1, Lost: 1, For: 5, Against: 6, Points: 4, AwayFor: 3},
+			Home:   Record{Played: 1, Won: 1, For: 2, Against: 1, Points: 3},
+			Away:   Record{Played: 2, Drawn: 1, Lost: 1, For: 3, Against: 5, Points: 1, AwayFor: 3},
+		}},
+		{"B", Row{
+			Record: Record{Played: 2, Drawn: 1, Lost: 1, For: 4, Against: 5, Points: 1, AwayFor: 1},
+			Home:   Record{Played: 1, Drawn: 1, For: 3, Against: 3, Points: 1},
+			Away:   Record{Played: 1, Lost: 1, For: 1, Against: 2, AwayFor: 1},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.team, func(t *testing.T) {
+			i := slices.IndexFunc(rows, func(r Row) bool { return r.TeamName == tt.team })
+			if i < 0 {
+				t.Fatalf("no row for %s", tt.team)
+			}
+			got := rows[i]
+			if got.Record != tt.want.Record || got.Home != tt.want.Home || got.Away != tt.want.Away {
+				t.Errorf("records = %+v / %+v / %+v, want %+v / %+v / %+v",
+					got.Record, got.Home, got.Away, tt.want.Record, tt.want.Home, tt.want.Away)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+CREATE TABLE "backfill_progress" (
+	"id" bigint PRIMARY KEY GENERATED ALWAYS AS IDENTITY (sequence name "backfill_progress_id_seq" INCREMENT BY 1 MINVALUE 1 MAXVALUE 9223372036854775807 START WITH 1 CACHE 1),
+	"sport" varchar(20) NOT NULL,
+	"day" date NOT NULL,
+	"inserted" integer DEFAULT 0 NOT NULL,
+	"updated" integer DEFAULT 0 NOT NULL,
+	"unchanged" integer DEFAULT 0 NOT NULL,
+	"failed" integer DEFAULT 0 NOT NULL,
+	"completed_at" timestamp DEFAULT now(),
+	CONSTRAINT "backfill_progress_sport_day_unique" UNIQUE("sport","day")
+);
@@ -0,0 +1,14 @@
+CREATE TABLE "match_changes" (
+	"id" bigint PRIMARY KEY GENERATED ALWAYS AS IDENTITY (sequence name "match_changes_id_seq" INCREMENT BY 1 MINVALUE 1 MAXVALUE 9223372036854775807 START WITH 1 CACHE 1),
+	"sport" varchar(20) NOT NULL,
+	"match_id" bigint NOT NULL,
+	"field" varchar(50) NOT NULL,
+	"old_value" text,
+	"new_value" text,
+	"changed_at" timestamp DEFAULT now() NOT NULL,
+	"sync_run_id" uuid NOT NULL
+);
+--> statement-breakpoint
+CREATE INDEX "match_changes_sport_match_id_idx" ON "match_changes" USING btree ("sport","match_id");
+--> statement-breakpoint
+CREATE INDEX "match_changes_sync_run_id_idx" ON "match_changes" USING btree ("sync_run_id");
@@ -0,0 +1,18 @@
+CREATE TABLE "soccer_match_events" (
+	"id" bigint PRIMARY KEY GENERATED ALWAYS AS IDENTITY (sequence name "soccer_match_events_id_seq" INCREMENT BY 1 MINVALUE 1 MAXVALUE 9223372036854775807 START WITH 1 CACHE 1),
+	"match_id" bigint NOT NULL,
+	"event_key" varchar(100) NOT NULL,
+	"sequence" integer NOT NULL,
+	"type" varchar(30) NOT NULL,
+	"team_side" varchar(10),
+	"player" varchar(255),
+	"player_id" bigint,
+	"minute" integer,
+	"extra_minute" integer,
+	"result" varchar(10),
+	"created_at" timestamp DEFAULT now(),
+	"updated_at" timestamp DEFAULT now(),
+	CONSTRAINT "soccer_match_events_match_id_event_key_unique" UNIQUE("match_id","event_key")
+);
+--> statement-breakpoint
+ALTER TABLE "soccer_match_events" ADD CONSTRAINT "soccer_match_events_match_id_soccer_matches_match_id_fk" FOREIGN KEY ("match_id") REFERENCES "public"."soccer_matches"("match_id") ON DELETE cascade ON UPDATE no action;
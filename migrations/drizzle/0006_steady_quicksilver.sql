@@ -0,0 +1,31 @@
+ALTER TABLE "soccer_matches" ADD COLUMN "status" varchar(20) DEFAULT 'scheduled' NOT NULL;
+--> statement-breakpoint
+ALTER TABLE "basketball_matches" ADD COLUMN "status" varchar(20) DEFAULT 'scheduled' NOT NULL;
+--> statement-breakpoint
+CREATE INDEX "soccer_matches_status_idx" ON "soccer_matches" USING btree ("status");
+--> statement-breakpoint
+CREATE INDEX "basketball_matches_status_idx" ON "basketball_matches" USING btree ("status");
+--> statement-breakpoint
+-- Classify rows synced before the status column existed; sync keeps it current from here on
+UPDATE "soccer_matches" SET "status" = CASE
+	WHEN "match_status" ~ '^[0-9]+(\+[0-9]*)?$' THEN 'live'
+	WHEN lower(rtrim("match_status", '.')) IN ('1h', '2h', 'et', 'p', 'live', 'in play') THEN 'live'
+	WHEN lower(rtrim("match_status", '.')) IN ('ht', 'break time', 'bt') THEN 'break'
+	WHEN lower(rtrim("match_status", '.')) IN ('ft', 'aet', 'pen', 'ap', 'awarded', 'awd', 'wo') THEN 'finished'
+	WHEN lower(rtrim("match_status", '.')) IN ('postp', 'postponed') THEN 'postponed'
+	WHEN lower(rtrim("match_status", '.')) IN ('canc', 'cancl', 'cancelled') THEN 'cancelled'
+	WHEN lower(rtrim("match_status", '.')) IN ('aban', 'abandoned') THEN 'abandoned'
+	WHEN lower(rtrim("match_status", '.')) IN ('int', 'interrupted', 'susp', 'suspended') THEN 'interrupted'
+	ELSE 'scheduled'
+END;
+--> statement-breakpoint
+UPDATE "basketball_matches" SET "status" = CASE
+	WHEN lower(rtrim("match_status", '.')) IN ('1st quarter', '2nd quarter', '3rd quarter', '4th quarter', 'q1', 'q2', 'q3', 'q4', '1st half', '2nd half', 'overtime', 'ot', 'live', 'in play') THEN 'live'
+	WHEN lower(rtrim("match_status", '.')) IN ('break time', 'halftime', 'half time', 'ht') THEN 'break'
+	WHEN lower(rtrim("match_status", '.')) IN ('finished', 'final', 'ft', 'after over time', 'aot', 'awarded') THEN 'finished'
+	WHEN lower(rtrim("match_status", '.')) IN ('postponed', 'postp') THEN 'postponed'
+	WHEN lower(rtrim("match_status", '.')) IN ('cancelled', 'canc') THEN 'cancelled'
+	WHEN lower(rtrim("match_status", '.')) IN ('abandoned', 'aban') THEN 'abandoned'
+	WHEN lower(rtrim("match_status", '.')) IN ('interrupted', 'int', 'suspended', 'susp') THEN 'interrupted'
+	ELSE 'scheduled'
+END;
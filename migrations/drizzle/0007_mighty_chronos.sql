@@ -0,0 +1,14 @@
+ALTER TABLE "soccer_matches" ADD COLUMN "kickoff_at" timestamp with time zone;
+--> statement-breakpoint
+ALTER TABLE "basketball_matches" ADD COLUMN "kickoff_at" timestamp with time zone;
+--> statement-breakpoint
+CREATE INDEX "soccer_matches_kickoff_at_idx" ON "soccer_matches" USING btree ("kickoff_at");
+--> statement-breakpoint
+CREATE INDEX "basketball_matches_kickoff_at_idx" ON "basketball_matches" USING btree ("kickoff_at");
+--> statement-breakpoint
+-- Existing rows were synced with GOALSERVE_TIMEZONE unset, so their date and time are UTC
+UPDATE "soccer_matches" SET "kickoff_at" = ("match_start_date" + "match_start_time") AT TIME ZONE 'UTC'
+WHERE "match_start_date" IS NOT NULL AND "match_start_time" IS NOT NULL;
+--> statement-breakpoint
+UPDATE "basketball_matches" SET "kickoff_at" = ("match_date" + "match_time") AT TIME ZONE 'UTC'
+WHERE "match_date" IS NOT NULL AND "match_time" IS NOT NULL;
@@ -0,0 +1,9 @@
+CREATE TABLE "sync_leader" (
+	"name" varchar(50) PRIMARY KEY,
+	"instance_id" uuid NOT NULL,
+	"hostname" varchar(255),
+	"pid" integer,
+	"backend_pid" integer NOT NULL,
+	"acquired_at" timestamp DEFAULT now() NOT NULL,
+	"heartbeat_at" timestamp DEFAULT now() NOT NULL
+);
@@ -0,0 +1,21 @@
+CREATE TABLE "sync_runs" (
+	"id" uuid PRIMARY KEY,
+	"sport" varchar(20) NOT NULL,
+	"feed" varchar(20) NOT NULL,
+	"status" varchar(20) DEFAULT 'running' NOT NULL,
+	"started_at" timestamp with time zone DEFAULT now() NOT NULL,
+	"finished_at" timestamp with time zone,
+	"http_status" integer,
+	"inserted" integer DEFAULT 0 NOT NULL,
+	"updated" integer DEFAULT 0 NOT NULL,
+	"unchanged" integer DEFAULT 0 NOT NULL,
+	"failed" integer DEFAULT 0 NOT NULL,
+	"failures" jsonb DEFAULT '[]'::jsonb NOT NULL,
+	"error" text
+);
+--> statement-breakpoint
+ALTER TABLE "api_keys" ADD COLUMN "is_admin" boolean DEFAULT false NOT NULL;
+--> statement-breakpoint
+CREATE INDEX "sync_runs_sport_feed_started_at_idx" ON "sync_runs" USING btree ("sport","feed","started_at");
+--> statement-breakpoint
+CREATE INDEX "sync_runs_started_at_idx" ON "sync_runs" USING btree ("started_at");
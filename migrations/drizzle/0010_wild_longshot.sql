@@ -0,0 +1,15 @@
+CREATE TABLE "sync_dead_letters" (
+	"id" bigint PRIMARY KEY GENERATED ALWAYS AS IDENTITY (sequence name "sync_dead_letters_id_seq" INCREMENT BY 1 MINVALUE 1 MAXVALUE 9223372036854775807 START WITH 1 CACHE 1),
+	"sport" varchar(20) NOT NULL,
+	"match_key" varchar(100) NOT NULL,
+	"payload" jsonb NOT NULL,
+	"error" text NOT NULL,
+	"attempts" integer DEFAULT 1 NOT NULL,
+	"sync_run_id" uuid NOT NULL,
+	"first_failed_at" timestamp with time zone DEFAULT now() NOT NULL,
+	"last_failed_at" timestamp with time zone DEFAULT now() NOT NULL,
+	"resolved_at" timestamp with time zone,
+	CONSTRAINT "sync_dead_letters_sport_match_key_unique" UNIQUE("sport","match_key")
+);
+--> statement-breakpoint
+CREATE INDEX "sync_dead_letters_sport_resolved_at_idx" ON "sync_dead_letters" USING btree ("sport","resolved_at");
@@ -0,0 +1,13 @@
+CREATE TABLE "raw_feeds" (
+	"id" bigint PRIMARY KEY GENERATED ALWAYS AS IDENTITY (sequence name "raw_feeds_id_seq" INCREMENT BY 1 MINVALUE 1 MAXVALUE 9223372036854775807 START WITH 1 CACHE 1),
+	"sport" varchar(20) NOT NULL,
+	"feed" varchar(150) NOT NULL,
+	"fetched_at" timestamp with time zone NOT NULL,
+	"sha256" varchar(64) NOT NULL,
+	"size" integer NOT NULL,
+	"body" bytea NOT NULL
+);
+--> statement-breakpoint
+CREATE INDEX "raw_feeds_sport_feed_fetched_at_idx" ON "raw_feeds" USING btree ("sport","feed","fetched_at");
+--> statement-breakpoint
+CREATE INDEX "raw_feeds_fetched_at_idx" ON "raw_feeds" USING btree ("fetched_at");
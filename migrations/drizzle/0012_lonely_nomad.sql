@@ -0,0 +1,11 @@
+ALTER TABLE "soccer_matches" ADD COLUMN "previous_kickoff_at" timestamp with time zone;
+--> statement-breakpoint
+ALTER TABLE "soccer_matches" ADD COLUMN "missed_syncs" integer DEFAULT 0 NOT NULL;
+--> statement-breakpoint
+ALTER TABLE "basketball_matches" ADD COLUMN "previous_kickoff_at" timestamp with time zone;
+--> statement-breakpoint
+ALTER TABLE "basketball_matches" ADD COLUMN "missed_syncs" integer DEFAULT 0 NOT NULL;
+--> statement-breakpoint
+CREATE INDEX "soccer_matches_match_start_date_status_idx" ON "soccer_matches" USING btree ("match_start_date","status");
+--> statement-breakpoint
+CREATE INDEX "basketball_matches_match_date_status_idx" ON "basketball_matches" USING btree ("match_date","status");
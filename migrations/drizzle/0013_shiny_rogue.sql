@@ -0,0 +1,152 @@
+CREATE TABLE "leagues" (
+	"id" bigint PRIMARY KEY GENERATED ALWAYS AS IDENTITY (sequence name "leagues_id_seq" INCREMENT BY 1 MINVALUE 1 MAXVALUE 9223372036854775807 START WITH 1 CACHE 1),
+	"sport" varchar(20) NOT NULL,
+	"league_id" bigint NOT NULL,
+	"gid" bigint,
+	"name" varchar(255) NOT NULL,
+	"country" varchar(100),
+	"created_at" timestamp with time zone DEFAULT now() NOT NULL,
+	"updated_at" timestamp with time zone DEFAULT now() NOT NULL,
+	CONSTRAINT "leagues_sport_league_id_unique" UNIQUE("sport","league_id")
+);
+--> statement-breakpoint
+CREATE TABLE "teams" (
+	"id" bigint PRIMARY KEY GENERATED ALWAYS AS IDENTITY (sequence name "teams_id_seq" INCREMENT BY 1 MINVALUE 1 MAXVALUE 9223372036854775807 START WITH 1 CACHE 1),
+	"sport" varchar(20) NOT NULL,
+	"team_id" bigint NOT NULL,
+	"name" varchar(255) NOT NULL,
+	"created_at" timestamp with time zone DEFAULT now() NOT NULL,
+	"updated_at" timestamp with time zone DEFAULT now() NOT NULL,
+	CONSTRAINT "teams_sport_team_id_unique" UNIQUE("sport","team_id")
+);
+--> statement-breakpoint
+CREATE TABLE "seasons" (
+	"id" bigint PRIMARY KEY GENERATED ALWAYS AS IDENTITY (sequence name "seasons_id_seq" INCREMENT BY 1 MINVALUE 1 MAXVALUE 9223372036854775807 START WITH 1 CACHE 1),
+	"league_ref" bigint NOT NULL,
+	"name" varchar(20) NOT NULL,
+	"starts_on" date NOT NULL,
+	"ends_on" date NOT NULL,
+	"created_at" timestamp with time zone DEFAULT now() NOT NULL,
+	"updated_at" timestamp with time zone DEFAULT now() NOT NULL,
+	CONSTRAINT "seasons_league_ref_name_unique" UNIQUE("league_ref","name")
+);
+--> statement-breakpoint
+ALTER TABLE "soccer_matches" ADD COLUMN "league_ref" bigint;
+--> statement-breakpoint
+ALTER TABLE "soccer_matches" ADD COLUMN "season_ref" bigint;
+--> statement-breakpoint
+ALTER TABLE "soccer_matches" ADD COLUMN "h_team_ref" bigint;
+--> statement-breakpoint
+ALTER TABLE "soccer_matches" ADD COLUMN "a_team_ref" bigint;
+--> statement-breakpoint
+ALTER TABLE "basketball_matches" ADD COLUMN "league_ref" bigint;
+--> statement-breakpoint
+ALTER TABLE "basketball_matches" ADD COLUMN "season_ref" bigint;
+--> statement-breakpoint
+ALTER TABLE "basketball_matches" ADD COLUMN "h_team_ref" bigint;
+--> statement-breakpoint
+ALTER TABLE "basketball_matches" ADD COLUMN "a_team_ref" bigint;
+--> statement-breakpoint
+ALTER TABLE "seasons" ADD CONSTRAINT "seasons_league_ref_leagues_id_fk" FOREIGN KEY ("league_ref") REFERENCES "public"."leagues"("id") ON DELETE cascade ON UPDATE no action;
+--> statement-breakpoint
+ALTER TABLE "soccer_matches" ADD CONSTRAINT "soccer_matches_league_ref_leagues_id_fk" FOREIGN KEY ("league_ref") REFERENCES "public"."leagues"("id") ON DELETE set null ON UPDATE no action;
+--> statement-breakpoint
+ALTER TABLE "soccer_matches" ADD CONSTRAINT "soccer_matches_season_ref_seasons_id_fk" FOREIGN KEY ("season_ref") REFERENCES "public"."seasons"("id") ON DELETE set null ON UPDATE no action;
+--> statement-breakpoint
+ALTER TABLE "soccer_matches" ADD CONSTRAINT "soccer_matches_h_team_ref_teams_id_fk" FOREIGN KEY ("h_team_ref") REFERENCES "public"."teams"("id") ON DELETE set null ON UPDATE no action;
+--> statement-breakpoint
+ALTER TABLE "soccer_matches" ADD CONSTRAINT "soccer_matches_a_team_ref_teams_id_fk" FOREIGN KEY ("a_team_ref") REFERENCES "public"."teams"("id") ON DELETE set null ON UPDATE no action;
+--> statement-breakpoint
+ALTER TABLE "basketball_matches" ADD CONSTRAINT "basketball_matches_league_ref_leagues_id_fk" FOREIGN KEY ("league_ref") REFERENCES "public"."leagues"("id") ON DELETE set null ON UPDATE no action;
+--> statement-breakpoint
+ALTER TABLE "basketball_matches" ADD CONSTRAINT "basketball_matches_season_ref_seasons_id_fk" FOREIGN KEY ("season_ref") REFERENCES "public"."seasons"("id") ON DELETE set null ON UPDATE no action;
+--> statement-breakpoint
+ALTER TABLE "basketball_matches" ADD CONSTRAINT "basketball_matches_h_team_ref_teams_id_fk" FOREIGN KEY ("h_team_ref") REFERENCES "public"."teams"("id") ON DELETE set null ON UPDATE no action;
+--> statement-breakpoint
+ALTER TABLE "basketball_matches" ADD CONSTRAINT "basketball_matches_a_team_ref_teams_id_fk" FOREIGN KEY ("a_team_ref") REFERENCES "public"."teams"("id") ON DELETE set null ON UPDATE no action;
+--> statement-breakpoint
+CREATE INDEX "teams_sport_name_idx" ON "teams" USING btree ("sport","name");
+--> statement-breakpoint
+CREATE INDEX "soccer_matches_league_ref_idx" ON "soccer_matches" USING btree ("league_ref");
+--> statement-breakpoint
+CREATE INDEX "soccer_matches_season_ref_idx" ON "soccer_matches" USING btree ("season_ref");
+--> statement-breakpoint
+CREATE INDEX "soccer_matches_h_team_ref_idx" ON "soccer_matches" USING btree ("h_team_ref");
+--> statement-breakpoint
+CREATE INDEX "soccer_matches_a_team_ref_idx" ON "soccer_matches" USING btree ("a_team_ref");
+--> statement-breakpoint
+CREATE INDEX "basketball_matches_league_ref_idx" ON "basketball_matches" USING btree ("league_ref");
+--> statement-breakpoint
+CREATE INDEX "basketball_matches_season_ref_idx" ON "basketball_matches" USING btree ("season_ref");
+--> statement-breakpoint
+CREATE INDEX "basketball_matches_h_team_ref_idx" ON "basketball_matches" USING btree ("h_team_ref");
+--> statement-breakpoint
+CREATE INDEX "basketball_matches_a_team_ref_idx" ON "basketball_matches" USING btree ("a_team_ref");
+--> statement-breakpoint
+INSERT INTO "leagues" ("sport", "league_id", "gid", "name", "country")
+SELECT DISTINCT ON ("league_id") 'soccer', "league_id", "league_gid", "league_name", NULLIF(split_part("league_name", ': ', 1), "league_name")
+FROM "soccer_matches"
+WHERE "league_id" IS NOT NULL AND "league_id" <> 0 AND "league_name" IS NOT NULL
+ORDER BY "league_id", "updated_at" DESC
+ON CONFLICT DO NOTHING;
+--> statement-breakpoint
+INSERT INTO "teams" ("sport", "team_id", "name")
+SELECT DISTINCT ON ("team_id") 'soccer', "team_id", "name"
+FROM (
+	SELECT "h_team_id" AS "team_id", "h_team_name" AS "name", "updated_at" FROM "soccer_matches"
+	UNION ALL
+	SELECT "a_team_id", "a_team_name", "updated_at" FROM "soccer_matches"
+) AS "sides"
+WHERE "team_id" IS NOT NULL AND "team_id" <> 0 AND "name" IS NOT NULL
+ORDER BY "team_id", "updated_at" DESC
+ON CONFLICT DO NOTHING;
+--> statement-breakpoint
+INSERT INTO "seasons" ("league_ref", "name", "starts_on", "ends_on")
+SELECT "l"."id", CASE WHEN EXTRACT(MONTH FROM "m"."match_start_date") >= 7 THEN EXTRACT(YEAR FROM "m"."match_start_date")::int || '/' || (EXTRACT(YEAR FROM "m"."match_start_date")::int + 1) ELSE (EXTRACT(YEAR FROM "m"."match_start_date")::int - 1) || '/' || EXTRACT(YEAR FROM "m"."match_start_date")::int END, MIN("m"."match_start_date"), MAX("m"."match_start_date")
+FROM "soccer_matches" AS "m"
+JOIN "leagues" AS "l" ON "l"."sport" = 'soccer' AND "l"."league_id" = "m"."league_id"
+WHERE "m"."match_start_date" IS NOT NULL
+GROUP BY 1, 2
+ON CONFLICT DO NOTHING;
+--> statement-breakpoint
+UPDATE "soccer_matches" AS "m" SET
+	"league_ref" = "l"."id",
+	"season_ref" = (SELECT "s"."id" FROM "seasons" AS "s" WHERE "s"."league_ref" = "l"."id" AND "s"."name" = CASE WHEN EXTRACT(MONTH FROM "m"."match_start_date") >= 7 THEN EXTRACT(YEAR FROM "m"."match_start_date")::int || '/' || (EXTRACT(YEAR FROM "m"."match_start_date")::int + 1) ELSE (EXTRACT(YEAR FROM "m"."match_start_date")::int - 1) || '/' || EXTRACT(YEAR FROM "m"."match_start_date")::int END),
+	"h_team_ref" = (SELECT "id" FROM "teams" WHERE "sport" = 'soccer' AND "team_id" = "m"."h_team_id"),
+	"a_team_ref" = (SELECT "id" FROM "teams" WHERE "sport" = 'soccer' AND "team_id" = "m"."a_team_id")
+FROM "leagues" AS "l"
+WHERE "l"."sport" = 'soccer' AND "l"."league_id" = "m"."league_id";
+--> statement-breakpoint
+INSERT INTO "leagues" ("sport", "league_id", "gid", "name", "country")
+SELECT DISTINCT ON ("league_id") 'basketball', "league_id", "league_gid", "league_name", NULLIF(split_part("league_name", ': ', 1), "league_name")
+FROM "basketball_matches"
+WHERE "league_id" IS NOT NULL AND "league_id" <> 0 AND "league_name" IS NOT NULL
+ORDER BY "league_id", "updated_at" DESC
+ON CONFLICT DO NOTHING;
+--> statement-breakpoint
+INSERT INTO "teams" ("sport", "team_id", "name")
+SELECT DISTINCT ON ("team_id") 'basketball', "team_id", "name"
+FROM (
+	SELECT "h_team_id" AS "team_id", "h_team_name" AS "name", "updated_at" FROM "basketball_matches"
+	UNION ALL
+	SELECT "a_team_id", "a_team_name", "updated_at" FROM "basketball_matches"
+) AS "sides"
+WHERE "team_id" IS NOT NULL AND "team_id" <> 0 AND "name" IS NOT NULL
+ORDER BY "team_id", "updated_at" DESC
+ON CONFLICT DO NOTHING;
+--> statement-breakpoint
+INSERT INTO "seasons" ("league_ref", "name", "starts_on", "ends_on")
+SELECT "l"."id", CASE WHEN EXTRACT(MONTH FROM "m"."match_date") >= 7 THEN EXTRACT(YEAR FROM "m"."match_date")::int || '/' || (EXTRACT(YEAR FROM "m"."match_date")::int + 1) ELSE (EXTRACT(YEAR FROM "m"."match_date")::int - 1) || '/' || EXTRACT(YEAR FROM "m"."match_date")::int END, MIN("m"."match_date"), MAX("m"."match_date")
+FROM "basketball_matches" AS "m"
+JOIN "leagues" AS "l" ON "l"."sport" = 'basketball' AND "l"."league_id" = "m"."league_id"
+WHERE "m"."match_date" IS NOT NULL
+GROUP BY 1, 2
+ON CONFLICT DO NOTHING;
+--> statement-breakpoint
+UPDATE "basketball_matches" AS "m" SET
+	"league_ref" = "l"."id",
+	"season_ref" = (SELECT "s"."id" FROM "seasons" AS "s" WHERE "s"."league_ref" = "l"."id" AND "s"."name" = CASE WHEN EXTRACT(MONTH FROM "m"."match_date") >= 7 THEN EXTRACT(YEAR FROM "m"."match_date")::int || '/' || (EXTRACT(YEAR FROM "m"."match_date")::int + 1) ELSE (EXTRACT(YEAR FROM "m"."match_date")::int - 1) || '/' || EXTRACT(YEAR FROM "m"."match_date")::int END),
+	"h_team_ref" = (SELECT "id" FROM "teams" WHERE "sport" = 'basketball' AND "team_id" = "m"."h_team_id"),
+	"a_team_ref" = (SELECT "id" FROM "teams" WHERE "sport" = 'basketball' AND "team_id" = "m"."a_team_id")
+FROM "leagues" AS "l"
+WHERE "l"."sport" = 'basketball' AND "l"."league_id" = "m"."league_id";
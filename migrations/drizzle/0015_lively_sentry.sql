@@ -0,0 +1,22 @@
+CREATE TABLE "team_ratings" (
+	"id" bigint PRIMARY KEY GENERATED ALWAYS AS IDENTITY (sequence name "team_ratings_id_seq" INCREMENT BY 1 MINVALUE 1 MAXVALUE 9223372036854775807 START WITH 1 CACHE 1),
+	"sport" varchar(20) NOT NULL,
+	"team_ref" bigint NOT NULL,
+	"opponent_ref" bigint NOT NULL,
+	"match_id" bigint NOT NULL,
+	"kickoff_at" timestamp with time zone NOT NULL,
+	"season" varchar(20),
+	"home" boolean NOT NULL,
+	"rating_before" double precision NOT NULL,
+	"rating" double precision NOT NULL,
+	"created_at" timestamp with time zone DEFAULT now() NOT NULL,
+	CONSTRAINT "team_ratings_sport_team_ref_match_id_unique" UNIQUE("sport","team_ref","match_id")
+);
+--> statement-breakpoint
+ALTER TABLE "team_ratings" ADD CONSTRAINT "team_ratings_team_ref_teams_id_fk" FOREIGN KEY ("team_ref") REFERENCES "public"."teams"("id") ON DELETE cascade ON UPDATE no action;
+--> statement-breakpoint
+ALTER TABLE "team_ratings" ADD CONSTRAINT "team_ratings_opponent_ref_teams_id_fk" FOREIGN KEY ("opponent_ref") REFERENCES "public"."teams"("id") ON DELETE cascade ON UPDATE no action;
+--> statement-breakpoint
+CREATE INDEX "team_ratings_sport_kickoff_at_idx" ON "team_ratings" USING btree ("sport","kickoff_at");
+--> statement-breakpoint
+CREATE INDEX "team_ratings_team_ref_kickoff_at_idx" ON "team_ratings" USING btree ("team_ref","kickoff_at");
@@ -0,0 +1,10 @@
+CREATE TABLE "h2h_requests" (
+	"id" bigint PRIMARY KEY GENERATED ALWAYS AS IDENTITY (sequence name "h2h_requests_id_seq" INCREMENT BY 1 MINVALUE 1 MAXVALUE 9223372036854775807 START WITH 1 CACHE 1),
+	"sport" varchar(20) NOT NULL,
+	"team_a" bigint NOT NULL,
+	"team_b" bigint NOT NULL,
+	"requested_at" timestamp with time zone DEFAULT now() NOT NULL,
+	CONSTRAINT "h2h_requests_sport_team_a_team_b_unique" UNIQUE("sport","team_a","team_b")
+);
+--> statement-breakpoint
+CREATE INDEX "h2h_requests_requested_at_idx" ON "h2h_requests" USING btree ("requested_at");
@@ -1,68 +1,143 @@
 import {
 	bigint,
 	boolean,
+	customType,
 	date,
+	doublePrecision,
+	index,
 	integer,
 	json,
+	jsonb,
 	pgTable,
 	text,
 	time,
 	timestamp,
+	unique,
+	uuid,
 	varchar,
 } from "drizzle-orm/pg-core";
 
-export const soccerMatches = pgTable("soccer_matches", {
-	id: bigint("id", { mode: "number" }).primaryKey().generatedAlwaysAsIdentity(),
-	matchId: bigint("match_id", { mode: "number" }).unique(),
-	leagueGid: bigint("league_gid", { mode: "number" }),
-	leagueId: bigint("league_id", { mode: "number" }),
-	leagueName: varchar("league_name", { length: 255 }),
-	matchStatus: varchar("match_status", { length: 50 }),
-	matchStartDate: date("match_start_date"),
-	matchStartTime: time("match_start_time"),
-	hTeamId: bigint("h_team_id", { mode: "number" }),
-	aTeamId: bigint("a_team_id", { mode: "number" }),
-	hTeamName: varchar("h_team_name", { length: 255 }),
-	aTeamName: varchar("a_team_name", { length: 255 }),
-	hTeamGoals: integer("h_team_goals"),
-	aTeamGoals: integer("a_team_goals"),
-	htScore: varchar("ht_score", { length: 10 }),
-	ftScore: varchar("ft_score", { length: 10 }),
-	events: json("events"),
-	createdAt: timestamp("created_at").defaultNow(),
-	updatedAt: timestamp("updated_at").defaultNow(),
+const bytea = customType<{ data: Buffer }>({
+	dataType() {
+		return "bytea";
+	},
 });
 
-export const basketballMatches = pgTable("basketball_matches", {
-	id: bigint("id", { mode: "number" }).primaryKey().generatedAlwaysAsIdentity(),
-	matchId: bigint("match_id", { mode: "number" }).unique(),
-	leagueGid: bigint("league_gid", { mode: "number" }),
-	leagueId: bigint("league_id", { mode: "number" }),
-	leagueName: varchar("league_name", { length: 255 }),
-	fileGroup: varchar("file_group", { length: 100 }),
-	matchStatus: varchar("match_status", { length: 50 }),
-	matchDate: date("match_date"),
-	matchTime: time("match_time"),
-	timer: varchar("timer", { length: 20 }),
-	hTeamId: bigint("h_team_id", { mode: "number" }),
-	hTeamName: varchar("h_team_name", { length: 255 }),
-	hTeamScore: integer("h_team_score"),
-	hTeamQ1: integer("h_team_q1"),
-	hTeamQ2: integer("h_team_q2"),
-	hTeamQ3: integer("h_team_q3"),
-	hTeamQ4: integer("h_team_q4"),
-	hTeamOt: integer("h_team_ot"),
-	aTeamId: bigint("a_team_id", { mode: "number" }),
-	aTeamName: varchar("a_team_name", { length: 255 }),
-	aTeamScore: integer("a_team_score"),
-	aTeamQ1: integer("a_team_q1"),
-	aTeamQ2: integer("a_team_q2"),
-	aTeamQ3: integer("a_team_q3"),
-	aTeamQ4: integer("a_team_q4"),
-	aTeamOt: integer("a_team_ot"),
-	createdAt: timestamp("created_at").defaultNow(),
-	updatedAt: timestamp("updated_at").defaultNow(),
-});
+export const soccerMatches = pgTable(
+	"soccer_matches",
+	{
+		id: bigint("id", { mode: "number" }).primaryKey().generatedAlwaysAsIdentity(),
+		matchId: bigint("match_id", { mode: "number" }).unique(),
+		leagueGid: bigint("league_gid", { mode: "number" }),
+		leagueId: bigint("league_id", { mode: "number" }),
+		leagueName: varchar("league_name", { length: 255 }),
+		matchStatus: varchar("match_status", { length: 50 }), // Raw Goalserve status
+		status: varchar("status", { length: 20 }).notNull().default("scheduled"), // Canonical lifecycle status
+		matchStartDate: date("match_start_date"),
+		matchStartTime: time("match_start_time"),
+		kickoffAt: timestamp("kickoff_at", { withTimezone: true }), // Start instant; date and time above are in the feed's timezone
+		previousKickoffAt: timestamp("previous_kickoff_at", { withTimezone: true }), // Kickoff before the latest move, null if never moved
+		hTeamId: bigint("h_team_id", { mode: "number" }),
+		aTeamId: bigint("a_team_id", { mode: "number" }),
+		hTeamName: varchar("h_team_name", { length: 255 }),
+		aTeamName: varchar("a_team_name", { length: 255 }),
+		hTeamGoals: integer("h_team_goals"),
+		aTeamGoals: integer("a_team_goals"),
+		htScore: varchar("ht_score", { length: 10 }),
+		ftScore: varchar("ft_score", { length: 10 }),
+		events: json("events"),
+		leagueRef: bigint("league_ref", { mode: "number" }).references(() => leagues.id, { onDelete: "set null" }),
+		seasonRef: bigint("season_ref", { mode: "number" }).references(() => seasons.id, { onDelete: "set null" }),
+		hTeamRef: bigint("h_team_ref", { mode: "number" }).references(() => teams.id, { onDelete: "set null" }),
+		aTeamRef: bigint("a_team_ref", { mode: "number" }).references(() => teams.id, { onDelete: "set null" }),
+		missedSyncs: integer("missed_syncs").notNull().default(0), // Consecutive syncs of its day the match was absent from
+		createdAt: timestamp("created_at").defaultNow(),
+		updatedAt: timestamp("updated_at").defaultNow(),
+	},
+	(t) => [
+		index("soccer_matches_status_idx").on(t.status),
+		index("soccer_matches_kickoff_at_idx").on(t.kickoffAt),
+		index("soccer_matches_league_ref_idx").on(t.leagueRef),
+		index("soccer_matches_season_ref_idx").on(t.seasonRef),
+		index("soccer_matches_h_team_ref_idx").on(t.hTeamRef),
+		index("soccer_matches_a_team_ref_idx").on(t.aTeamRef),
+		index("soccer_matches_match_start_date_status_idx").on(t.matchStartDate, t.status),
+	],
+);
+
+// Goals, cards and substitutions, one row per event, deduped across syncs by event_key
+export const soccerMatchEvents = pgTable(
+	"soccer_match_events",
+	{
+		id: bigint("id", { mode: "number" }).primaryKey().generatedAlwaysAsIdentity(),
+		matchId: bigint("match_id", { mode: "number" })
+			.notNull()
+			.references(() => soccerMatches.matchId, { onDelete: "cascade" }),
+		eventKey: varchar("event_key", { length: 100 }).notNull(), // Goalserve event id, or type/side/player/minute
+		sequence: integer("sequence").notNull(), // Order within the match
+		type: varchar("type", { length: 30 }).notNull(),
+		teamSide: varchar("team_side", { length: 10 }), // "home" or "away"
+		player: varchar("player", { length: 255 }),
+		playerId: bigint("player_id", { mode: "number" }),
+		minute: integer("minute"),
+		extraMinute: integer("extra_minute"),
+		result: varchar("result", { length: 10 }),
+		createdAt: timestamp("created_at").defaultNow(),
+		updatedAt: timestamp("updated_at").defaultNow(),
+	},
+	(t) => [unique("soccer_match_events_match_id_event_key_unique").on(t.matchId, t.eventKey)],
+);
+
+export const basketballMatches = pgTable(
+	"basketball_matches",
+	{
+		id: bigint("id", { mode: "number" }).primaryKey().generatedAlwaysAsIdentity(),
+		matchId: bigint("match_id", { mode: "number" }).unique(),
+		leagueGid: bigint("league_gid", { mode: "number" }),
+		leagueId: bigint("league_id", { mode: "number" }),
+		leagueName: varchar("league_name", { length: 255 }),
+		fileGroup: varchar("file_group", { length: 100 }),
+		matchStatus: varchar("match_status", { length: 50 }), // Raw Goalserve status
+		status: varchar("status", { length: 20 }).notNull().default("scheduled"), // Canonical lifecycle status
+		matchDate: date("match_date"),
+		matchTime: time("match_time"),
+		kickoffAt: timestamp("kickoff_at", { withTimezone: true }), // Start instant; date and time above are in the feed's timezone
+		previousKickoffAt: timestamp("previous_kickoff_at", { withTimezone: true }), // Kickoff before the latest move, null if never moved
+		timer: varchar("timer", { length: 20 }),
+		hTeamId: bigint("h_team_id", { mode: "number" }),
+		hTeamName: varchar("h_team_name", { length: 255 }),
+		hTeamScore: integer("h_team_score"),
+		hTeamQ1: integer("h_team_q1"),
+		hTeamQ2: integer("h_team_q2"),
+		hTeamQ3: integer("h_team_q3"),
+		hTeamQ4: integer("h_team_q4"),
+		hTeamOt: integer("h_team_ot"),
+		aTeamId: bigint("a_team_id", { mode: "number" }),
+		aTeamName: varchar("a_team_name", { length: 255 }),
+		aTeamScore: integer("a_team_score"),
+		aTeamQ1: integer("a_team_q1"),
+		aTeamQ2: integer("a_team_q2"),
+		aTeamQ3: integer("a_team_q3"),
+		aTeamQ4: integer("a_team_q4"),
+		aTeamOt: integer("a_team_ot"),
+		leagueRef: bigint("league_ref", { mode: "number" }).references(() => leagues.id, { onDelete: "set null" }),
+		seasonRef: bigint("season_ref", { mode: "number" }).references(() => seasons.id, { onDelete: "set null" }),
+		hTeamRef: bigint("h_team_ref", { mode: "number" }).references(() => teams.id, { onDelete: "set null" }),
+		aTeamRef: bigint("a_team_ref", { mode: "number" }).references(() => teams.id, { onDelete: "set null" }),
+		missedSyncs: integer("missed_syncs").notNull().default(0), // Consecutive syncs of its day the match was absent from
+		createdAt: timestamp("created_at").defaultNow(),
+		updatedAt: timestamp("updated_at").defaultNow(),
+	},
+	(t) => [
+		index("basketball_matches_status_idx").on(t.status),
+		index("basketball_matches_kickoff_at_idx").on(t.kickoffAt),
+		index("basketball_matches_league_ref_idx").on(t.leagueRef),
+		index("basketball_matches_season_ref_idx").on(t.seasonRef),
+		index("basketball_matches_h_team_ref_idx").on(t.hTeamRef),
+		index("basketball_matches_a_team_ref_idx").on(t.aTeamRef),
+		index("basketball_matches_match_date_status_idx").on(t.matchDate, t.status),
+	],
+);
 
 // API Keys for authentication
 export const apiKeys = pgTable("api_keys", {
@@ -73,7 +148,223 @@ export const apiKeys = pgTable("api_keys", {
 	sports: json("sports").notNull(), // Array of allowed sports: ["soccer", "basketball"] or ["*"]
 	rateLimit: integer("rate_limit").notNull().default(100), // Requests per minute
 	isActive: boolean("is_active").notNull().default(true),
+	isAdmin: boolean("is_admin").notNull().default(false), // Grants the /api/v1/admin endpoints
 	createdAt: timestamp("created_at").defaultNow(),
 	lastUsedAt: timestamp("last_used_at"),
 	expiresAt: timestamp("expires_at"),
 });
+
+// Days completed by the backfill command, so an interrupted run can resume
+export const backfillProgress = pgTable(
+	"backfill_progress",
+	{
+		id: bigint("id", { mode: "number" }).primaryKey().generatedAlwaysAsIdentity(),
+		sport: varchar("sport", { length: 20 }).notNull(),
+		day: date("day").notNull(),
+		inserted: integer("inserted").notNull().default(0),
+		updated: integer("updated").notNull().default(0),
+		unchanged: integer("unchanged").notNull().default(0),
+		failed: integer("failed").notNull().default(0),
+		completedAt: timestamp("completed_at").defaultNow(),
+	},
+	(t) => [unique("backfill_progress_sport_day_unique").on(t.sport, t.day)],
+);
+
+// Field-level history of match data changes written by sync
+export const matchChanges = pgTable(
+	"match_changes",
+	{
+		id: bigint("id", { mode: "number" }).primaryKey().generatedAlwaysAsIdentity(),
+		sport: varchar("sport", { length: 20 }).notNull(),
+		matchId: bigint("match_id", { mode: "number" }).notNull(),
+		field: varchar("field", { length: 50 }).notNull(),
+		oldValue: text("old_value"),
+		newValue: text("new_value"),
+		changedAt: timestamp("changed_at").notNull().defaultNow(),
+		syncRunId: uuid("sync_run_id").notNull(),
+	},
+	(t) => [
+		index("match_changes_sport_match_id_idx").on(t.sport, t.matchId),
+		index("match_changes_sync_run_id_idx").on(t.syncRunId),
+	],
+);
+
+// Current holder of each advisory-lock leadership (one row per lock name), refreshed by its heartbeat
+export const syncLeader = pgTable("sync_leader", {
+	name: varchar("name", { length: 50 }).primaryKey(),
+	instanceId: uuid("instance_id").notNull(),
+	hostname: varchar("hostname", { length: 255 }),
+	pid: integer("pid"),
+	backendPid: integer("backend_pid").notNull(), // Postgres session holding the lock
+	acquiredAt: timestamp("acquired_at").notNull().defaultNow(),
+	heartbeatAt: timestamp("heartbeat_at").notNull().defaultNow(),
+});
+
+// One row per scheduled feed sync; id is the sync_run_id recorded in match_changes
+export const syncRuns = pgTable(
+	"sync_runs",
+	{
+		id: uuid("id").primaryKey(),
+		sport: varchar("sport", { length: 20 }).notNull(),
+		feed: varchar("feed", { length: 20 }).notNull(), // "today", "future" or "retry"
+		status: varchar("status", { length: 20 }).notNull().default("running"), // running, succeeded or failed
+		startedAt: timestamp("started_at", { withTimezone: true }).notNull().defaultNow(),
+		finishedAt: timestamp("finished_at", { withTimezone: true }),
+		httpStatus: integer("http_status"), // Goalserve response status, null when no response was received
+		inserted: integer("inserted").notNull().default(0),
+		updated: integer("updated").notNull().default(0),
+		unchanged: integer("unchanged").notNull().default(0),
+		failed: integer("failed").notNull().default(0),
+		failures: jsonb("failures").notNull().default([]), // [{ match_id, error }] for matches that failed to parse
+		error: text("error"),
+	},
+	(t) => [
+		index("sync_runs_sport_feed_started_at_idx").on(t.sport, t.feed, t.startedAt),
+		index("sync_runs_started_at_idx").on(t.startedAt),
+	],
+);
+
+// Matches a sync could not parse or store, kept for `sync retry-failed`
+export const syncDeadLetters = pgTable(
+	"sync_dead_letters",
+	{
+		id: bigint("id", { mode: "number" }).primaryKey().generatedAlwaysAsIdentity(),
+		sport: varchar("sport", { length: 20 }).notNull(),
+		matchKey: varchar("match_key", { length: 100 }).notNull(), // Raw feed match ID, or a payload hash when it has none
+		payload: jsonb("payload").notNull(), // Goalserve category holding just this match
+		error: text("error").notNull(), // Latest failure
+		attempts: integer("attempts").notNull().default(1),
+		syncRunId: uuid("sync_run_id").notNull(), // Latest run the match failed in
+		firstFailedAt: timestamp("first_failed_at", { withTimezone: true }).notNull().defaultNow(),
+		lastFailedAt: timestamp("last_failed_at", { withTimezone: true }).notNull().defaultNow(),
+		resolvedAt: timestamp("resolved_at", { withTimezone: true }), // Set once the match syncs successfully
+	},
+	(t) => [
+		unique("sync_dead_letters_sport_match_key_unique").on(t.sport, t.matchKey),
+		index("sync_dead_letters_sport_resolved_at_idx").on(t.sport, t.resolvedAt),
+	],
+);
+
+export const rawFeeds = pgTable(
+	"raw_feeds",
+	{
+		id: bigint("id", { mode: "number" }).primaryKey().generatedAlwaysAsIdentity(),
+		sport: varchar("sport", { length: 20 }).notNull(),
+		feed: varchar("feed", { length: 150 }).notNull(), // Feed path with its date query, e.g. soccernew/home
+		fetchedAt: timestamp("fetched_at", { withTimezone: true }).notNull(),
+		sha256: varchar("sha256", { length: 64 }).notNull(), // Of the uncompressed payload
+		size: integer("size").notNull(), // Uncompressed bytes
+		body: bytea("body").notNull(), // Gzipped payload as received
+	},
+	(t) => [
+		index("raw_feeds_sport_feed_fetched_at_idx").on(t.sport, t.feed, t.fetchedAt),
+		index("raw_feeds_fetched_at_idx").on(t.fetchedAt),
+	],
+);
+
+// Competitions seen by sync, one row per Goalserve category
+export const leagues = pgTable(
+	"leagues",
+	{
+		id: bigint("id", { mode: "number" }).primaryKey().generatedAlwaysAsIdentity(),
+		sport: varchar("sport", { length: 20 }).notNull(),
+		leagueId: bigint("league_id", { mode: "number" }).notNull(), // Goalserve category id, the league_id of matches
+		gid: bigint("gid", { mode: "number" }),
+		name: varchar("name", { length: 255 }).notNull(), // As Goalserve sends it, e.g. "England: Premier League"
+		country: varchar("country", { length: 100 }), // Part of the name before ": "
+		createdAt: timestamp("created_at", { withTimezone: true }).notNull().defaultNow(),
+		updatedAt: timestamp("updated_at", { withTimezone: true }).notNull().defaultNow(),
+	},
+	(t) => [unique("leagues_sport_league_id_unique").on(t.sport, t.leagueId)],
+);
+
+// Teams seen by sync, keyed by their Goalserve id
+export const teams = pgTable(
+	"teams",
+	{
+		id: bigint("id", { mode: "number" }).primaryKey().generatedAlwaysAsIdentity(),
+		sport: varchar("sport", { length: 20 }).notNull(),
+		teamId: bigint("team_id", { mode: "number" }).notNull(), // Goalserve team id, the h_team_id/a_team_id of matches
+		name: varchar("name", { length: 255 }).notNull(),
+		createdAt: timestamp("created_at", { withTimezone: true }).notNull().defaultNow(),
+		updatedAt: timestamp("updated_at", { withTimezone: true }).notNull().defaultNow(),
+	},
+	(t) => [
+		unique("teams_sport_team_id_unique").on(t.sport, t.teamId),
+		index("teams_sport_name_idx").on(t.sport, t.name),
+	],
+);
+
+// League seasons, starting in the league's configured month (default July), spanning the match days seen so far
+export const seasons = pgTable(
+	"seasons",
+	{
+		id: bigint("id", { mode: "number" }).primaryKey().generatedAlwaysAsIdentity(),
+		leagueRef: bigint("league_ref", { mode: "number" })
+			.notNull()
+			.references(() => leagues.id, { onDelete: "cascade" }),
+		name: varchar("name", { length: 20 }).notNull(), // e.g. "2026/2027"
+		startsOn: date("starts_on").notNull(), // First match day seen
+		endsOn: date("ends_on").notNull(), // Last match day seen
+		createdAt: timestamp("created_at", { withTimezone: true }).notNull().defaultNow(),
+		updatedAt: timestamp("updated_at", { withTimezone: true }).notNull().defaultNow(),
+	},
+	(t) => [unique("seasons_league_ref_name_unique").on(t.leagueRef, t.name)],
+);
+
+// Head-to-head meetings fetched from Goalserve when too few are stored locally
+export const h2hCache = pgTable(
+	"h2h_cache",
+	{
+		id: bigint("id", { mode: "number" }).primaryKey().generatedAlwaysAsIdentity(),
+		sport: varchar("sport", { length: 20 }).notNull(),
+		teamA: bigint("team_a", { mode: "number" }).notNull(), // Lower Goalserve team id of the pair
+		teamB: bigint("team_b", { mode: "number" }).notNull(), // Higher Goalserve team id of the pair
+		meetings: jsonb("meetings").notNull(), // [{ match_id, kickoff_at, home_team_id, ... }] as sent by the feed
+		fetchedAt: timestamp("fetched_at", { withTimezone: true }).notNull().defaultNow(),
+	},
+	(t) => [unique("h2h_cache_sport_team_a_team_b_unique").on(t.sport, t.teamA, t.teamB)],
+);
+
+// Team pairs the API asked for whose Goalserve h2h feed the sync worker has yet to fetch into h2h_cache
+export const h2hRequests = pgTable(
+	"h2h_requests",
+	{
+		id: bigint("id", { mode: "number" }).primaryKey().generatedAlwaysAsIdentity(),
+		sport: varchar("sport", { length: 20 }).notNull(),
+		teamA: bigint("team_a", { mode: "number" }).notNull(), // Lower Goalserve team id of the pair
+		teamB: bigint("team_b", { mode: "number" }).notNull(), // Higher Goalserve team id of the pair
+		requestedAt: timestamp("requested_at", { withTimezone: true }).notNull().defaultNow(),
+	},
+	(t) => [
+		unique("h2h_requests_sport_team_a_team_b_unique").on(t.sport, t.teamA, t.teamB),
+		index("h2h_requests_requested_at_idx").on(t.requestedAt),
+	],
+);
+
+// Elo rating history: one row per team per rated match, the latest row being the current rating
+export const teamRatings = pgTable(
+	"team_ratings",
+	{
+		id: bigint("id", { mode: "number" }).primaryKey().generatedAlwaysAsIdentity(),
+		sport: varchar("sport", { length: 20 }).notNull(),
+		teamRef: bigint("team_ref", { mode: "number" })
+			.notNull()
+			.references(() => teams.id, { onDelete: "cascade" }),
+		opponentRef: bigint("opponent_ref", { mode: "number" })
+			.notNull()
+			.references(() => teams.id, { onDelete: "cascade" }),
+		matchId: bigint("match_id", { mode: "number" }).notNull(), // Goalserve match id
+		kickoffAt: timestamp("kickoff_at", { withTimezone: true }).notNull(),
+		season: varchar("season", { length: 20 }), // Season name of the match, else the team's previous one, e.g. "2026/2027"
+		home: boolean("home").notNull(),
+		ratingBefore: doublePrecision("rating_before").notNull(), // After any season regression
+		rating: doublePrecision("rating").notNull(),
+		createdAt: timestamp("created_at", { withTimezone: true }).notNull().defaultNow(),
+	},
+	(t) => [
+		unique("team_ratings_sport_team_ref_match_id_unique").on(t.sport, t.teamRef, t.matchId),
+		index("team_ratings_sport_kickoff_at_idx").on(t.sport, t.kickoffAt),
+		index("team_ratings_team_ref_kickoff_at_idx").on(t.teamRef, t.kickoffAt),
+	],
+);